@@ -2,6 +2,8 @@ package entrypoints
 
 import (
 	"github.com/flyteorg/datacatalog/pkg/repositories"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
 	"context"
 
@@ -23,7 +25,20 @@ var migrateCmd = &cobra.Command{
 	},
 }
 
+// This looks up the sizes of artifact data stored before sizes were recorded, it is run once after migrating
+var backfillSizesCmd = &cobra.Command{
+	Use:   "backfill-sizes",
+	Short: "This command will look up the sizes of artifact data stored without them in the blob storage",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		// the labeled metrics of the data store panic unless the metric keys are set, like when serving
+		labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
+		return repositories.BackfillArtifactDataSizes(ctx)
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(parentMigrateCmd)
 	parentMigrateCmd.AddCommand(migrateCmd)
+	parentMigrateCmd.AddCommand(backfillSizesCmd)
 }
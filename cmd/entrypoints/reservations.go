@@ -0,0 +1,154 @@
+package entrypoints
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/manager/impl"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
+	"github.com/flyteorg/datacatalog/pkg/repositories"
+	"github.com/flyteorg/datacatalog/pkg/runtime"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/spf13/cobra"
+)
+
+var reservationsScope = promutils.NewScope("reservations")
+
+var releasedBy string
+
+var listReservationsRequest = interfaces.ListReservationsRequest{
+	Pagination: &datacatalog.PaginationOptions{},
+}
+
+var forceReleaseReservationRequest = interfaces.ForceReleaseReservationRequest{
+	ReservationID: &datacatalog.ReservationID{
+		DatasetId: &datacatalog.DatasetID{},
+	},
+}
+
+var parentReservationsCmd = &cobra.Command{
+	Use:   "reservations",
+	Short: "This command inspects the cache reservations of the Flyte Catalog database. Please choose a subcommand.",
+}
+
+// This lists the reservations, including their owner and expiry
+var listReservationsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "This command lists the reservations, who owns them and when they expire",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		reservationManager, notifier, err := newReservationManager(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeNotifier(ctx, notifier)
+
+		response, err := reservationManager.ListReservations(ctx, &listReservationsRequest)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "PROJECT\tDOMAIN\tNAME\tVERSION\tTAG\tOWNER\tEXPIRES AT\tEXPIRES IN")
+		for _, reservation := range response.Reservations {
+			datasetID := reservation.ReservationId.DatasetId
+			expiresAt := reservation.ExpiresAt.AsTime()
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", datasetID.Project, datasetID.Domain,
+				datasetID.Name, datasetID.Version, reservation.ReservationId.TagName, reservation.OwnerId,
+				expiresAt.Format(time.RFC3339), expiresAt.Sub(now).Round(time.Second))
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "\nNext token: %s\n", response.NextToken)
+		return nil
+	},
+}
+
+// This releases a reservation regardless of its owner
+var releaseReservationCmd = &cobra.Command{
+	Use:   "release",
+	Short: "This command releases a reservation regardless of its owner, unblocking tasks waiting for it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// having access to the database grants the same privileges as an administrator
+		ctx := auth.WithAdmin(context.Background(), true)
+		ctx = auth.WithIdentity(ctx, releasedBy)
+		reservationManager, notifier, err := newReservationManager(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeNotifier(ctx, notifier)
+
+		response, err := reservationManager.ForceReleaseReservation(ctx, &forceReleaseReservationRequest)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Released reservation held by %s\n", response.ReleasedOwnerID)
+		return nil
+	},
+}
+
+// Create the reservation manager of the commands. The notifier of the reservation manager is returned to be closed when
+// the command is done.
+func newReservationManager(ctx context.Context) (interfaces.ReservationManager, notifications.Notifier, error) {
+	configProvider := runtime.NewConfigurationProvider()
+	dataCatalogConfig := configProvider.ApplicationConfiguration().GetDataCatalogConfig()
+	dbConfigValues := configProvider.ApplicationConfiguration().GetDbConfig()
+
+	repos := repositories.GetRepository(ctx, repositories.POSTGRES, *dbConfigValues,
+		dataCatalogConfig.StatementTimeouts, reservationsScope)
+
+	// released reservations are notified to the waiters of the service replicas
+	notifier, err := notifications.NewNotifier(ctx, *dbConfigValues, dataCatalogConfig.PostgresNotifications,
+		reservationsScope.NewSubScope("notifications"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return impl.NewReservationManager(repos, time.Duration(dataCatalogConfig.HeartbeatGracePeriodMultiplier),
+		dataCatalogConfig.MaxReservationHeartbeat.Duration, time.Now, notifier, dataCatalogConfig.MaxArtifactWait.Duration,
+		dataCatalogConfig.QueueReservationWaiters, reservationsScope.NewSubScope("reservation")), notifier, nil
+}
+
+func closeNotifier(ctx context.Context, notifier notifications.Notifier) {
+	if err := notifier.Close(); err != nil {
+		logger.Warnf(ctx, "Failed to close notifier, err: %v", err)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(parentReservationsCmd)
+	parentReservationsCmd.AddCommand(listReservationsCmd)
+
+	flags := listReservationsCmd.Flags()
+	flags.StringVar(&listReservationsRequest.Project, "project", "", "Only list reservations of datasets in this project")
+	flags.StringVar(&listReservationsRequest.Domain, "domain", "", "Only list reservations of datasets in this domain")
+	flags.StringVar(&listReservationsRequest.DatasetName, "name", "", "Only list reservations of datasets with this name")
+	flags.StringVar(&listReservationsRequest.OwnerID, "owner", "", "Only list reservations held by this owner")
+	flags.StringVar((*string)(&listReservationsRequest.State), "state", "", "Only list reservations in this state, either active or expired")
+	flags.Uint32Var(&listReservationsRequest.Pagination.Limit, "limit", 50, "Maximum number of reservations to list")
+	flags.StringVar(&listReservationsRequest.Pagination.Token, "token", "", "Token of the page of reservations to list")
+
+	parentReservationsCmd.AddCommand(releaseReservationCmd)
+
+	datasetID := forceReleaseReservationRequest.ReservationID.DatasetId
+	flags = releaseReservationCmd.Flags()
+	flags.StringVar(&datasetID.Project, "project", "", "Project of the dataset of the reservation")
+	flags.StringVar(&datasetID.Domain, "domain", "", "Domain of the dataset of the reservation")
+	flags.StringVar(&datasetID.Name, "name", "", "Name of the dataset of the reservation")
+	flags.StringVar(&datasetID.Version, "version", "", "Version of the dataset of the reservation")
+	flags.StringVar(&forceReleaseReservationRequest.ReservationID.TagName, "tag", "", "Tag name of the reservation")
+	flags.IntVar(&forceReleaseReservationRequest.Slot, "slot", 0, "Slot of the reservation if it has a capacity of more than one")
+	flags.StringVar(&forceReleaseReservationRequest.Reason, "reason", "", "Why the reservation is released")
+	flags.StringVar(&releasedBy, "released-by", os.Getenv("USER"), "Who releases the reservation")
+}
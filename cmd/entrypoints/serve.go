@@ -2,6 +2,9 @@ package entrypoints
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/flyteorg/datacatalog/pkg/config"
 	"github.com/flyteorg/datacatalog/pkg/rpc/datacatalogservice"
@@ -18,17 +21,11 @@ var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Launches the Data Catalog server",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		// shut down gracefully on termination, draining the requests in flight
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 		cfg := config.GetConfig()
 
-		// serve a http healthcheck endpoint
-		go func() {
-			err := datacatalogservice.ServeHTTPHealthCheck(ctx, cfg)
-			if err != nil {
-				logger.Errorf(ctx, "Unable to serve http", config.GetConfig().GetHTTPHostAddress(), err)
-			}
-		}()
-
 		// Serve profiling endpoint.
 		dataCatalogConfig := runtime.NewConfigurationProvider().ApplicationConfiguration().GetDataCatalogConfig()
 		go func() {
@@ -42,6 +39,10 @@ var serveCmd = &cobra.Command{
 		// Set Keys
 		labeled.SetMetricKeys(contextutils.AppNameKey, contextutils.ProjectKey, contextutils.DomainKey)
 
+		// the http healthcheck, readiness endpoint and JSON API are served alongside the gRPC server until terminated
+		if cfg.Secure {
+			return datacatalogservice.ServeSecure(ctx, cfg)
+		}
 		return datacatalogservice.ServeInsecure(ctx, cfg)
 	},
 }
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// The API key of a service account
+const APIKeyHeader = "datacatalog-api-key"
+
+// APIKeyAuthenticator authenticates service accounts by their static API key. Only the SHA-256 hashes of the keys are
+// configured, so that the keys can't be read from the configuration.
+type APIKeyAuthenticator struct {
+	// The identities of the service accounts by the hex encoded hashes of their keys
+	identities map[string]string
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+
+	values := md.Get(APIKeyHeader)
+	if len(values) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	hash := sha256.Sum256([]byte(values[0]))
+	identity, ok := a.identities[hex.EncodeToString(hash[:])]
+	if !ok {
+		return "", fmt.Errorf("unknown API key")
+	}
+	return identity, nil
+}
+
+// NewAPIKeyAuthenticator creates an authenticator for the service accounts, given by their identity and the hex
+// encoded SHA-256 hash of their key.
+func NewAPIKeyAuthenticator(keyHashes map[string]string) *APIKeyAuthenticator {
+	identities := make(map[string]string, len(keyHashes))
+	for identity, keyHash := range keyHashes {
+		identities[strings.ToLower(keyHash)] = identity
+	}
+	return &APIKeyAuthenticator{identities: identities}
+}
+
+// LoadAPIKeyHashes loads the key hashes of service accounts from a file with a "<identity>:<hex encoded SHA-256 hash
+// of the key>" line per service account. Empty lines and lines starting with # are ignored.
+func LoadAPIKeyHashes(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keyHashes := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		identity, keyHash, found := strings.Cut(line, ":")
+		if decoded, err := hex.DecodeString(keyHash); !found || identity == "" || err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("invalid API key in line %d of %s", lineNumber, path)
+		}
+		keyHashes[identity] = keyHash
+	}
+	return keyHashes, scanner.Err()
+}
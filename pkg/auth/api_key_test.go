@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "api-keys")
+	assert.NoError(t, os.WriteFile(keysFile, []byte("# service accounts\n\nflytepropeller:"+hashAPIKey("secret")+"\n"), 0600))
+
+	keyHashes, err := LoadAPIKeyHashes(keysFile)
+	assert.NoError(t, err)
+	authenticator := NewAPIKeyAuthenticator(keyHashes)
+
+	t.Run("valid key", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, "secret"))
+		identity, err := authenticator.Authenticate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "flytepropeller", identity)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, "guess"))
+		_, err := authenticator.Authenticate(ctx)
+		assert.Error(t, err)
+		assert.NotEqual(t, ErrNoCredentials, err)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs())
+		_, err := authenticator.Authenticate(ctx)
+		assert.Equal(t, ErrNoCredentials, err)
+	})
+}
+
+func TestLoadAPIKeyHashes(t *testing.T) {
+	keysFile := filepath.Join(t.TempDir(), "api-keys")
+	assert.NoError(t, os.WriteFile(keysFile, []byte("flytepropeller:secret\n"), 0600))
+
+	_, err := LoadAPIKeyHashes(keysFile)
+	assert.EqualError(t, err, "invalid API key in line 1 of "+keysFile)
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoCredentials is returned by authenticators if the request carries none of the credentials they verify, so that
+// the next authenticator of the chain is tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator verifies the credentials of a request and returns the identity of the caller.
+type Authenticator interface {
+	// Authenticate returns the identity of the caller, ErrNoCredentials if the request carries none of the credentials
+	// of the authenticator or another error if the credentials are invalid.
+	Authenticate(ctx context.Context) (string, error)
+}
+
+// Requests of these methods are served without authentication, so that e.g. the health of the server can be probed
+var unauthenticatedMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+}
+
+// AuthenticationInterceptor authenticates requests with the first authenticator of the chain whose credentials they
+// carry and stores the identity of the caller in the request context. Requests with invalid credentials are rejected,
+// requests without credentials only if authentication is required.
+func AuthenticationInterceptor(required bool, authenticators ...Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for _, prefix := range unauthenticatedMethodPrefixes {
+			if strings.HasPrefix(info.FullMethod, prefix) {
+				return handler(ctx, req)
+			}
+		}
+
+		for _, authenticator := range authenticators {
+			identity, err := authenticator.Authenticate(ctx)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				logger.Infof(ctx, "Rejected request to %s with invalid credentials, err: %v", info.FullMethod, err)
+				return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+			}
+
+			return handler(WithIdentity(ctx, identity), req)
+		}
+
+		if required {
+			logger.Infof(ctx, "Rejected request to %s without credentials", info.FullMethod)
+			return nil, status.Error(codes.Unauthenticated, "missing credentials")
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type staticAuthenticator struct {
+	identity string
+	err      error
+}
+
+func (a staticAuthenticator) Authenticate(context.Context) (string, error) {
+	return a.identity, a.err
+}
+
+func TestAuthenticationInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/CreateArtifact"}
+	noCredentials := staticAuthenticator{err: ErrNoCredentials}
+
+	t.Run("first authenticator with credentials", func(t *testing.T) {
+		interceptor := AuthenticationInterceptor(true, noCredentials, staticAuthenticator{identity: "svc"}, staticAuthenticator{identity: "other"})
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, "svc", IdentityFromContext(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		interceptor := AuthenticationInterceptor(false, staticAuthenticator{err: fmt.Errorf("expired")}, staticAuthenticator{identity: "svc"})
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Fail(t, "request with invalid credentials was served")
+			return nil, nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		interceptor := AuthenticationInterceptor(true, noCredentials)
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Fail(t, "request without credentials was served")
+			return nil, nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("optional credentials", func(t *testing.T) {
+		interceptor := AuthenticationInterceptor(false, noCredentials)
+		served := false
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			served = true
+			assert.Empty(t, IdentityFromContext(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, served)
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		interceptor := AuthenticationInterceptor(true, noCredentials)
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientCertificateAuthenticator authenticates callers by the verified client certificate of their mutual TLS
+// connection.
+type ClientCertificateAuthenticator struct{}
+
+func (ClientCertificateAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	identity := CertificateIdentity(tlsInfo.State.VerifiedChains[0][0])
+	if identity == "" {
+		return "", ErrNoCredentials
+	}
+	return identity, nil
+}
+
+// CertificateIdentity returns the identity of a client certificate, which is its common name or, if unset, its first
+// URI or DNS subject alternative name.
+func CertificateIdentity(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
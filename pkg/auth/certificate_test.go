@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientCertificateAuthenticator(t *testing.T) {
+	authenticator := ClientCertificateAuthenticator{}
+
+	t.Run("verified certificate", func(t *testing.T) {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "flytepropeller"}}
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+
+		identity, err := authenticator.Authenticate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "flytepropeller", identity)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+		_, err := authenticator.Authenticate(ctx)
+		assert.Equal(t, ErrNoCredentials, err)
+	})
+
+	t.Run("insecure connection", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background())
+		assert.Equal(t, ErrNoCredentials, err)
+	})
+}
+
+func TestCertificateIdentity(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://cluster.local/ns/flyte/sa/flytepropeller")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "propeller", CertificateIdentity(&x509.Certificate{
+		Subject: pkix.Name{CommonName: "propeller"},
+		URIs:    []*url.URL{spiffeID},
+	}))
+	assert.Equal(t, spiffeID.String(), CertificateIdentity(&x509.Certificate{URIs: []*url.URL{spiffeID}}))
+	assert.Equal(t, "propeller.flyte.svc", CertificateIdentity(&x509.Certificate{DNSNames: []string{"propeller.flyte.svc"}}))
+	assert.Empty(t, CertificateIdentity(&x509.Certificate{}))
+}
@@ -0,0 +1,35 @@
+// Package auth authenticates the callers of requests and carries their identity and privileges in the request context.
+package auth
+
+import (
+	"context"
+)
+
+type contextKey string
+
+const (
+	adminKey    contextKey = "admin"
+	identityKey contextKey = "identity"
+)
+
+// WithAdmin marks the caller of the request as an administrator, allowing privileged operations.
+func WithAdmin(ctx context.Context, admin bool) context.Context {
+	return context.WithValue(ctx, adminKey, admin)
+}
+
+// IsAdmin returns whether the caller of the request is an administrator.
+func IsAdmin(ctx context.Context) bool {
+	admin, ok := ctx.Value(adminKey).(bool)
+	return ok && admin
+}
+
+// WithIdentity sets the identity of the caller of the request.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// IdentityFromContext returns the identity of the caller of the request, empty if unknown.
+func IdentityFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(identityKey).(string)
+	return identity
+}
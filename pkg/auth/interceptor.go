@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// Marks the caller as an administrator if set to true
+	AdminHeader = "datacatalog-admin"
+	// The identity of the caller
+	IdentityHeader = "datacatalog-identity"
+)
+
+// AdminHeaderInterceptor trusts the admin and identity headers of incoming requests and stores them in the request
+// context. The headers are not authenticated, so the interceptor must only be used if all clients able to reach the
+// server are trusted.
+func AdminHeaderInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if values := md.Get(AdminHeader); len(values) > 0 {
+			if admin, err := strconv.ParseBool(values[0]); err == nil {
+				ctx = WithAdmin(ctx, admin)
+			}
+		}
+
+		if values := md.Get(IdentityHeader); len(values) > 0 {
+			ctx = WithIdentity(ctx, values[0])
+		}
+
+		return handler(ctx, req)
+	}
+}
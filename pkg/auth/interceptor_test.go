@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAdminHeaderInterceptor(t *testing.T) {
+	interceptor := AdminHeaderInterceptor()
+
+	t.Run("admin", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AdminHeader, "true", IdentityHeader, "ops@example.com"))
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.True(t, IsAdmin(ctx))
+			assert.Equal(t, "ops@example.com", IdentityFromContext(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.False(t, IsAdmin(ctx))
+			assert.Empty(t, IdentityFromContext(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid admin header", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AdminHeader, "yes please"))
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.False(t, IsAdmin(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+// Only asymmetric algorithms are accepted, so that tokens can't be signed with the public keys
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// JWTAuthenticator authenticates callers by the bearer JSON Web Token of the authorization header, verified with
+// public keys loaded from a JSON Web Key Set or PEM files.
+type JWTAuthenticator struct {
+	// The public keys by their key ID
+	keys          map[string]crypto.PublicKey
+	issuer        string
+	audience      string
+	identityClaim string
+	parser        *jwt.Parser
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 || !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+		return "", ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(values[0][len(bearerPrefix):], claims, a.getKey); err != nil {
+		return "", err
+	}
+
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return "", fmt.Errorf("unexpected issuer %v", claims["iss"])
+	}
+	if a.audience != "" && !claims.VerifyAudience(a.audience, true) {
+		return "", fmt.Errorf("unexpected audience %v", claims["aud"])
+	}
+
+	identity, ok := claims[a.identityClaim].(string)
+	if !ok || identity == "" {
+		return "", fmt.Errorf("missing identity claim %s", a.identityClaim)
+	}
+	return identity, nil
+}
+
+// Get the key to verify the token with, identified by the key ID of the token unless there is only a single key
+func (a *JWTAuthenticator) getKey(token *jwt.Token) (interface{}, error) {
+	var key crypto.PublicKey
+	if kid, ok := token.Header["kid"].(string); ok {
+		key, ok = a.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %s", kid)
+		}
+	} else if len(a.keys) == 1 {
+		for _, singleKey := range a.keys {
+			key = singleKey
+		}
+	} else {
+		return nil, fmt.Errorf("missing key ID")
+	}
+
+	// the algorithm of the token must match the type of the key
+	var matches bool
+	switch key.(type) {
+	case *rsa.PublicKey:
+		_, matches = token.Method.(*jwt.SigningMethodRSA)
+		if !matches {
+			_, matches = token.Method.(*jwt.SigningMethodRSAPSS)
+		}
+	case *ecdsa.PublicKey:
+		_, matches = token.Method.(*jwt.SigningMethodECDSA)
+	case ed25519.PublicKey:
+		_, matches = token.Method.(*jwt.SigningMethodEd25519)
+	}
+	if !matches {
+		return nil, fmt.Errorf("algorithm %s does not match the key", token.Method.Alg())
+	}
+	return key, nil
+}
+
+// NewJWTAuthenticator creates an authenticator verifying tokens with the public keys, identified by their key ID. The
+// issuer and audience of the tokens are only verified if set, the identity of the caller is taken from the identity
+// claim.
+func NewJWTAuthenticator(keys map[string]crypto.PublicKey, issuer, audience, identityClaim string) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		keys:          keys,
+		issuer:        issuer,
+		audience:      audience,
+		identityClaim: identityClaim,
+		parser:        jwt.NewParser(jwt.WithValidMethods(jwtSigningMethods)),
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// LoadJWKS loads the signature keys of a JSON Web Key Set file by their key ID
+func LoadJWKS(path string) (map[string]crypto.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS %s: %w", path, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %s in JWKS %s: %w", jwk.Kid, path, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+// LoadPublicKeys loads PEM encoded public keys, identified by the name of their file without its extension
+func LoadPublicKeys(paths []string) (map[string]crypto.PublicKey, error) {
+	keys := make(map[string]crypto.PublicKey, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data found in %s", path)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", path, err)
+		}
+
+		keys[strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))] = key
+	}
+	return keys, nil
+}
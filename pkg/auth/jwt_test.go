@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func bearerContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Bearer "+token))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "EC", "kid": "ec", "use": "sig", "crv": "P-256", "x": encodeBigInt(ecKey.X), "y": encodeBigInt(ecKey.Y)},
+			{"kty": "RSA", "kid": "rsa", "n": encodeBigInt(rsaKey.N), "e": encodeBigInt(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "RSA", "kid": "encryption", "use": "enc", "n": encodeBigInt(rsaKey.N), "e": "AQAB"},
+		},
+	})
+	assert.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, jwks, 0600))
+
+	keys, err := LoadJWKS(jwksFile)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	authenticator := NewJWTAuthenticator(keys, "https://issuer.example.com", "datacatalog", "sub")
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "flytepropeller",
+			"iss": "https://issuer.example.com",
+			"aud": "datacatalog",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	t.Run("valid tokens", func(t *testing.T) {
+		identity, err := authenticator.Authenticate(bearerContext(signToken(t, jwt.SigningMethodES256, ecKey, "ec", validClaims())))
+		assert.NoError(t, err)
+		assert.Equal(t, "flytepropeller", identity)
+
+		identity, err = authenticator.Authenticate(bearerContext(signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())))
+		assert.NoError(t, err)
+		assert.Equal(t, "flytepropeller", identity)
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		expired := validClaims()
+		expired["exp"] = time.Now().Add(-time.Minute).Unix()
+		otherIssuer := validClaims()
+		otherIssuer["iss"] = "https://attacker.example.com"
+		otherAudience := validClaims()
+		otherAudience["aud"] = "flyteadmin"
+		noIdentity := validClaims()
+		delete(noIdentity, "sub")
+
+		for name, token := range map[string]string{
+			"expired":         signToken(t, jwt.SigningMethodES256, ecKey, "ec", expired),
+			"other issuer":    signToken(t, jwt.SigningMethodES256, ecKey, "ec", otherIssuer),
+			"other audience":  signToken(t, jwt.SigningMethodES256, ecKey, "ec", otherAudience),
+			"no identity":     signToken(t, jwt.SigningMethodES256, ecKey, "ec", noIdentity),
+			"unknown key":     signToken(t, jwt.SigningMethodES256, ecKey, "unknown", validClaims()),
+			"missing key ID":  signToken(t, jwt.SigningMethodES256, ecKey, "", validClaims()),
+			"wrong algorithm": signToken(t, jwt.SigningMethodRS256, rsaKey, "ec", validClaims()),
+			"symmetric":       signToken(t, jwt.SigningMethodHS256, []byte("secret"), "ec", validClaims()),
+			"malformed":       "not-a-token",
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := authenticator.Authenticate(bearerContext(token))
+				assert.Error(t, err)
+				assert.NotEqual(t, ErrNoCredentials, err)
+			})
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Basic dXNlcjpwYXNz"))
+		_, err := authenticator.Authenticate(ctx)
+		assert.Equal(t, ErrNoCredentials, err)
+	})
+}
+
+func TestLoadPublicKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "issuer.pem")
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+	keys, err := LoadPublicKeys([]string{keyFile})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]crypto.PublicKey{"issuer": &ecKey.PublicKey}, keys)
+
+	// a single key verifies tokens without key ID, issuer and audience are optional
+	authenticator := NewJWTAuthenticator(keys, "", "", "email")
+	identity, err := authenticator.Authenticate(bearerContext(signToken(t, jwt.SigningMethodES256, ecKey, "", jwt.MapClaims{
+		"email": "ops@example.com",
+	})))
+	assert.NoError(t, err)
+	assert.Equal(t, "ops@example.com", identity)
+}
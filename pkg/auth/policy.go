@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/ghodss/yaml"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Operation is a kind of access to the datasets of a project and domain
+type Operation string
+
+const (
+	// Get, list and search datasets and artifacts and wait for reserved artifacts
+	OperationRead Operation = "read"
+	// Create and update datasets and artifacts
+	OperationWrite Operation = "write"
+	// Tag artifacts
+	OperationTag Operation = "tag"
+	// Acquire, extend, release and list reservations
+	OperationReserve Operation = "reserve"
+	// Force the release of reservations held by other owners
+	OperationAdmin Operation = "admin"
+	// Matches all operations in policy rules
+	operationAny Operation = "*"
+)
+
+// PolicyRule allows the subjects to perform the operations on the datasets of the projects and domains. Subjects,
+// projects and domains are matched as patterns, e.g. "team-a-*".
+type PolicyRule struct {
+	Subjects   []string    `json:"subjects"`
+	Projects   []string    `json:"projects"`
+	Domains    []string    `json:"domains"`
+	Operations []Operation `json:"operations"`
+}
+
+// Policy authorizes the operations of callers by their identity. Operations are denied unless a rule allows them, and
+// are always denied for unauthenticated callers.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// Allows returns whether the policy allows the subject to perform the operation on the datasets of the project and
+// domain.
+func (p *Policy) Allows(subject, project, domain string, operation Operation) bool {
+	if subject == "" {
+		return false
+	}
+
+	for _, rule := range p.Rules {
+		if matchesAny(rule.Subjects, subject) && matchesAny(rule.Projects, project) && matchesAny(rule.Domains, domain) &&
+			allowsOperation(rule.Operations, operation) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func allowsOperation(operations []Operation, operation Operation) bool {
+	for _, allowed := range operations {
+		if allowed == operation || allowed == operationAny {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPolicy loads the rules of a policy from a YAML or JSON file
+func LoadPolicy(file string) (*Policy, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{}
+	if err := yaml.Unmarshal(raw, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", file, err)
+	}
+
+	for i, rule := range policy.Rules {
+		for _, pattern := range append(append(append([]string{}, rule.Subjects...), rule.Projects...), rule.Domains...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in rule %d of policy %s", pattern, i, file)
+			}
+		}
+		for _, operation := range rule.Operations {
+			switch operation {
+			case OperationRead, OperationWrite, OperationTag, OperationReserve, OperationAdmin, operationAny:
+			default:
+				return nil, fmt.Errorf("invalid operation %q in rule %d of policy %s", operation, i, file)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Resource is the project and domain a request accesses with the operations it performs
+type Resource struct {
+	Project    string
+	Domain     string
+	Operations []Operation
+}
+
+// ResourceFunc returns the resource accessed by a request, false if the request does not access any
+type ResourceFunc func(req interface{}) (Resource, bool)
+
+// AuthorizationInterceptor authorizes the operations of requests with the policy before serving them. Callers allowed
+// to administrate the project and domain are marked as administrators, all others are not. Denied requests are counted by the counter.
+func AuthorizationInterceptor(policy *Policy, getResource ResourceFunc, deniedCounter labeled.Counter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resource, ok := getResource(req)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		identity := IdentityFromContext(ctx)
+		for _, operation := range resource.Operations {
+			if !policy.Allows(identity, resource.Project, resource.Domain, operation) {
+				logger.Infof(ctx, "Denied %s access of %q to project %q and domain %q in %s", operation, identity,
+					resource.Project, resource.Domain, info.FullMethod)
+				deniedCounter.Inc(contextutils.WithProjectDomain(ctx, resource.Project, resource.Domain))
+				return nil, status.Errorf(codes.PermissionDenied, "%s access to project %q and domain %q denied",
+					operation, resource.Project, resource.Domain)
+			}
+		}
+
+		// the policy takes precedence over the admin header
+		ctx = WithAdmin(ctx, policy.Allows(identity, resource.Project, resource.Domain, OperationAdmin))
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.AppNameKey)
+}
+
+const testPolicy = `
+rules:
+  # teams may write their own projects
+  - subjects: ["team-a-*"]
+    projects: ["team-a"]
+    domains: ["*"]
+    operations: [read, write, tag, reserve]
+  # everybody may read production caches
+  - subjects: ["*"]
+    projects: ["*"]
+    domains: ["production"]
+    operations: [read]
+  - subjects: ["ops@example.com"]
+    projects: ["*"]
+    domains: ["*"]
+    operations: ["*"]
+`
+
+func loadTestPolicy(t *testing.T, content string) (*Policy, error) {
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NoError(t, os.WriteFile(policyFile, []byte(content), 0600))
+	return LoadPolicy(policyFile)
+}
+
+func TestPolicy(t *testing.T) {
+	policy, err := loadTestPolicy(t, testPolicy)
+	assert.NoError(t, err)
+
+	assert.True(t, policy.Allows("team-a-pipeline", "team-a", "development", OperationWrite))
+	assert.True(t, policy.Allows("team-a-pipeline", "team-b", "production", OperationRead))
+	assert.False(t, policy.Allows("team-a-pipeline", "team-b", "production", OperationWrite))
+	assert.False(t, policy.Allows("team-a-pipeline", "team-b", "development", OperationRead))
+	assert.False(t, policy.Allows("team-a-pipeline", "team-a", "development", OperationAdmin))
+	assert.True(t, policy.Allows("ops@example.com", "team-b", "development", OperationAdmin))
+	// unauthenticated callers match no rule
+	assert.False(t, policy.Allows("", "team-b", "production", OperationRead))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	_, err := loadTestPolicy(t, `{"rules": [{"subjects": ["*"], "projects": ["*"], "domains": ["*"], "operations": ["delete"]}]}`)
+	assert.Error(t, err)
+
+	_, err = loadTestPolicy(t, `{"rules": [{"subjects": ["[a-"], "projects": ["*"], "domains": ["*"], "operations": ["read"]}]}`)
+	assert.Error(t, err)
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	policy, err := loadTestPolicy(t, testPolicy)
+	assert.NoError(t, err)
+
+	getResource := func(req interface{}) (Resource, bool) {
+		resource, ok := req.(Resource)
+		return resource, ok
+	}
+	interceptor := AuthorizationInterceptor(policy, getResource,
+		labeled.NewCounter("denied", "The number of denied requests", promutils.NewTestScope()))
+	info := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/CreateArtifact"}
+
+	t.Run("allowed", func(t *testing.T) {
+		ctx := WithAdmin(WithIdentity(context.Background(), "team-a-pipeline"), true)
+		req := Resource{Project: "team-a", Domain: "development", Operations: []Operation{OperationWrite, OperationTag}}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			// the admin header is overridden by the policy
+			assert.False(t, IsAdmin(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		ctx := WithIdentity(context.Background(), "team-a-pipeline")
+		req := Resource{Project: "team-b", Domain: "production", Operations: []Operation{OperationRead, OperationWrite}}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Fail(t, "denied request was served")
+			return nil, nil
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		ctx := WithIdentity(context.Background(), "ops@example.com")
+		req := Resource{Project: "team-b", Domain: "production", Operations: []Operation{OperationAdmin}}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.True(t, IsAdmin(ctx))
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no resource", func(t *testing.T) {
+		_, err := interceptor(context.Background(), "health check", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		assert.NoError(t, err)
+	})
+}
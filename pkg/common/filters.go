@@ -14,6 +14,11 @@ const (
 	Dataset   Entity = "Dataset"
 	Partition Entity = "Partition"
 	Tag       Entity = "Tag"
+
+	Reservation Entity = "Reservation"
+
+	ArtifactMetadata Entity = "ArtifactMetadata"
+	DatasetMetadata  Entity = "DatasetMetadata"
 )
 
 // Supported operators that can be used on filters
@@ -21,5 +26,22 @@ type ComparisonOperator int
 
 const (
 	Equal ComparisonOperator = iota
+	GreaterThanOrEqual
+	LessThan
 	// Add more operators as needed, ie., gte, lte
 )
+
+// Timestamp properties of entities which can be filtered by a time range
+const (
+	CreatedAt = "created_at"
+	UpdatedAt = "updated_at"
+)
+
+// A time range filter on a timestamp property of an entity. The bounds are RFC3339 timestamps, the start is inclusive
+// and the end is exclusive. Either bound may be empty to leave that side of the range open.
+type TimeRangeFilter struct {
+	Entity   Entity `json:"entity"`
+	Property string `json:"property"`
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/config"
 )
@@ -11,11 +12,55 @@ const SectionKey = "application"
 //go:generate pflags Config
 
 type Config struct {
-	GrpcPort                 int  `json:"grpcPort" pflag:",On which grpc port to serve Catalog"`
-	GrpcServerReflection     bool `json:"grpcServerReflection" pflag:",Enable GRPC Server Reflection"`
-	HTTPPort                 int  `json:"httpPort" pflag:",On which http port to serve Catalog"`
-	Secure                   bool `json:"secure" pflag:",Whether to run Catalog in secure mode or not"`
-	ReadHeaderTimeoutSeconds int  `json:"readHeaderTimeoutSeconds" pflag:",The amount of time allowed to read request headers."`
+	GrpcPort                 int             `json:"grpcPort" pflag:",On which grpc port to serve Catalog"`
+	GrpcServerReflection     bool            `json:"grpcServerReflection" pflag:",Enable GRPC Server Reflection"`
+	HTTPPort                 int             `json:"httpPort" pflag:",On which http port to serve Catalog"`
+	Secure                   bool            `json:"secure" pflag:",Whether to run Catalog in secure mode or not"`
+	ReadHeaderTimeoutSeconds int             `json:"readHeaderTimeoutSeconds" pflag:",The amount of time allowed to read request headers."`
+	TrustAdminHeader         bool            `json:"trustAdminHeader" pflag:",Trust the unauthenticated admin and identity headers of requests. Only enable if all clients are trusted."`
+	TLS                      TLSConfig       `json:"tls" pflag:",The TLS configuration of the grpc server, used in secure mode."`
+	Auth                     AuthConfig      `json:"auth" pflag:",The authentication configuration of the grpc server."`
+	Readiness                ReadinessConfig `json:"readiness" pflag:",The configuration of the readiness checks of the database and the blob storage."`
+	ShutdownTimeout          config.Duration `json:"shutdownTimeout" pflag:",How long requests in flight are drained on shutdown before they are cancelled. Should be shorter than the termination grace period of the pod."`
+	Tracing                  TracingConfig   `json:"tracing" pflag:",The configuration of the OpenTelemetry traces of requests."`
+}
+
+// TracingConfig configures where the spans of requests, repo calls and storage reads are exported to. Spans are not
+// recorded with the none exporter.
+type TracingConfig struct {
+	Exporter         string `json:"exporter" pflag:",Where spans are exported to, one of none, stdout or otlp."`
+	OTLPEndpoint     string `json:"otlpEndpoint" pflag:",The host:port of the OTLP gRPC collector spans are exported to by the otlp exporter."`
+	OTLPInsecure     bool   `json:"otlpInsecure" pflag:",Export spans to the OTLP collector without TLS."`
+	SamplePercentage int    `json:"samplePercentage" pflag:",The percentage of requests traced, unless the caller already decided whether to trace them."`
+}
+
+// ReadinessConfig configures the periodic checks of the dependencies, reported by the grpc health service and the
+// /readyz http endpoint. The /healthcheck endpoint reports liveness and does not depend on them.
+type ReadinessConfig struct {
+	Interval config.Duration `json:"interval" pflag:",How often the dependencies are checked."`
+	Timeout  config.Duration `json:"timeout" pflag:",How long a check may take before the dependency is considered unavailable."`
+}
+
+// AuthConfig configures how callers are authenticated. Callers may present a bearer token, an API key or, with mutual
+// TLS, a client certificate. Requests with invalid credentials are always rejected.
+type AuthConfig struct {
+	Required       bool     `json:"required" pflag:",Reject requests without credentials."`
+	JWKSFile       string   `json:"jwksFile" pflag:",Path to a JSON Web Key Set with the public keys to verify bearer tokens with."`
+	PublicKeyFiles []string `json:"publicKeyFiles" pflag:",Paths to PEM encoded public keys to verify bearer tokens with, identified by their file name without extension as key ID."`
+	Issuer         string   `json:"issuer" pflag:",The issuer bearer tokens must have, not verified if empty."`
+	Audience       string   `json:"audience" pflag:",The audience bearer tokens must have, not verified if empty."`
+	IdentityClaim  string   `json:"identityClaim" pflag:",The claim of bearer tokens holding the identity of the caller."`
+	APIKeysFile    string   `json:"apiKeysFile" pflag:",Path to a file with a '<identity>:<hex encoded SHA-256 hash of the key>' line per service account."`
+	PolicyFile     string   `json:"policyFile" pflag:",Path to the policy authorizing the operations of callers on projects and domains. Requests are not authorized if empty."`
+}
+
+// TLSConfig configures the certificates of the grpc server in secure mode. The files are checked for changes
+// periodically and reloaded, so that rotated certificates are picked up without restarting the server.
+type TLSConfig struct {
+	CertFile       string          `json:"certFile" pflag:",Path to the PEM encoded certificate of the server."`
+	KeyFile        string          `json:"keyFile" pflag:",Path to the PEM encoded private key of the server."`
+	ClientCAFile   string          `json:"clientCAFile" pflag:",Path to the PEM encoded CA certificates to verify client certificates with. Enables mutual TLS if set."`
+	ReloadInterval config.Duration `json:"reloadInterval" pflag:",How often the certificate files are checked for changes."`
 }
 
 var defaultConfig = &Config{
@@ -26,6 +71,22 @@ var defaultConfig = &Config{
 	// https://deepsource.io/directory/analyzers/go/issues/GO-S2114
 	// just shy of requestTimeoutUpperBound
 	ReadHeaderTimeoutSeconds: 32,
+	TLS: TLSConfig{
+		ReloadInterval: config.Duration{Duration: time.Minute},
+	},
+	Auth: AuthConfig{
+		IdentityClaim: "sub",
+	},
+	Readiness: ReadinessConfig{
+		Interval: config.Duration{Duration: 10 * time.Second},
+		Timeout:  config.Duration{Duration: 5 * time.Second},
+	},
+	ShutdownTimeout: config.Duration{Duration: 20 * time.Second},
+	Tracing: TracingConfig{
+		Exporter:         "none",
+		OTLPEndpoint:     "localhost:4317",
+		SamplePercentage: 100,
+	},
 }
 var applicationConfig = config.MustRegisterSection(SectionKey, defaultConfig)
 
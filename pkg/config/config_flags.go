@@ -55,5 +55,25 @@ func (cfg Config) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "httpPort"), defaultConfig.HTTPPort, "On which http port to serve Catalog")
 	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "secure"), defaultConfig.Secure, "Whether to run Catalog in secure mode or not")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "readHeaderTimeoutSeconds"), defaultConfig.ReadHeaderTimeoutSeconds, "The amount of time allowed to read request headers.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "trustAdminHeader"), defaultConfig.TrustAdminHeader, "Trust the unauthenticated admin and identity headers of requests. Only enable if all clients are trusted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tls.certFile"), defaultConfig.TLS.CertFile, "Path to the PEM encoded certificate of the server.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tls.keyFile"), defaultConfig.TLS.KeyFile, "Path to the PEM encoded private key of the server.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tls.clientCAFile"), defaultConfig.TLS.ClientCAFile, "Path to the PEM encoded CA certificates to verify client certificates with. Enables mutual TLS if set.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tls.reloadInterval"), defaultConfig.TLS.ReloadInterval.String(), "How often the certificate files are checked for changes.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "auth.required"), defaultConfig.Auth.Required, "Reject requests without credentials.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.jwksFile"), defaultConfig.Auth.JWKSFile, "Path to a JSON Web Key Set with the public keys to verify bearer tokens with.")
+	cmdFlags.StringSlice(fmt.Sprintf("%v%v", prefix, "auth.publicKeyFiles"), defaultConfig.Auth.PublicKeyFiles, "Paths to PEM encoded public keys to verify bearer tokens with,  identified by their file name without extension as key ID.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.issuer"), defaultConfig.Auth.Issuer, "The issuer bearer tokens must have,  not verified if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.audience"), defaultConfig.Auth.Audience, "The audience bearer tokens must have,  not verified if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.identityClaim"), defaultConfig.Auth.IdentityClaim, "The claim of bearer tokens holding the identity of the caller.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.apiKeysFile"), defaultConfig.Auth.APIKeysFile, "Path to a file with a '<identity>:<hex encoded SHA-256 hash of the key>' line per service account.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "auth.policyFile"), defaultConfig.Auth.PolicyFile, "Path to the policy authorizing the operations of callers on projects and domains. Requests are not authorized if empty.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "readiness.interval"), defaultConfig.Readiness.Interval.String(), "How often the dependencies are checked.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "readiness.timeout"), defaultConfig.Readiness.Timeout.String(), "How long a check may take before the dependency is considered unavailable.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "shutdownTimeout"), defaultConfig.ShutdownTimeout.String(), "How long requests in flight are drained on shutdown before they are cancelled. Should be shorter than the termination grace period of the pod.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tracing.exporter"), defaultConfig.Tracing.Exporter, "Where spans are exported to,  one of none,  stdout or otlp.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "tracing.otlpEndpoint"), defaultConfig.Tracing.OTLPEndpoint, "The host:port of the OTLP gRPC collector spans are exported to by the otlp exporter.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "tracing.otlpInsecure"), defaultConfig.Tracing.OTLPInsecure, "Export spans to the OTLP collector without TLS.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "tracing.samplePercentage"), defaultConfig.Tracing.SamplePercentage, "The percentage of requests traced,  unless the caller already decided whether to trace them.")
 	return cmdFlags
 }
@@ -169,4 +169,284 @@ func TestConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_trustAdminHeader", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("trustAdminHeader", testValue)
+			if vBool, err := cmdFlags.GetBool("trustAdminHeader"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.TrustAdminHeader)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tls.certFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tls.certFile", testValue)
+			if vString, err := cmdFlags.GetString("tls.certFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TLS.CertFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tls.keyFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tls.keyFile", testValue)
+			if vString, err := cmdFlags.GetString("tls.keyFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TLS.KeyFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tls.clientCAFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tls.clientCAFile", testValue)
+			if vString, err := cmdFlags.GetString("tls.clientCAFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TLS.ClientCAFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tls.reloadInterval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.TLS.ReloadInterval.String()
+
+			cmdFlags.Set("tls.reloadInterval", testValue)
+			if vString, err := cmdFlags.GetString("tls.reloadInterval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.TLS.ReloadInterval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.required", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.required", testValue)
+			if vBool, err := cmdFlags.GetBool("auth.required"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Auth.Required)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.jwksFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.jwksFile", testValue)
+			if vString, err := cmdFlags.GetString("auth.jwksFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.JWKSFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.publicKeyFiles", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := join_Config(defaultConfig.Auth.PublicKeyFiles, ",")
+
+			cmdFlags.Set("auth.publicKeyFiles", testValue)
+			if vStringSlice, err := cmdFlags.GetStringSlice("auth.publicKeyFiles"); err == nil {
+				testDecodeRaw_Config(t, join_Config(vStringSlice, ","), &actual.Auth.PublicKeyFiles)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.issuer", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.issuer", testValue)
+			if vString, err := cmdFlags.GetString("auth.issuer"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.Issuer)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.audience", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.audience", testValue)
+			if vString, err := cmdFlags.GetString("auth.audience"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.Audience)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.identityClaim", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.identityClaim", testValue)
+			if vString, err := cmdFlags.GetString("auth.identityClaim"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.IdentityClaim)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.apiKeysFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.apiKeysFile", testValue)
+			if vString, err := cmdFlags.GetString("auth.apiKeysFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.APIKeysFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_auth.policyFile", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("auth.policyFile", testValue)
+			if vString, err := cmdFlags.GetString("auth.policyFile"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Auth.PolicyFile)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_readiness.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Readiness.Interval.String()
+
+			cmdFlags.Set("readiness.interval", testValue)
+			if vString, err := cmdFlags.GetString("readiness.interval"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Readiness.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_readiness.timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.Readiness.Timeout.String()
+
+			cmdFlags.Set("readiness.timeout", testValue)
+			if vString, err := cmdFlags.GetString("readiness.timeout"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Readiness.Timeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_shutdownTimeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ShutdownTimeout.String()
+
+			cmdFlags.Set("shutdownTimeout", testValue)
+			if vString, err := cmdFlags.GetString("shutdownTimeout"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.ShutdownTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.exporter", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.exporter", testValue)
+			if vString, err := cmdFlags.GetString("tracing.exporter"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Tracing.Exporter)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.otlpEndpoint", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.otlpEndpoint", testValue)
+			if vString, err := cmdFlags.GetString("tracing.otlpEndpoint"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vString), &actual.Tracing.OTLPEndpoint)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.otlpInsecure", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.otlpInsecure", testValue)
+			if vBool, err := cmdFlags.GetBool("tracing.otlpInsecure"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vBool), &actual.Tracing.OTLPInsecure)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_tracing.samplePercentage", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("tracing.samplePercentage", testValue)
+			if vInt, err := cmdFlags.GetInt("tracing.samplePercentage"); err == nil {
+				testDecodeJson_Config(t, fmt.Sprintf("%v", vInt), &actual.Tracing.SamplePercentage)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
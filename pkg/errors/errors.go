@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -45,6 +46,22 @@ func NewDataCatalogErrorf(code codes.Code, format string, a ...interface{}) erro
 	return NewDataCatalogError(code, fmt.Sprintf(format, a...))
 }
 
+// NewQuotaExceededError returns a ResourceExhausted error describing the violated quota of the subject, telling
+// clients that retrying will not succeed before the usage drops below the quota.
+func NewQuotaExceededError(subject, description string) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("quota exceeded for %s: %s", subject, description))
+	detailed, err := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: subject, Description: description}},
+	})
+	if err == nil {
+		st = detailed
+	}
+
+	return &dataCatalogErrorImpl{
+		status: st,
+	}
+}
+
 func NewCollectedErrors(code codes.Code, errors []error) error {
 	errorCollection := make([]string, len(errors))
 	for idx, err := range errors {
@@ -63,3 +80,14 @@ func IsDoesNotExistError(err error) bool {
 	dcErr, ok := err.(DataCatalogError)
 	return ok && dcErr.GRPCStatus().Code() == codes.NotFound
 }
+
+// IsServerError returns whether the catalog failed to serve the request, as opposed to e.g. the request being invalid
+// or the entity not existing, which are expected while serving the catalog
+func IsServerError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -24,6 +25,24 @@ func TestErrorHelpers(t *testing.T) {
 		assert.True(t, IsDoesNotExistError(notFoundErr))
 	})
 
+	t.Run("TestQuotaExceeded", func(t *testing.T) {
+		quotaErr := NewQuotaExceededError("project:p1", "2 of 2 artifacts stored")
+		st := status.Convert(quotaErr)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		assert.Equal(t, "quota exceeded for project:p1: 2 of 2 artifacts stored", st.Message())
+		assert.Len(t, st.Details(), 1)
+		quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+		assert.True(t, ok)
+		assert.Equal(t, "project:p1", quotaFailure.Violations[0].Subject)
+	})
+
+	t.Run("TestServerError", func(t *testing.T) {
+		assert.True(t, IsServerError(NewDataCatalogError(codes.Internal, "failed")))
+		assert.True(t, IsServerError(fmt.Errorf("failed")))
+		assert.False(t, IsServerError(notFoundErr))
+		assert.False(t, IsServerError(nil))
+	})
+
 	t.Run("TestCollectErrs", func(t *testing.T) {
 		collectedErr := NewCollectedErrors(codes.InvalidArgument, []error{alreadyExistsErr, notFoundErr})
 		assert.EqualValues(t, status.Code(collectedErr), codes.InvalidArgument)
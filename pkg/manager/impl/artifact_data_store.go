@@ -8,11 +8,22 @@ import (
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/flyteorg/flytestdlib/storage"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 )
 
 const artifactDataFile = "data.pb"
 
+var tracer = otel.Tracer("github.com/flyteorg/datacatalog/pkg/manager/impl")
+
+// Start the span of a storage call on the object in the location, a child of the span of the request issuing it
+func startStorageSpan(ctx context.Context, operation string, location storage.DataReference) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("storage.location", location.String())))
+}
+
 // ArtifactDataStore stores and retrieves ArtifactData values in a data.pb
 type ArtifactDataStore interface {
 	PutData(ctx context.Context, artifact *datacatalog.Artifact, data *datacatalog.ArtifactData) (storage.DataReference, error)
@@ -36,6 +47,10 @@ func (m *artifactDataStore) PutData(ctx context.Context, artifact *datacatalog.A
 	if err != nil {
 		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to generate data location %s, err %v", dataLocation.String(), err)
 	}
+
+	ctx, span := startStorageSpan(ctx, "artifactDataStore.PutData", dataLocation)
+	defer span.End()
+
 	err = m.store.WriteProtobuf(ctx, dataLocation, storage.Options{}, data.Value)
 	if err != nil {
 		return "", errors.NewDataCatalogErrorf(codes.Internal, "Unable to store artifact data in location %s, err %v", dataLocation.String(), err)
@@ -46,6 +61,9 @@ func (m *artifactDataStore) PutData(ctx context.Context, artifact *datacatalog.A
 
 // Retrieve the literal value of the ArtifactData from its specified location
 func (m *artifactDataStore) GetData(ctx context.Context, dataModel models.ArtifactData) (*core.Literal, error) {
+	ctx, span := startStorageSpan(ctx, "artifactDataStore.GetData", storage.DataReference(dataModel.Location))
+	defer span.End()
+
 	var value core.Literal
 	err := m.store.ReadProtobuf(ctx, storage.DataReference(dataModel.Location), &value)
 	if err != nil {
@@ -57,6 +75,9 @@ func (m *artifactDataStore) GetData(ctx context.Context, dataModel models.Artifa
 
 // DeleteData removes the stored artifact data from the underlying blob storage
 func (m *artifactDataStore) DeleteData(ctx context.Context, dataModel models.ArtifactData) error {
+	ctx, span := startStorageSpan(ctx, "artifactDataStore.DeleteData", storage.DataReference(dataModel.Location))
+	defer span.End()
+
 	if err := m.store.Delete(ctx, storage.DataReference(dataModel.Location)); err != nil {
 		return errors.NewDataCatalogErrorf(codes.Internal, "Unable to delete artifact data in location %s, err %v", dataModel.Location, err)
 	}
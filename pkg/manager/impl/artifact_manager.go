@@ -8,6 +8,7 @@ import (
 	"github.com/flyteorg/datacatalog/pkg/errors"
 	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
 	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	"github.com/flyteorg/datacatalog/pkg/repositories"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type artifactMetrics struct {
@@ -46,11 +48,21 @@ type artifactMetrics struct {
 	updateDataFailureCounter labeled.Counter
 	deleteDataSuccessCounter labeled.Counter
 	deleteDataFailureCounter labeled.Counter
+	searchResponseTime       labeled.StopWatch
+	searchSuccessCounter     labeled.Counter
+	searchFailureCounter     labeled.Counter
+	completeResponseTime     labeled.StopWatch
+	completeSuccessCounter   labeled.Counter
+	completeFailureCounter   labeled.Counter
+	quotaExceededCounter     labeled.Counter
 }
 
 type artifactManager struct {
 	repo          repositories.RepositoryInterface
 	artifactStore ArtifactDataStore
+	notifier      notifications.Notifier
+	handOff       ReservationHandOff
+	quotas        ArtifactQuotas
 	systemMetrics artifactMetrics
 }
 
@@ -88,6 +100,22 @@ func (m *artifactManager) CreateArtifact(ctx context.Context, request *datacatal
 		return nil, err
 	}
 
+	// reject the artifact before storing its data if it was produced by a previous owner of the reservation, the
+	// reservation is checked again when the artifact is created
+	err = checkFencingToken(ctx, m.repo.ReservationRepo(), artifact.Dataset, time.Now())
+	if err != nil {
+		logger.Warnf(ctx, "Rejected artifact %v of stale reservation owner, err: %v", artifact.Id, err)
+		m.systemMetrics.createFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	// the quota is checked before storing the data as well, it is checked again when the artifact is created
+	err = m.checkQuota(ctx, artifact, false)
+	if err != nil {
+		m.systemMetrics.createFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
 	// create Artifact Data offloaded storage files
 	artifactDataModels := make([]models.ArtifactData, len(request.Artifact.Data))
 	for i, artifactData := range request.Artifact.Data {
@@ -100,6 +128,7 @@ func (m *artifactManager) CreateArtifact(ctx context.Context, request *datacatal
 
 		artifactDataModels[i].Name = artifactData.Name
 		artifactDataModels[i].Location = dataLocation.String()
+		artifactDataModels[i].Size = artifactDataSize(artifactData)
 		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
 	}
 
@@ -112,13 +141,35 @@ func (m *artifactManager) CreateArtifact(ctx context.Context, request *datacatal
 		return nil, err
 	}
 
-	err = m.repo.ArtifactRepo().Create(ctx, artifactModel)
+	rejected := false
+	err = m.repo.Transaction(ctx, func(ctx context.Context) error {
+		// the reservation stays locked until the artifact is committed, so that it can't be taken over in between
+		if err := checkFencingToken(ctx, m.repo.ReservationRepo(), artifact.Dataset, time.Now()); err != nil {
+			logger.Warnf(ctx, "Rejected artifact %v of stale reservation owner, err: %v", artifact.Id, err)
+			rejected = true
+			return err
+		}
+		// likewise the usage of the project, so that concurrent artifacts can't exceed its quota
+		if err := m.checkQuota(ctx, artifact, true); err != nil {
+			rejected = true
+			return err
+		}
+		return m.repo.ArtifactRepo().Create(ctx, artifactModel)
+	})
 	if err != nil {
-		if errors.IsAlreadyExistsError(err) {
+		if rejected {
+			m.systemMetrics.createFailureCounter.Inc(ctx)
+			// a rejected artifact may exist already if its creation is retried, its data is stored in the same location
+			if _, getErr := m.repo.ArtifactRepo().Get(ctx, artifactModel.ArtifactKey); errors.IsDoesNotExistError(getErr) {
+				m.deleteArtifactData(ctx, artifactDataModels)
+			}
+		} else if errors.IsAlreadyExistsError(err) {
+			// the data of the existing artifact is stored in the same location and must be kept
 			logger.Warnf(ctx, "Artifact already exists key: %+v, err %v", artifact.Id, err)
 			m.systemMetrics.alreadyExistsCounter.Inc(ctx)
 		} else {
 			logger.Errorf(ctx, "Failed to create artifact %v, err: %v", artifactDataModels, err)
+			m.deleteArtifactData(ctx, artifactDataModels)
 			m.systemMetrics.createFailureCounter.Inc(ctx)
 		}
 		return nil, err
@@ -258,14 +309,35 @@ func (m *artifactManager) ListArtifacts(ctx context.Context, request *datacatalo
 		return nil, err
 	}
 
+	metadataFilters, err := getMetadataFilters(ctx)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid metadata filters in list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
 	// Get the list inputs
-	listInput, err := transformers.FilterToListInput(ctx, common.Artifact, request.GetFilter())
+	listInput, err := transformers.FilterToListInput(ctx, common.Artifact, request.GetFilter(), metadataFilters...)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid list artifact request %v, err: %v", request, err)
 		m.systemMetrics.validationErrorCounter.Inc(ctx)
 		return nil, err
 	}
 
+	timeRangeFilters, err := getTimeRangeFilters(ctx, common.Artifact)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid time range filters in list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = transformers.ApplyTimeRangeFilters(ctx, common.Artifact, timeRangeFilters, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid time range filters in list artifact request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
 	err = transformers.ApplyPagination(request.Pagination, &listInput)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid pagination options in list artifact request %v, err: %v", request, err)
@@ -358,6 +430,7 @@ func (m *artifactManager) UpdateArtifact(ctx context.Context, request *datacatal
 
 		artifactDataModels[i].Name = artifactData.Name
 		artifactDataModels[i].Location = dataLocation.String()
+		artifactDataModels[i].Size = artifactDataSize(artifactData)
 		m.systemMetrics.updateDataSuccessCounter.Inc(ctx)
 	}
 
@@ -405,7 +478,252 @@ func (m *artifactManager) UpdateArtifact(ctx context.Context, request *datacatal
 	}, nil
 }
 
-func NewArtifactManager(repo repositories.RepositoryInterface, store *storage.DataStore, storagePrefix storage.DataReference, artifactScope promutils.Scope) interfaces.ArtifactManager {
+// SearchArtifacts searches artifacts across all datasets of a project and domain, optionally restricted to the versions
+// of a single dataset. The artifacts are returned without their data to avoid reading it from the blob storage.
+func (m *artifactManager) SearchArtifacts(ctx context.Context, request *interfaces.SearchArtifactsRequest) (*interfaces.SearchArtifactsResponse, error) {
+	ctx = contextutils.WithProjectDomain(ctx, request.Project, request.Domain)
+
+	timer := m.systemMetrics.searchResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateSearchArtifactsRequest(request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid search artifacts request %+v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	listInput, err := transformers.FilterToListInput(ctx, common.Artifact, request.Filter, request.MetadataFilters...)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid search artifacts request %+v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = transformers.ApplyTimeRangeFilters(ctx, common.Artifact, request.TimeRangeFilters, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid time range filters in search artifacts request %+v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = transformers.ApplyPagination(request.Pagination, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in search artifacts request %+v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	datasetKey := models.DatasetKey{
+		Project: request.Project,
+		Domain:  request.Domain,
+		Name:    request.DatasetName,
+	}
+	artifactModels, err := m.repo.ArtifactRepo().Search(ctx, datasetKey, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to search Artifacts err: %v", err)
+		m.systemMetrics.searchFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactsList, err := transformers.FromArtifactModels(artifactModels)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to transform Artifacts %+v err: %v", artifactModels, err)
+		m.systemMetrics.searchFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	token := strconv.Itoa(listInput.Offset + len(artifactsList))
+
+	logger.Debugf(ctx, "Found %v matching artifacts successfully", len(artifactsList))
+	m.systemMetrics.searchSuccessCounter.Inc(ctx)
+	return &interfaces.SearchArtifactsResponse{Artifacts: artifactsList, NextToken: token}, nil
+}
+
+// Complete the reservation of the owner by creating the artifact it computed, tagging the artifact and releasing the
+// reservation. The artifact data is stored beforehand and deleted again if the artifact can't be created, while the
+// artifact, its tags and the release are committed in a single transaction, so either all of them or none take effect.
+func (m *artifactManager) CompleteReservation(ctx context.Context, request *interfaces.CompleteReservationRequest) (*interfaces.CompleteReservationResponse, error) {
+	timer := m.systemMetrics.completeResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateCompleteReservationRequest(request)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid complete reservation request %v, err: %v", request, err)
+		m.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifact := request.Artifact
+	ctx = contextutils.WithProjectDomain(ctx, artifact.Dataset.Project, artifact.Dataset.Domain)
+
+	// check that the owner holds the reservation before storing any data for it
+	reservationKey, err := m.findOwnerReservation(ctx, request.ReservationID, request.OwnerID)
+	if err != nil {
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	dataset, err := m.repo.DatasetRepo().Get(ctx, transformers.FromDatasetID(artifact.Dataset))
+	if err != nil {
+		logger.Warnf(ctx, "Failed to get dataset for completing reservation %+v, err: %v", reservationKey, err)
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	datasetPartitionKeys := transformers.FromPartitionKeyModel(dataset.PartitionKeys)
+	err = validators.ValidatePartitions(datasetPartitionKeys, artifact.Partitions)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid artifact partitions %v, err: %+v", artifact.Partitions, err)
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = m.checkQuota(ctx, artifact, false)
+	if err != nil {
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	artifactDataModels := make([]models.ArtifactData, 0, len(artifact.Data))
+	for _, artifactData := range artifact.Data {
+		dataLocation, err := m.artifactStore.PutData(ctx, artifact, artifactData)
+		if err != nil {
+			logger.Errorf(ctx, "Failed to store artifact data err: %v", err)
+			m.systemMetrics.createDataFailureCounter.Inc(ctx)
+			m.deleteArtifactData(ctx, artifactDataModels)
+			m.systemMetrics.completeFailureCounter.Inc(ctx)
+			return nil, err
+		}
+
+		artifactDataModels = append(artifactDataModels, models.ArtifactData{
+			Name:     artifactData.Name,
+			Location: dataLocation.String(),
+			Size:     artifactDataSize(artifactData),
+		})
+		m.systemMetrics.createDataSuccessCounter.Inc(ctx)
+	}
+
+	artifactModel, err := transformers.CreateArtifactModel(&datacatalog.CreateArtifactRequest{Artifact: artifact}, artifactDataModels, dataset)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to transform artifact err: %v", err)
+		m.systemMetrics.transformerErrorCounter.Inc(ctx)
+		m.deleteArtifactData(ctx, artifactDataModels)
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	tagNames := append([]string{request.ReservationID.TagName}, request.TagNames...)
+	artifactExists := false
+	err = m.repo.Transaction(ctx, func(ctx context.Context) error {
+		// the usage of the project stays locked until the artifact is committed, so that concurrent artifacts can't
+		// exceed its quota
+		if err := m.checkQuota(ctx, artifact, true); err != nil {
+			return err
+		}
+
+		if err := m.repo.ArtifactRepo().Create(ctx, artifactModel); err != nil {
+			artifactExists = errors.IsAlreadyExistsError(err)
+			return err
+		}
+
+		for _, tagName := range tagNames {
+			err := m.repo.TagRepo().Create(ctx, models.Tag{
+				TagKey:      transformers.ToTagKey(artifact.Dataset, tagName),
+				ArtifactID:  artifact.Id,
+				DatasetUUID: dataset.UUID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// releasing fails if the reservation has been taken over since it was looked up
+		err := m.repo.ReservationRepo().Delete(ctx, reservationKey, request.OwnerID)
+		if errors.IsDoesNotExistError(err) {
+			return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+				"reservation %+v is no longer held by owner %s", reservationKey, request.OwnerID)
+		}
+		return err
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to complete reservation %+v of owner %s, err: %v", reservationKey, request.OwnerID, err)
+		// the data of an existing artifact is stored in the same location and must be kept
+		if artifactExists {
+			m.systemMetrics.alreadyExistsCounter.Inc(ctx)
+		} else {
+			m.deleteArtifactData(ctx, artifactDataModels)
+		}
+		m.systemMetrics.completeFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	logger.Debugf(ctx, "Completed reservation %+v of owner %s with artifact %v", reservationKey, request.OwnerID, artifact.Id)
+
+	// the released slot is handed to the first queued owner like when the reservation is released
+	if m.handOff != nil {
+		m.handOff.HandOffReservation(ctx, reservationKey)
+	}
+
+	// wake up the waiters for the tagged artifact and the released reservation
+	for _, tagName := range tagNames {
+		m.notifier.Publish(ctx, notifications.ReservationKey(&datacatalog.ReservationID{
+			DatasetId: artifact.Dataset,
+			TagName:   tagName,
+		}))
+	}
+	m.systemMetrics.completeSuccessCounter.Inc(ctx)
+	return &interfaces.CompleteReservationResponse{TagNames: tagNames}, nil
+}
+
+// Check that the project of the artifact stays within its quota when storing it, locking the usage of the project for
+// update when checked in the transaction creating the artifact
+func (m *artifactManager) checkQuota(ctx context.Context, artifact *datacatalog.Artifact, forUpdate bool) error {
+	err := checkArtifactQuota(ctx, m.repo.ArtifactRepo(), m.quotas, artifact.Dataset.Project, artifact.Data, forUpdate)
+	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			logger.Warnf(ctx, "Rejected artifact %v exceeding the quota, err: %v", artifact.Id, err)
+			m.systemMetrics.quotaExceededCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Failed to get the usage of project %v, err: %v", artifact.Dataset.Project, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Find the slot of the reservation held by the owner
+func (m *artifactManager) findOwnerReservation(ctx context.Context, reservationID *datacatalog.ReservationID, ownerID string) (models.ReservationKey, error) {
+	reservationKey := transformers.FromReservationID(reservationID)
+	reservations, err := m.repo.ReservationRepo().GetSlots(ctx, reservationKey)
+	if err != nil {
+		return models.ReservationKey{}, err
+	}
+
+	for _, reservation := range reservations {
+		if reservation.OwnerID == ownerID {
+			return reservation.ReservationKey, nil
+		}
+	}
+
+	return models.ReservationKey{}, errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+		"reservation %+v is not held by owner %s", reservationKey, ownerID)
+}
+
+// Delete the stored data of an artifact which could not be created. Failures are only logged, the data is overwritten
+// once the artifact is created again.
+func (m *artifactManager) deleteArtifactData(ctx context.Context, artifactDataModels []models.ArtifactData) {
+	for _, artifactData := range artifactDataModels {
+		if err := m.artifactStore.DeleteData(ctx, artifactData); err != nil {
+			logger.Warnf(ctx, "Failed to delete artifact data %+v, err: %v", artifactData, err)
+			m.systemMetrics.deleteDataFailureCounter.Inc(ctx)
+			continue
+		}
+		m.systemMetrics.deleteDataSuccessCounter.Inc(ctx)
+	}
+}
+
+func NewArtifactManager(repo repositories.RepositoryInterface, store *storage.DataStore, storagePrefix storage.DataReference, notifier notifications.Notifier, handOff ReservationHandOff, quotas ArtifactQuotas, artifactScope promutils.Scope) interfaces.ArtifactManager {
 	artifactMetrics := artifactMetrics{
 		scope:                    artifactScope,
 		createResponseTime:       labeled.NewStopWatch("create_duration", "The duration of the create artifact calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
@@ -429,11 +747,21 @@ func NewArtifactManager(repo repositories.RepositoryInterface, store *storage.Da
 		updateDataFailureCounter: labeled.NewCounter("update_data_failure_count", "The number of times update artifact data failed", artifactScope, labeled.EmitUnlabeledMetric),
 		deleteDataSuccessCounter: labeled.NewCounter("delete_data_success_count", "The number of times delete artifact data succeeded", artifactScope, labeled.EmitUnlabeledMetric),
 		deleteDataFailureCounter: labeled.NewCounter("delete_data_failure_count", "The number of times delete artifact data failed", artifactScope, labeled.EmitUnlabeledMetric),
+		searchResponseTime:       labeled.NewStopWatch("search_duration", "The duration of the search artifacts calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+		searchSuccessCounter:     labeled.NewCounter("search_success_count", "The number of times search artifacts succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+		searchFailureCounter:     labeled.NewCounter("search_failure_count", "The number of times search artifacts failed", artifactScope, labeled.EmitUnlabeledMetric),
+		completeResponseTime:     labeled.NewStopWatch("complete_reservation_duration", "The duration of the complete reservation calls.", time.Millisecond, artifactScope, labeled.EmitUnlabeledMetric),
+		completeSuccessCounter:   labeled.NewCounter("complete_reservation_success_count", "The number of times complete reservation succeeded", artifactScope, labeled.EmitUnlabeledMetric),
+		completeFailureCounter:   labeled.NewCounter("complete_reservation_failure_count", "The number of times complete reservation failed", artifactScope, labeled.EmitUnlabeledMetric),
+		quotaExceededCounter:     labeled.NewCounter("quota_exceeded_count", "The number of times an artifact was rejected for exceeding the quota of its project", artifactScope, labeled.EmitUnlabeledMetric),
 	}
 
 	return &artifactManager{
 		repo:          repo,
 		artifactStore: NewArtifactDataStore(store, storagePrefix),
+		notifier:      notifier,
+		handOff:       handOff,
+		quotas:        quotas,
 		systemMetrics: artifactMetrics,
 	}
 }
@@ -11,9 +11,12 @@ import (
 
 	"github.com/flyteorg/datacatalog/pkg/common"
 	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	repoErrors "github.com/flyteorg/datacatalog/pkg/repositories/errors"
 	"github.com/flyteorg/datacatalog/pkg/repositories/mocks"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/flyteorg/flytestdlib/contextutils"
@@ -24,7 +27,9 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -225,7 +230,7 @@ func TestCreateArtifact(t *testing.T) {
 			})).Return(nil)
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, artifactResponse)
@@ -244,7 +249,7 @@ func TestCreateArtifact(t *testing.T) {
 		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(models.Dataset{}, status.Error(codes.NotFound, "not found"))
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -260,7 +265,7 @@ func TestCreateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(&mocks.DataCatalogRepo{}, createInmemoryDataStore(t, mockScope.NewTestScope()), testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(&mocks.DataCatalogRepo{}, createInmemoryDataStore(t, mockScope.NewTestScope()), testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		_, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		responseCode := status.Code(err)
@@ -276,7 +281,7 @@ func TestCreateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(&mocks.DataCatalogRepo{}, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(&mocks.DataCatalogRepo{}, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		_, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		responseCode := status.Code(err)
@@ -302,7 +307,7 @@ func TestCreateArtifact(t *testing.T) {
 			})).Return(status.Error(codes.AlreadyExists, "test already exists"))
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -323,7 +328,7 @@ func TestCreateArtifact(t *testing.T) {
 			})).Return(fmt.Errorf("Validation should happen before this happens"))
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: artifact}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -348,7 +353,7 @@ func TestCreateArtifact(t *testing.T) {
 		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: artifact}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		_, err := artifactManager.CreateArtifact(ctx, request)
 		assert.NoError(t, err)
 	})
@@ -365,7 +370,7 @@ func TestCreateArtifact(t *testing.T) {
 			})).Return(fmt.Errorf("Validation should happen before this happens"))
 
 		request := &datacatalog.CreateArtifactRequest{Artifact: artifact}
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -374,6 +379,187 @@ func TestCreateArtifact(t *testing.T) {
 		assert.Equal(t, codes.InvalidArgument, responseCode)
 	})
 
+	t.Run("Stale Fencing Token", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.Anything,
+			mock.MatchedBy(func(reservationKey models.ReservationKey) bool {
+				return reservationKey.DatasetProject == expectedDataset.Id.Project &&
+					reservationKey.DatasetName == expectedDataset.Id.Name &&
+					reservationKey.TagName == "cache-key"
+			})).Return([]models.Reservation{{OwnerID: "newOwner", FencingToken: 2}}, nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "cache-key=1"))
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// the data of a rejected artifact must not be stored
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, testStoragePrefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		err = datastore.ReadProtobuf(ctx, dataRef, &value)
+		assert.Error(t, err)
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Artifact count quota exceeded", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockArtifactRepo.On("GetProjectUsage", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 10, DataBytes: 100}, nil)
+
+		quotas := ArtifactQuotas{Default: ArtifactQuota{MaxArtifacts: 10}}
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, quotas, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		assert.Len(t, st.Details(), 1)
+		quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+		assert.True(t, ok)
+		assert.Equal(t, "project:"+expectedDataset.Id.Project, quotaFailure.Violations[0].Subject)
+
+		// the data of a rejected artifact must not be stored
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, testStoragePrefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		err = datastore.ReadProtobuf(ctx, dataRef, &value)
+		assert.Error(t, err)
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Data bytes quota exceeded", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockArtifactRepo.On("GetProjectUsage", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 1, DataBytes: 100}, nil)
+
+		quotas := ArtifactQuotas{Default: ArtifactQuota{MaxArtifacts: 10, MaxBytes: 101}}
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, quotas, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fenced while storing the data", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		// the reservation is taken over after the data is stored
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.Anything, mock.Anything).
+			Return([]models.Reservation{{OwnerID: "owner", FencingToken: 1, ExpiresAt: time.Now().Add(time.Hour)}}, nil).Once()
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.Anything, mock.Anything).
+			Return([]models.Reservation{{OwnerID: "newOwner", FencingToken: 2}}, nil).Once()
+		dcRepo.MockArtifactRepo.On("Get", mock.Anything, mock.Anything).
+			Return(models.Artifact{}, errors.NewDataCatalogErrorf(codes.NotFound, "not found"))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "cache-key=1"))
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// the stored data of the rejected artifact is deleted
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, testStoragePrefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		err = datastore.ReadProtobuf(ctx, dataRef, &value)
+		assert.Error(t, err)
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejected retry of an existing artifact", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.Anything, mock.Anything).
+			Return([]models.Reservation{{OwnerID: "owner", FencingToken: 1, ExpiresAt: time.Now().Add(time.Hour)}}, nil).Once()
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.Anything, mock.Anything).
+			Return([]models.Reservation{{OwnerID: "newOwner", FencingToken: 2}}, nil).Once()
+		dcRepo.MockArtifactRepo.On("Get", mock.Anything, mock.Anything).Return(models.Artifact{}, nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "cache-key=1"))
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// the data of the existing artifact is stored in the same location and is kept
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, testStoragePrefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		assert.NoError(t, datastore.ReadProtobuf(ctx, dataRef, &value))
+	})
+
+	t.Run("Quota exceeded by a concurrent artifact", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		// another artifact of the project is created after the data is stored
+		dcRepo.MockArtifactRepo.On("GetProjectUsage", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 9}, nil)
+		dcRepo.MockArtifactRepo.On("GetProjectUsageForUpdate", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 10}, nil)
+		dcRepo.MockArtifactRepo.On("Get", mock.Anything, mock.Anything).
+			Return(models.Artifact{}, errors.NewDataCatalogErrorf(codes.NotFound, "not found"))
+
+		quotas := ArtifactQuotas{Default: ArtifactQuota{MaxArtifacts: 10}}
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, quotas, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+		// the stored data of the rejected artifact is deleted
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, testStoragePrefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		err = datastore.ReadProtobuf(ctx, dataRef, &value)
+		assert.Error(t, err)
+	})
+
+	t.Run("Within project quota", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockArtifactRepo.On("GetProjectUsage", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 10, DataBytes: 100}, nil)
+		dcRepo.MockArtifactRepo.On("GetProjectUsageForUpdate", mock.Anything, expectedDataset.Id.Project).
+			Return(models.ProjectUsage{ArtifactCount: 10, DataBytes: 100}, nil)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything,
+			mock.MatchedBy(func(artifact models.Artifact) bool {
+				return artifact.ArtifactData[0].Size == int64(proto.Size(getTestArtifact().Data[0].Value))
+			})).Return(nil)
+
+		// the quota of the project overrides the exceeded default quota
+		quotas := ArtifactQuotas{
+			Default:  ArtifactQuota{MaxArtifacts: 10},
+			Projects: map[string]ArtifactQuota{expectedDataset.Id.Project: {MaxArtifacts: 11, MaxBytes: 1 << 20}},
+		}
+		request := &datacatalog.CreateArtifactRequest{Artifact: getTestArtifact()}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, quotas, mockScope.NewTestScope())
+		artifactResponse, err := artifactManager.CreateArtifact(ctx, request)
+		assert.NoError(t, err)
+		assert.NotNil(t, artifactResponse)
+		// the quota is checked again when the artifact is created, locking the usage of the project
+		dcRepo.MockArtifactRepo.AssertNumberOfCalls(t, "GetProjectUsage", 1)
+		dcRepo.MockArtifactRepo.AssertNumberOfCalls(t, "GetProjectUsageForUpdate", 1)
+	})
 }
 
 func TestGetArtifact(t *testing.T) {
@@ -397,7 +583,7 @@ func TestGetArtifact(t *testing.T) {
 					artifactKey.DatasetName == expectedArtifact.Dataset.Name
 			})).Return(mockArtifactModel, nil)
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.GetArtifact(ctx, &datacatalog.GetArtifactRequest{
 			Dataset:     getTestDataset().Id,
 			QueryHandle: &datacatalog.GetArtifactRequest_ArtifactId{ArtifactId: expectedArtifact.Id},
@@ -430,7 +616,7 @@ func TestGetArtifact(t *testing.T) {
 			ArtifactID:  mockArtifactModel.ArtifactID,
 		}, nil)
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.GetArtifact(ctx, &datacatalog.GetArtifactRequest{
 			Dataset:     getTestDataset().Id,
 			QueryHandle: &datacatalog.GetArtifactRequest_TagName{TagName: expectedTag.TagName},
@@ -440,7 +626,7 @@ func TestGetArtifact(t *testing.T) {
 	})
 
 	t.Run("Get missing input", func(t *testing.T) {
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.GetArtifact(ctx, &datacatalog.GetArtifactRequest{Dataset: getTestDataset().Id})
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -451,7 +637,7 @@ func TestGetArtifact(t *testing.T) {
 	t.Run("Get does not exist", func(t *testing.T) {
 		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(
 			models.Tag{}, errors.NewDataCatalogError(codes.NotFound, "tag with artifact does not exist"))
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.GetArtifact(ctx, &datacatalog.GetArtifactRequest{Dataset: getTestDataset().Id, QueryHandle: &datacatalog.GetArtifactRequest_TagName{TagName: "test"}})
 		assert.Error(t, err)
 		assert.Nil(t, artifactResponse)
@@ -483,7 +669,7 @@ func TestListArtifact(t *testing.T) {
 	mockArtifactModel := getExpectedArtifactModel(ctx, t, datastore, expectedArtifact)
 
 	t.Run("List Artifact on invalid filter", func(t *testing.T) {
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -506,7 +692,7 @@ func TestListArtifact(t *testing.T) {
 	})
 
 	t.Run("List Artifacts with Partition and Tag", func(t *testing.T) {
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -577,7 +763,7 @@ func TestListArtifact(t *testing.T) {
 	})
 
 	t.Run("List Artifacts with No Partition", func(t *testing.T) {
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{Filters: nil}
 
 		dcRepo.MockDatasetRepo.On("Get", mock.Anything,
@@ -607,6 +793,177 @@ func TestListArtifact(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotEmpty(t, artifactResponse)
 	})
+
+	t.Run("List Artifacts with Metadata filter", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		metadataCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(metadataFilterHeader, "execution_id=exec-1"))
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockArtifactRepo.On("List", mock.Anything, mock.Anything,
+			mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+				if len(listInput.ModelFilters) != 1 || listInput.ModelFilters[0].Entity != common.ArtifactMetadata {
+					return false
+				}
+				keyExpr, _ := listInput.ModelFilters[0].ValueFilters[0].GetDBQueryExpression("artifact_metadata")
+				valueExpr, _ := listInput.ModelFilters[0].ValueFilters[1].GetDBQueryExpression("artifact_metadata")
+				return keyExpr.Args == "execution_id" && valueExpr.Args == "exec-1"
+			})).Return([]models.Artifact{mockArtifactModel}, nil)
+
+		artifactResponse, err := artifactManager.ListArtifacts(metadataCtx, &datacatalog.ListArtifactsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		assert.Len(t, artifactResponse.Artifacts, 1)
+	})
+
+	t.Run("List Artifacts with invalid Metadata filter", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		metadataCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(metadataFilterHeader, "execution_id"))
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+
+		artifactResponse, err := artifactManager.ListArtifacts(metadataCtx, &datacatalog.ListArtifactsRequest{Dataset: expectedDataset.Id})
+		assert.Error(t, err)
+		assert.Nil(t, artifactResponse)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListArtifactsWithTimeRange(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+	assert.NoError(t, err)
+
+	expectedDataset := getTestDataset()
+	mockDatasetModel := models.Dataset{
+		DatasetKey: models.DatasetKey{
+			Project: expectedDataset.Id.Project,
+			Domain:  expectedDataset.Id.Domain,
+			Name:    expectedDataset.Id.Name,
+			Version: expectedDataset.Id.Version,
+		},
+	}
+
+	t.Run("Created in range", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		timeCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(timeRangeFilterHeader, "created_at=2023-01-01T00:00:00Z/"))
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		dcRepo.MockArtifactRepo.On("List", mock.Anything, mock.Anything,
+			mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+				if len(listInput.ModelFilters) != 1 || listInput.ModelFilters[0].Entity != common.Artifact {
+					return false
+				}
+				expr, err := listInput.ModelFilters[0].ValueFilters[0].GetDBQueryExpression("artifacts")
+				return err == nil && expr.Query == "artifacts.created_at >= ?" &&
+					expr.Args == time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+			})).Return([]models.Artifact{}, nil)
+
+		artifactResponse, err := artifactManager.ListArtifacts(timeCtx, &datacatalog.ListArtifactsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		assert.NotNil(t, artifactResponse)
+	})
+
+	t.Run("Invalid timestamp", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		timeCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(timeRangeFilterHeader, "tag.created_at=yesterday/"))
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+
+		_, err := artifactManager.ListArtifacts(timeCtx, &datacatalog.ListArtifactsRequest{Dataset: expectedDataset.Id})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Unsupported entity", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		timeCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(timeRangeFilterHeader, "dataset.created_at=/2023-01-01T00:00:00Z"))
+
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+
+		_, err := artifactManager.ListArtifacts(timeCtx, &datacatalog.ListArtifactsRequest{Dataset: expectedDataset.Id})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSearchArtifacts(t *testing.T) {
+	ctx := context.Background()
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+	testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+	assert.NoError(t, err)
+
+	expectedArtifact := getTestArtifact()
+	mockArtifactModel := getExpectedArtifactModel(ctx, t, datastore, expectedArtifact)
+
+	t.Run("Search by Partition across versions", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		filter := &datacatalog.FilterExpression{
+			Filters: []*datacatalog.SinglePropertyFilter{
+				{
+					PropertyFilter: &datacatalog.SinglePropertyFilter_PartitionFilter{
+						PartitionFilter: &datacatalog.PartitionPropertyFilter{
+							Property: &datacatalog.PartitionPropertyFilter_KeyVal{
+								KeyVal: &datacatalog.KeyValuePair{Key: "key1", Value: "value1"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		dcRepo.MockArtifactRepo.On("Search", mock.Anything,
+			models.DatasetKey{Project: "test-project", Domain: "test-domain", Name: "test-name"},
+			mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+				return len(listInput.ModelFilters) == 2 &&
+					listInput.ModelFilters[0].Entity == common.Partition &&
+					listInput.ModelFilters[1].Entity == common.ArtifactMetadata &&
+					listInput.Limit == common.MaxPageLimit
+			})).Return([]models.Artifact{mockArtifactModel}, nil)
+
+		response, err := artifactManager.SearchArtifacts(ctx, &interfaces.SearchArtifactsRequest{
+			Project:         "test-project",
+			Domain:          "test-domain",
+			DatasetName:     "test-name",
+			Filter:          filter,
+			MetadataFilters: []*datacatalog.KeyValuePair{{Key: "key1", Value: "value1"}},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.Artifacts, 1)
+		assert.Equal(t, expectedArtifact.Id, response.Artifacts[0].Id)
+		assert.Empty(t, response.Artifacts[0].Data)
+		assert.Equal(t, "1", response.NextToken)
+	})
+
+	t.Run("Missing project", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+
+		_, err := artifactManager.SearchArtifacts(ctx, &interfaces.SearchArtifactsRequest{Domain: "test-domain"})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid time range", func(t *testing.T) {
+		dcRepo := newMockDataCatalogRepo()
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+
+		_, err := artifactManager.SearchArtifacts(ctx, &interfaces.SearchArtifactsRequest{
+			Project: "test-project",
+			Domain:  "test-domain",
+			TimeRangeFilters: []common.TimeRangeFilter{
+				{Entity: common.Artifact, Property: common.CreatedAt, Start: "2023-01-02T00:00:00Z", End: "2023-01-01T00:00:00Z"},
+			},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestUpdateArtifact(t *testing.T) {
@@ -666,7 +1023,7 @@ func TestUpdateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, artifactResponse)
@@ -749,7 +1106,7 @@ func TestUpdateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.NoError(t, err)
 		assert.NotNil(t, artifactResponse)
@@ -806,7 +1163,7 @@ func TestUpdateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Equal(t, codes.NotFound, status.Code(err))
@@ -834,7 +1191,7 @@ func TestUpdateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
@@ -862,7 +1219,7 @@ func TestUpdateArtifact(t *testing.T) {
 			},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
@@ -883,7 +1240,7 @@ func TestUpdateArtifact(t *testing.T) {
 			Data: nil,
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
@@ -904,10 +1261,181 @@ func TestUpdateArtifact(t *testing.T) {
 			Data: []*datacatalog.ArtifactData{},
 		}
 
-		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, mockScope.NewTestScope())
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
 		artifactResponse, err := artifactManager.UpdateArtifact(ctx, request)
 		assert.Error(t, err)
 		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		assert.Nil(t, artifactResponse)
 	})
 }
+
+// Records the reservations handed off instead of handing them off
+type recordingHandOff struct {
+	reservationKeys []models.ReservationKey
+}
+
+func (h *recordingHandOff) HandOffReservation(_ context.Context, reservationKey models.ReservationKey) {
+	h.reservationKeys = append(h.reservationKeys, reservationKey)
+}
+
+func TestCompleteReservation(t *testing.T) {
+	ctx := context.Background()
+	expectedDataset := getTestDataset()
+	mockDatasetModel := models.Dataset{
+		DatasetKey: models.DatasetKey{
+			Project: expectedDataset.Id.Project,
+			Domain:  expectedDataset.Id.Domain,
+			Name:    expectedDataset.Id.Name,
+			Version: expectedDataset.Id.Version,
+			UUID:    expectedDataset.Id.UUID,
+		},
+		PartitionKeys: []models.PartitionKey{
+			{Name: expectedDataset.PartitionKeys[0]},
+			{Name: expectedDataset.PartitionKeys[1]},
+		},
+	}
+
+	ownerID := "owner"
+	getRequest := func() *interfaces.CompleteReservationRequest {
+		artifact := getTestArtifact()
+		return &interfaces.CompleteReservationRequest{
+			ReservationID: &datacatalog.ReservationID{DatasetId: artifact.Dataset, TagName: "reserved-tag"},
+			OwnerID:       ownerID,
+			Artifact:      artifact,
+			TagNames:      []string{"latest"},
+		}
+	}
+	reservationKey := transformers.FromReservationID(getRequest().ReservationID)
+	reservationKey.Slot = 1
+
+	setUpRepo := func(holder string) *mocks.DataCatalogRepo {
+		dcRepo := newMockDataCatalogRepo()
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, transformers.FromReservationID(getRequest().ReservationID)).Return(
+			[]models.Reservation{
+				{ReservationKey: transformers.FromReservationID(getRequest().ReservationID), OwnerID: "other"},
+				{ReservationKey: reservationKey, OwnerID: holder},
+			}, nil)
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(mockDatasetModel, nil)
+		return dcRepo
+	}
+
+	readData := func(t *testing.T, datastore *storage.DataStore, prefix storage.DataReference) error {
+		dataRef, err := getExpectedDatastoreLocation(ctx, datastore, prefix, getTestArtifact(), 0)
+		assert.NoError(t, err)
+		var value core.Literal
+		return datastore.ReadProtobuf(ctx, dataRef, &value)
+	}
+
+	t.Run("HappyPath", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+		assert.NoError(t, err)
+
+		dcRepo := setUpRepo(ownerID)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.MatchedBy(func(artifact models.Artifact) bool {
+			return artifact.ArtifactID == getTestArtifact().Id && len(artifact.ArtifactData) == 1
+		})).Return(nil)
+		for _, tagName := range []string{"reserved-tag", "latest"} {
+			dcRepo.MockTagRepo.On("Create", mock.Anything, models.Tag{
+				TagKey:      transformers.ToTagKey(getTestArtifact().Dataset, tagName),
+				ArtifactID:  getTestArtifact().Id,
+				DatasetUUID: expectedDataset.Id.UUID,
+			}).Return(nil)
+		}
+		dcRepo.MockReservationRepo.On("Delete", mock.Anything, reservationKey, ownerID).Return(nil)
+
+		notifier := notifications.NewInProcessNotifier()
+		subscription := notifier.Subscribe(notifications.ReservationKey(getRequest().ReservationID))
+		defer subscription.Close()
+
+		handOff := &recordingHandOff{}
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifier, handOff, ArtifactQuotas{}, mockScope.NewTestScope())
+		response, err := artifactManager.CompleteReservation(ctx, getRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"reserved-tag", "latest"}, response.TagNames)
+		assert.NoError(t, readData(t, datastore, testStoragePrefix))
+		dcRepo.MockTagRepo.AssertNumberOfCalls(t, "Create", 2)
+		dcRepo.MockReservationRepo.AssertExpectations(t)
+		assert.Equal(t, []models.ReservationKey{reservationKey}, handOff.reservationKeys)
+
+		select {
+		case <-subscription.C:
+		default:
+			assert.Fail(t, "waiters for the reserved tag were not notified")
+		}
+	})
+
+	t.Run("Not held by owner", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+		assert.NoError(t, err)
+
+		dcRepo := setUpRepo("another-owner")
+
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err = artifactManager.CompleteReservation(ctx, getRequest())
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Error(t, readData(t, datastore, testStoragePrefix))
+		dcRepo.MockArtifactRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Taken over before release", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+		assert.NoError(t, err)
+
+		dcRepo := setUpRepo(ownerID)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		dcRepo.MockTagRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		dcRepo.MockReservationRepo.On("Delete", mock.Anything, reservationKey, ownerID).Return(
+			errors.NewDataCatalogErrorf(codes.NotFound, "reservation not found"))
+
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err = artifactManager.CompleteReservation(ctx, getRequest())
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		// the stored data is deleted along with the rolled back artifact
+		assert.Error(t, readData(t, datastore, testStoragePrefix))
+	})
+
+	t.Run("Tag already exists", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+		assert.NoError(t, err)
+
+		dcRepo := setUpRepo(ownerID)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		dcRepo.MockTagRepo.On("Create", mock.Anything, mock.Anything).Return(
+			errors.NewDataCatalogErrorf(codes.AlreadyExists, "tag already exists"))
+
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err = artifactManager.CompleteReservation(ctx, getRequest())
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		assert.Error(t, readData(t, datastore, testStoragePrefix))
+		dcRepo.MockReservationRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Artifact already exists", func(t *testing.T) {
+		datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+		testStoragePrefix, err := datastore.ConstructReference(ctx, datastore.GetBaseContainerFQN(ctx), "test")
+		assert.NoError(t, err)
+
+		dcRepo := setUpRepo(ownerID)
+		dcRepo.MockArtifactRepo.On("Create", mock.Anything, mock.Anything).Return(
+			errors.NewDataCatalogErrorf(codes.AlreadyExists, "artifact already exists"))
+
+		artifactManager := NewArtifactManager(dcRepo, datastore, testStoragePrefix, notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err = artifactManager.CompleteReservation(ctx, getRequest())
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		// the data is stored in the location of the existing artifact and therefore kept
+		assert.NoError(t, readData(t, datastore, testStoragePrefix))
+	})
+
+	t.Run("Artifact of another dataset", func(t *testing.T) {
+		request := getRequest()
+		request.ReservationID.DatasetId = &datacatalog.DatasetID{Project: "p", Domain: "d", Name: "n", Version: "v"}
+
+		artifactManager := NewArtifactManager(newMockDataCatalogRepo(), createInmemoryDataStore(t, mockScope.NewTestScope()), "", notifications.NewInProcessNotifier(), nil, ArtifactQuotas{}, mockScope.NewTestScope())
+		_, err := artifactManager.CompleteReservation(ctx, request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,71 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/golang/protobuf/proto"
+)
+
+// ArtifactQuota caps the artifacts stored by a project. Limits are not enforced if zero.
+type ArtifactQuota struct {
+	MaxArtifacts int64
+	MaxBytes     int64
+}
+
+// ArtifactQuotas of all projects, which individual projects may override
+type ArtifactQuotas struct {
+	Default  ArtifactQuota
+	Projects map[string]ArtifactQuota
+}
+
+func (q ArtifactQuotas) forProject(project string) ArtifactQuota {
+	if quota, ok := q.Projects[project]; ok {
+		return quota
+	}
+	return q.Default
+}
+
+// Get the size of the artifact data as stored in the blob storage
+func artifactDataSize(artifactData *datacatalog.ArtifactData) int64 {
+	return int64(proto.Size(artifactData.Value))
+}
+
+// Check that the project stays within its quota when storing another artifact with the data, returning a
+// ResourceExhausted error otherwise. Within a transaction the usage of the project is locked for update, so that it
+// stays within the quota until the artifact is created.
+func checkArtifactQuota(ctx context.Context, artifactRepo interfaces.ArtifactRepo, quotas ArtifactQuotas, project string, data []*datacatalog.ArtifactData, forUpdate bool) error {
+	quota := quotas.forProject(project)
+	if quota.MaxArtifacts <= 0 && quota.MaxBytes <= 0 {
+		return nil
+	}
+
+	getUsage := artifactRepo.GetProjectUsage
+	if forUpdate {
+		getUsage = artifactRepo.GetProjectUsageForUpdate
+	}
+	usage, err := getUsage(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("project:%s", project)
+	if quota.MaxArtifacts > 0 && usage.ArtifactCount+1 > quota.MaxArtifacts {
+		return errors.NewQuotaExceededError(subject,
+			fmt.Sprintf("%d of %d artifacts stored", usage.ArtifactCount, quota.MaxArtifacts))
+	}
+
+	var newBytes int64
+	for _, artifactData := range data {
+		newBytes += artifactDataSize(artifactData)
+	}
+	if quota.MaxBytes > 0 && usage.DataBytes+newBytes > quota.MaxBytes {
+		return errors.NewQuotaExceededError(subject,
+			fmt.Sprintf("%d of %d bytes stored, %d more requested", usage.DataBytes, quota.MaxBytes, newBytes))
+	}
+
+	return nil
+}
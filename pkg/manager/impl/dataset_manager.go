@@ -3,6 +3,7 @@ package impl
 import (
 	"context"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/flyteorg/datacatalog/pkg/common"
@@ -10,8 +11,10 @@ import (
 	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
 	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	"github.com/flyteorg/datacatalog/pkg/repositories"
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
@@ -23,12 +26,19 @@ type datasetMetrics struct {
 	scope                   promutils.Scope
 	createResponseTime      labeled.StopWatch
 	getResponseTime         labeled.StopWatch
+	updateResponseTime      labeled.StopWatch
+	statsResponseTime       labeled.StopWatch
 	createSuccessCounter    labeled.Counter
 	createErrorCounter      labeled.Counter
 	getSuccessCounter       labeled.Counter
 	getErrorCounter         labeled.Counter
 	listSuccessCounter      labeled.Counter
 	listFailureCounter      labeled.Counter
+	updateSuccessCounter    labeled.Counter
+	updateFailureCounter    labeled.Counter
+	statsSuccessCounter     labeled.Counter
+	statsFailureCounter     labeled.Counter
+	statsCacheHitCounter    labeled.Counter
 	transformerErrorCounter labeled.Counter
 	validationErrorCounter  labeled.Counter
 	alreadyExistsCounter    labeled.Counter
@@ -39,6 +49,60 @@ type datasetManager struct {
 	repo          repositories.RepositoryInterface
 	store         *storage.DataStore
 	systemMetrics datasetMetrics
+	statsCache    *datasetStatsCache
+}
+
+// datasetStatsCache holds computed dataset statistics until they are older than the configured ttl. Computing the
+// statistics aggregates all artifacts, tags and partitions of the dataset, which is expensive for large datasets. The
+// statistics are copied in and out of the cache, so that callers can't modify the cached statistics.
+type datasetStatsCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[models.DatasetKey]interfaces.GetDatasetStatsResponse
+}
+
+func (c *datasetStatsCache) get(key models.DatasetKey, now time.Time) (*interfaces.GetDatasetStatsResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats, ok := c.entries[key]
+	if !ok || now.Sub(stats.ComputedAt) >= c.ttl {
+		return nil, false
+	}
+	return copyDatasetStats(stats), true
+}
+
+func (c *datasetStatsCache) put(key models.DatasetKey, stats interfaces.GetDatasetStatsResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// drop expired entries so the cache does not grow with every dataset ever queried
+	for existingKey, existingStats := range c.entries {
+		if stats.ComputedAt.Sub(existingStats.ComputedAt) >= c.ttl {
+			delete(c.entries, existingKey)
+		}
+	}
+	c.entries[key] = *copyDatasetStats(stats)
+}
+
+// Copy the statistics, including the partition value counts and timestamps they point to
+func copyDatasetStats(stats interfaces.GetDatasetStatsResponse) *interfaces.GetDatasetStatsResponse {
+	if stats.PartitionValueCounts != nil {
+		partitionValueCounts := make(map[string]int64, len(stats.PartitionValueCounts))
+		for key, count := range stats.PartitionValueCounts {
+			partitionValueCounts[key] = count
+		}
+		stats.PartitionValueCounts = partitionValueCounts
+	}
+	if stats.OldestArtifactCreatedAt != nil {
+		oldest := *stats.OldestArtifactCreatedAt
+		stats.OldestArtifactCreatedAt = &oldest
+	}
+	if stats.NewestArtifactCreatedAt != nil {
+		newest := *stats.NewestArtifactCreatedAt
+		stats.NewestArtifactCreatedAt = &newest
+	}
+	return &stats
 }
 
 func (dm *datasetManager) validateCreateRequest(request *datacatalog.CreateDatasetRequest) error {
@@ -141,14 +205,35 @@ func (dm *datasetManager) ListDatasets(ctx context.Context, request *datacatalog
 		return nil, err
 	}
 
+	metadataFilters, err := getMetadataFilters(ctx)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid metadata filters in list datasets request %v, err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
 	// Get the list inputs
-	listInput, err := transformers.FilterToListInput(ctx, common.Dataset, request.GetFilter())
+	listInput, err := transformers.FilterToListInput(ctx, common.Dataset, request.GetFilter(), metadataFilters...)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid list datasets request %v, err: %v", request, err)
 		dm.systemMetrics.validationErrorCounter.Inc(ctx)
 		return nil, err
 	}
 
+	timeRangeFilters, err := getTimeRangeFilters(ctx, common.Dataset)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid time range filters in list datasets request %v, err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	err = transformers.ApplyTimeRangeFilters(ctx, common.Dataset, timeRangeFilters, &listInput)
+	if err != nil {
+		logger.Warningf(ctx, "Invalid time range filters in list datasets request %v, err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
 	err = transformers.ApplyPagination(request.Pagination, &listInput)
 	if err != nil {
 		logger.Warningf(ctx, "Invalid pagination options in list datasets request %v, err: %v", request, err)
@@ -189,14 +274,172 @@ func (dm *datasetManager) ListDatasets(ctx context.Context, request *datacatalog
 	return &datacatalog.ListDatasetsResponse{Datasets: datasetList, NextToken: token}, nil
 }
 
-func NewDatasetManager(repo repositories.RepositoryInterface, store *storage.DataStore, datasetScope promutils.Scope) interfaces.DatasetManager {
+// Update the metadata and partition keys of an existing Dataset. Partition keys can only be removed if no artifact of
+// the dataset is partitioned by them, otherwise a grpc FailedPrecondition err will be returned.
+func (dm *datasetManager) UpdateDataset(ctx context.Context, request *interfaces.UpdateDatasetRequest) (*interfaces.UpdateDatasetResponse, error) {
+	timer := dm.systemMetrics.updateResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateUpdateDatasetRequest(request)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid update dataset request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+	datasetKey := transformers.FromDatasetID(request.Dataset)
+
+	// the dataset is locked while its partition keys are checked and updated, so that no artifact can be created with a
+	// partition key which is removed in the meantime
+	var datasetModel models.Dataset
+	err = dm.repo.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		datasetModel, err = dm.repo.DatasetRepo().GetForUpdate(ctx, datasetKey)
+		if err != nil {
+			if errors.IsDoesNotExistError(err) {
+				logger.Warnf(ctx, "Dataset does not exist key: %+v, err %v", datasetKey, err)
+				dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+			} else {
+				logger.Errorf(ctx, "Unable to get dataset for update request %+v err: %v", request, err)
+				dm.systemMetrics.updateFailureCounter.Inc(ctx)
+			}
+			return err
+		}
+
+		if request.PartitionKeys != nil {
+			partitionKeysInUse, err := dm.repo.DatasetRepo().ListPartitionKeysInUse(ctx, datasetModel.DatasetKey)
+			if err != nil {
+				logger.Errorf(ctx, "Unable to list partition keys in use for dataset %+v err: %v", datasetKey, err)
+				dm.systemMetrics.updateFailureCounter.Inc(ctx)
+				return err
+			}
+
+			existingPartitionKeys := transformers.FromPartitionKeyModel(datasetModel.PartitionKeys)
+			err = validators.ValidatePartitionKeysUpdate(existingPartitionKeys, request.PartitionKeys, partitionKeysInUse)
+			if err != nil {
+				logger.Warnf(ctx, "Invalid partition keys update %v for dataset %+v err: %v", request.PartitionKeys, datasetKey, err)
+				dm.systemMetrics.validationErrorCounter.Inc(ctx)
+				return err
+			}
+		}
+
+		err = transformers.UpdateDatasetModel(&datasetModel, request.Metadata, request.PartitionKeys)
+		if err != nil {
+			logger.Errorf(ctx, "Unable to transform update dataset request %+v err: %v", request, err)
+			dm.systemMetrics.transformerErrorCounter.Inc(ctx)
+			return err
+		}
+
+		err = dm.repo.DatasetRepo().Update(ctx, datasetModel)
+		if err != nil {
+			if errors.IsDoesNotExistError(err) {
+				logger.Warnf(ctx, "Dataset does not exist key: %+v, err %v", datasetKey, err)
+				dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+			} else {
+				logger.Errorf(ctx, "Failed to update dataset model: %+v err: %v", datasetModel, err)
+				dm.systemMetrics.updateFailureCounter.Inc(ctx)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dataset, err := transformers.FromDatasetModel(datasetModel)
+	if err != nil {
+		dm.systemMetrics.transformerErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	logger.Debugf(ctx, "Successfully updated dataset %+v", dataset.Id)
+	dm.systemMetrics.updateSuccessCounter.Inc(ctx)
+	return &interfaces.UpdateDatasetResponse{
+		Dataset: dataset,
+	}, nil
+}
+
+// Get statistics about the artifacts, tags and partitions of a Dataset, including the total size of the offloaded
+// artifact data. If enabled, the statistics are cached for the configured ttl.
+func (dm *datasetManager) GetDatasetStats(ctx context.Context, request *interfaces.GetDatasetStatsRequest) (*interfaces.GetDatasetStatsResponse, error) {
+	timer := dm.systemMetrics.statsResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	err := validators.ValidateDatasetID(request.Dataset)
+	if err != nil {
+		logger.Warnf(ctx, "Invalid get dataset stats request %+v err: %v", request, err)
+		dm.systemMetrics.validationErrorCounter.Inc(ctx)
+		return nil, err
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.Dataset.Project, request.Dataset.Domain)
+	datasetKey := transformers.FromDatasetID(request.Dataset)
+	if dm.statsCache != nil {
+		if stats, ok := dm.statsCache.get(datasetKey, time.Now()); ok {
+			dm.systemMetrics.statsCacheHitCounter.Inc(ctx)
+			return stats, nil
+		}
+	}
+
+	datasetModel, err := dm.repo.DatasetRepo().Get(ctx, datasetKey)
+	if err != nil {
+		if errors.IsDoesNotExistError(err) {
+			logger.Warnf(ctx, "Dataset does not exist key: %+v, err %v", datasetKey, err)
+			dm.systemMetrics.doesNotExistCounter.Inc(ctx)
+		} else {
+			logger.Errorf(ctx, "Unable to get dataset for stats request %+v err: %v", request, err)
+			dm.systemMetrics.statsFailureCounter.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	datasetStats, err := dm.repo.DatasetRepo().GetStats(ctx, datasetModel.DatasetKey)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to compute stats for dataset %+v err: %v", datasetKey, err)
+		dm.systemMetrics.statsFailureCounter.Inc(ctx)
+		return nil, err
+	}
+
+	stats := &interfaces.GetDatasetStatsResponse{
+		ArtifactCount:           datasetStats.ArtifactCount,
+		TagCount:                datasetStats.TagCount,
+		PartitionValueCounts:    datasetStats.PartitionValueCounts,
+		OldestArtifactCreatedAt: datasetStats.OldestArtifactCreatedAt,
+		NewestArtifactCreatedAt: datasetStats.NewestArtifactCreatedAt,
+		TotalStoredBytes:        datasetStats.StoredBytes,
+		ComputedAt:              time.Now(),
+	}
+
+	if dm.statsCache != nil {
+		dm.statsCache.put(datasetKey, *stats)
+	}
+
+	logger.Debugf(ctx, "Computed stats for dataset %+v: %+v", datasetKey, stats)
+	dm.systemMetrics.statsSuccessCounter.Inc(ctx)
+	return stats, nil
+}
+
+func NewDatasetManager(repo repositories.RepositoryInterface, store *storage.DataStore, statsCacheTTL time.Duration, datasetScope promutils.Scope) interfaces.DatasetManager {
+	var statsCache *datasetStatsCache
+	if statsCacheTTL > 0 {
+		statsCache = &datasetStatsCache{
+			ttl:     statsCacheTTL,
+			entries: make(map[models.DatasetKey]interfaces.GetDatasetStatsResponse),
+		}
+	}
+
 	return &datasetManager{
-		repo:  repo,
-		store: store,
+		repo:       repo,
+		store:      store,
+		statsCache: statsCache,
 		systemMetrics: datasetMetrics{
 			scope:                   datasetScope,
 			createResponseTime:      labeled.NewStopWatch("create_duration", "The duration of the create dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
 			getResponseTime:         labeled.NewStopWatch("get_duration", "The duration of the get dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			updateResponseTime:      labeled.NewStopWatch("update_duration", "The duration of the update dataset calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
+			statsResponseTime:       labeled.NewStopWatch("stats_duration", "The duration of the get dataset stats calls.", time.Millisecond, datasetScope, labeled.EmitUnlabeledMetric),
 			createSuccessCounter:    labeled.NewCounter("create_success_count", "The number of times create dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
 			getSuccessCounter:       labeled.NewCounter("get_success_count", "The number of times get dataset was called", datasetScope, labeled.EmitUnlabeledMetric),
 			createErrorCounter:      labeled.NewCounter("create_failed_count", "The number of times create dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
@@ -207,6 +450,11 @@ func NewDatasetManager(repo repositories.RepositoryInterface, store *storage.Dat
 			doesNotExistCounter:     labeled.NewCounter("does_not_exists_count", "The number of times a dataset was not found", datasetScope, labeled.EmitUnlabeledMetric),
 			listSuccessCounter:      labeled.NewCounter("list_success_count", "The number of times list dataset succeeded", datasetScope, labeled.EmitUnlabeledMetric),
 			listFailureCounter:      labeled.NewCounter("list_failure_count", "The number of times list dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			updateSuccessCounter:    labeled.NewCounter("update_success_count", "The number of times update dataset succeeded", datasetScope, labeled.EmitUnlabeledMetric),
+			updateFailureCounter:    labeled.NewCounter("update_failure_count", "The number of times update dataset failed", datasetScope, labeled.EmitUnlabeledMetric),
+			statsSuccessCounter:     labeled.NewCounter("stats_success_count", "The number of times dataset stats were computed successfully", datasetScope, labeled.EmitUnlabeledMetric),
+			statsFailureCounter:     labeled.NewCounter("stats_failure_count", "The number of times computing dataset stats failed", datasetScope, labeled.EmitUnlabeledMetric),
+			statsCacheHitCounter:    labeled.NewCounter("stats_cache_hit_count", "The number of times dataset stats were served from the cache", datasetScope, labeled.EmitUnlabeledMetric),
 		},
 	}
 }
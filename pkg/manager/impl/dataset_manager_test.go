@@ -2,11 +2,13 @@ package impl
 
 import (
 	"testing"
+	"time"
 
 	"context"
 
 	"github.com/flyteorg/datacatalog/pkg/common"
 	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	"github.com/flyteorg/datacatalog/pkg/repositories/mocks"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
@@ -53,7 +55,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("CreateDatasetWithPartitions", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
 			mock.MatchedBy(func(dataset models.Dataset) bool {
@@ -74,7 +76,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("CreateDatasetNoPartitions", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
 			mock.MatchedBy(func(dataset models.Dataset) bool {
@@ -95,7 +97,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("MissingInput", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 		request := &datacatalog.CreateDatasetRequest{
 			Dataset: &datacatalog.Dataset{
 				Id: &datacatalog.DatasetID{
@@ -114,7 +116,7 @@ func TestCreateDataset(t *testing.T) {
 
 	t.Run("AlreadyExists", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.Anything,
@@ -133,7 +135,7 @@ func TestCreateDataset(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
 		badDataset := getTestDataset()
 		badDataset.PartitionKeys = append(badDataset.PartitionKeys, badDataset.PartitionKeys[0])
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Create",
 			mock.Anything,
@@ -153,7 +155,7 @@ func TestGetDataset(t *testing.T) {
 
 	t.Run("HappyPath", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 
 		datasetModelResponse, err := transformers.CreateDatasetModel(expectedDataset)
 		assert.NoError(t, err)
@@ -177,7 +179,7 @@ func TestGetDataset(t *testing.T) {
 
 	t.Run("Does not exist", func(t *testing.T) {
 		dcRepo := getDataCatalogRepo()
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 
 		dcRepo.MockDatasetRepo.On("Get",
 			mock.MatchedBy(func(ctx context.Context) bool { return true }),
@@ -203,7 +205,7 @@ func TestListDatasets(t *testing.T) {
 	dcRepo := getDataCatalogRepo()
 
 	t.Run("List Datasets on invalid filter", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -226,7 +228,7 @@ func TestListDatasets(t *testing.T) {
 	})
 
 	t.Run("List Datasets with Project and Name", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 		filter := &datacatalog.FilterExpression{
 			Filters: []*datacatalog.SinglePropertyFilter{
 				{
@@ -271,7 +273,7 @@ func TestListDatasets(t *testing.T) {
 	})
 
 	t.Run("List Datasets with no filtering", func(t *testing.T) {
-		datasetManager := NewDatasetManager(dcRepo, nil, mockScope.NewTestScope())
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
 
 		datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
 		assert.NoError(t, err)
@@ -289,3 +291,195 @@ func TestListDatasets(t *testing.T) {
 		assert.Len(t, datasetResponse.Datasets, 1)
 	})
 }
+
+func TestUpdateDataset(t *testing.T) {
+	expectedDataset := getTestDataset()
+	updatedMetadata := &datacatalog.Metadata{
+		KeyMap: map[string]string{"key2": "value2"},
+	}
+
+	getDatasetModel := func() models.Dataset {
+		datasetModel, err := transformers.CreateDatasetModel(getTestDataset())
+		assert.NoError(t, err)
+		for i := range datasetModel.PartitionKeys {
+			datasetModel.PartitionKeys[i].DatasetUUID = datasetModel.UUID
+		}
+		return *datasetModel
+	}
+
+	t.Run("UpdateMetadataAndAddPartitionKey", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("GetForUpdate", mock.Anything,
+			mock.MatchedBy(func(datasetKey models.DatasetKey) bool {
+				return datasetKey.Project == expectedDataset.Id.Project &&
+					datasetKey.Name == expectedDataset.Id.Name
+			})).Return(getDatasetModel(), nil)
+		dcRepo.MockDatasetRepo.On("ListPartitionKeysInUse", mock.Anything, mock.Anything).Return([]string{"key1", "key2"}, nil)
+		dcRepo.MockDatasetRepo.On("Update", mock.Anything,
+			mock.MatchedBy(func(dataset models.Dataset) bool {
+				return len(dataset.PartitionKeys) == 3 &&
+					dataset.PartitionKeys[0].Name == "key1" &&
+					dataset.PartitionKeys[1].Name == "key2" &&
+					dataset.PartitionKeys[2].Name == "key3" &&
+					dataset.PartitionKeys[2].DatasetUUID == expectedDataset.Id.UUID
+			})).Return(nil)
+
+		request := &interfaces.UpdateDatasetRequest{
+			Dataset:       expectedDataset.Id,
+			Metadata:      updatedMetadata,
+			PartitionKeys: []string{"key1", "key2", "key3"},
+		}
+		resp, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(updatedMetadata, resp.Dataset.Metadata))
+		assert.Equal(t, []string{"key1", "key2", "key3"}, resp.Dataset.PartitionKeys)
+	})
+
+	t.Run("RemoveUnusedPartitionKey", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("GetForUpdate", mock.Anything, mock.Anything).Return(getDatasetModel(), nil)
+		dcRepo.MockDatasetRepo.On("ListPartitionKeysInUse", mock.Anything, mock.Anything).Return([]string{"key1"}, nil)
+		dcRepo.MockDatasetRepo.On("Update", mock.Anything,
+			mock.MatchedBy(func(dataset models.Dataset) bool {
+				return len(dataset.PartitionKeys) == 1 && dataset.PartitionKeys[0].Name == "key1"
+			})).Return(nil)
+
+		request := &interfaces.UpdateDatasetRequest{
+			Dataset:       expectedDataset.Id,
+			PartitionKeys: []string{"key1"},
+		}
+		resp, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedDataset.Metadata, resp.Dataset.Metadata))
+		assert.Equal(t, []string{"key1"}, resp.Dataset.PartitionKeys)
+	})
+
+	t.Run("RemovePartitionKeyInUse", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("GetForUpdate", mock.Anything, mock.Anything).Return(getDatasetModel(), nil)
+		dcRepo.MockDatasetRepo.On("ListPartitionKeysInUse", mock.Anything, mock.Anything).Return([]string{"key1", "key2"}, nil)
+
+		request := &interfaces.UpdateDatasetRequest{
+			Dataset:       expectedDataset.Id,
+			PartitionKeys: []string{"key1"},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		dcRepo.MockDatasetRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DuplicatePartitionKeys", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
+
+		request := &interfaces.UpdateDatasetRequest{
+			Dataset:       expectedDataset.Id,
+			PartitionKeys: []string{"key1", "key1"},
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("DoesNotExist", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, nil, 0, mockScope.NewTestScope())
+
+		dcRepo.MockDatasetRepo.On("GetForUpdate", mock.Anything, mock.Anything).Return(models.Dataset{},
+			errors.NewDataCatalogError(codes.NotFound, "dataset does not exist"))
+
+		request := &interfaces.UpdateDatasetRequest{
+			Dataset:  expectedDataset.Id,
+			Metadata: updatedMetadata,
+		}
+		_, err := datasetManager.UpdateDataset(context.Background(), request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetDatasetStats(t *testing.T) {
+	ctx := context.Background()
+	expectedDataset := getTestDataset()
+	datasetModel, err := transformers.CreateDatasetModel(expectedDataset)
+	assert.NoError(t, err)
+
+	oldest := time.Unix(100, 0)
+	newest := time.Unix(200, 0)
+	datasetStats := models.DatasetStats{
+		ArtifactCount:           2,
+		TagCount:                1,
+		PartitionValueCounts:    map[string]int64{"key1": 2, "key2": 1},
+		OldestArtifactCreatedAt: &oldest,
+		NewestArtifactCreatedAt: &newest,
+		StoredBytes:             5,
+	}
+
+	datastore := createInmemoryDataStore(t, mockScope.NewTestScope())
+
+	setUpRepo := func() *mocks.DataCatalogRepo {
+		dcRepo := getDataCatalogRepo()
+		dcRepo.MockDatasetRepo.On("Get", mock.Anything, mock.Anything).Return(*datasetModel, nil)
+		dcRepo.MockDatasetRepo.On("GetStats", mock.Anything,
+			mock.MatchedBy(func(datasetKey models.DatasetKey) bool {
+				return datasetKey.UUID == expectedDataset.Id.UUID
+			})).Return(datasetStats, nil)
+		return dcRepo
+	}
+
+	t.Run("ComputeStats", func(t *testing.T) {
+		dcRepo := setUpRepo()
+		datasetManager := NewDatasetManager(dcRepo, datastore, 0, mockScope.NewTestScope())
+
+		stats, err := datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), stats.ArtifactCount)
+		assert.Equal(t, int64(1), stats.TagCount)
+		assert.Equal(t, datasetStats.PartitionValueCounts, stats.PartitionValueCounts)
+		assert.Equal(t, oldest, *stats.OldestArtifactCreatedAt)
+		assert.Equal(t, newest, *stats.NewestArtifactCreatedAt)
+		assert.Equal(t, int64(5), stats.TotalStoredBytes)
+
+		_, err = datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		dcRepo.MockDatasetRepo.AssertNumberOfCalls(t, "GetStats", 2)
+	})
+
+	t.Run("CachedStats", func(t *testing.T) {
+		dcRepo := setUpRepo()
+		datasetManager := NewDatasetManager(dcRepo, datastore, time.Hour, mockScope.NewTestScope())
+
+		stats, err := datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		cachedStats, err := datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, stats, cachedStats)
+		dcRepo.MockDatasetRepo.AssertNumberOfCalls(t, "GetStats", 1)
+
+		// modifying the returned stats does not modify the cached stats
+		cachedStats.PartitionValueCounts["key1"] = 100
+		cachedStats.ArtifactCount = 100
+		cachedStats, err = datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{Dataset: expectedDataset.Id})
+		assert.NoError(t, err)
+		assert.Equal(t, stats, cachedStats)
+		assert.Equal(t, int64(2), cachedStats.PartitionValueCounts["key1"])
+	})
+
+	t.Run("InvalidDataset", func(t *testing.T) {
+		dcRepo := getDataCatalogRepo()
+		datasetManager := NewDatasetManager(dcRepo, datastore, 0, mockScope.NewTestScope())
+
+		_, err := datasetManager.GetDatasetStats(ctx, &interfaces.GetDatasetStatsRequest{
+			Dataset: &datacatalog.DatasetID{Project: "p"},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,105 @@
+package impl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// The fencing token of the reservation held by the writer of an artifact or tag, formatted as "<tag name>=<token>".
+// The requests to create artifacts and add tags have no field for it, so it is passed as gRPC metadata instead.
+const fencingTokenHeader = "datacatalog-fencing-token"
+
+const fencingTokenSeparator = "="
+
+type fencingToken struct {
+	tagName string
+	token   int64
+}
+
+// Get the fencing token of the incoming request, if any
+func getFencingToken(ctx context.Context) (*fencingToken, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(fencingTokenHeader)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	// tag names may contain the separator, the token can't
+	headerValue := values[0]
+	separatorIndex := strings.LastIndex(headerValue, fencingTokenSeparator)
+	if separatorIndex <= 0 {
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+			"invalid fencing token [%v], expected format <tag name>%v<token>", headerValue, fencingTokenSeparator)
+	}
+
+	token, err := strconv.ParseInt(headerValue[separatorIndex+1:], 10, 64)
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+			"invalid fencing token [%v], the token must be an integer", headerValue)
+	}
+
+	return &fencingToken{
+		tagName: headerValue[:separatorIndex],
+		token:   token,
+	}, nil
+}
+
+// Reject writes to the dataset unless the writer holds the reservation named by the fencing token of the request, so
+// that previous owners of taken over, released or expired reservations can't overwrite the result of the current
+// owner. The slots of the reservation are locked until the transaction of the context ends, so the write must be made
+// within the same transaction for the reservation not to change hands before the write is committed. The slots of a
+// reservation are acquired independently, so a write is accepted if any slot is held with its token. Writes without a
+// fencing token are not fenced.
+func checkFencingToken(ctx context.Context, repo interfaces.ReservationRepo, datasetID *datacatalog.DatasetID, now time.Time) error {
+	fencingToken, err := getFencingToken(ctx)
+	if err != nil || fencingToken == nil {
+		return err
+	}
+
+	reservationKey := transformers.FromReservationID(&datacatalog.ReservationID{
+		DatasetId: datasetID,
+		TagName:   fencingToken.tagName,
+	})
+	reservations, err := repo.GetSlotsForUpdate(ctx, reservationKey)
+	if err != nil {
+		return err
+	}
+
+	var newerReservation *models.Reservation
+	for i := range reservations {
+		if reservations[i].FencingToken == fencingToken.token {
+			if reservations[i].ExpiresAt.After(now) {
+				return nil
+			}
+			return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+				"fencing token %v of reservation %+v is stale, the reservation expired at %v",
+				fencingToken.token, reservationKey, reservations[i].ExpiresAt)
+		}
+		if reservations[i].FencingToken > fencingToken.token {
+			newerReservation = &reservations[i]
+		}
+	}
+
+	if newerReservation != nil {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+			"fencing token %v of reservation %+v is stale, the reservation has been acquired by %v with token %v",
+			fencingToken.token, reservationKey, newerReservation.OwnerID, newerReservation.FencingToken)
+	}
+
+	return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+		"fencing token %v of reservation %+v is stale, the reservation is no longer held", fencingToken.token, reservationKey)
+}
@@ -0,0 +1,103 @@
+package impl
+
+import (
+	"context"
+	"strings"
+
+	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// The filter expression of the list requests can't express metadata or time range filters, so they are passed as
+// gRPC metadata instead. Every value of a header holds a single filter and all filters have to match.
+const (
+	// Filters by metadata, formatted as "<key>=<value>"
+	metadataFilterHeader = "datacatalog-metadata-filter"
+	// Filters by a timestamp, formatted as "[<entity>.]<property>=<start>/<end>" with RFC3339 bounds of which either
+	// may be empty, e.g. "tag.created_at=2023-01-01T00:00:00Z/". Without an entity the listed entity is filtered.
+	timeRangeFilterHeader = "datacatalog-time-filter"
+)
+
+const (
+	metadataFilterSeparator    = "="
+	timeRangeFilterSeparator   = "="
+	timeRangeEntitySeparator   = "."
+	timeRangeIntervalSeparator = "/"
+)
+
+// The entity names which can prefix the property of a time range filter
+var timeRangeFilterEntities = map[string]common.Entity{
+	"artifact": common.Artifact,
+	"dataset":  common.Dataset,
+	"tag":      common.Tag,
+}
+
+// Get the metadata key/value filters of the incoming request
+func getMetadataFilters(ctx context.Context) ([]*datacatalog.KeyValuePair, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	headerValues := md.Get(metadataFilterHeader)
+	metadataFilters := make([]*datacatalog.KeyValuePair, 0, len(headerValues))
+	for _, headerValue := range headerValues {
+		key, value, found := strings.Cut(headerValue, metadataFilterSeparator)
+		if !found {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+				"invalid metadata filter [%v], expected format <key>%v<value>", headerValue, metadataFilterSeparator)
+		}
+		metadataFilters = append(metadataFilters, &datacatalog.KeyValuePair{Key: key, Value: value})
+	}
+
+	return metadataFilters, nil
+}
+
+// Get the time range filters of the incoming request on the source entity
+func getTimeRangeFilters(ctx context.Context, sourceEntity common.Entity) ([]common.TimeRangeFilter, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	headerValues := md.Get(timeRangeFilterHeader)
+	timeRangeFilters := make([]common.TimeRangeFilter, 0, len(headerValues))
+	for _, headerValue := range headerValues {
+		property, interval, found := strings.Cut(headerValue, timeRangeFilterSeparator)
+		if !found {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+				"invalid time range filter [%v], expected format [<entity>.]<property>=<start>/<end>", headerValue)
+		}
+		start, end, found := strings.Cut(interval, timeRangeIntervalSeparator)
+		if !found {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+				"invalid time range filter [%v], expected format [<entity>.]<property>=<start>/<end>", headerValue)
+		}
+
+		entity := sourceEntity
+		if entityName, entityProperty, found := strings.Cut(property, timeRangeEntitySeparator); found {
+			if entity, ok = timeRangeFilterEntities[entityName]; !ok {
+				return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+					"invalid entity [%v] in time range filter [%v]", entityName, headerValue)
+			}
+			property = entityProperty
+		}
+
+		timeRangeFilters = append(timeRangeFilters, common.TimeRangeFilter{
+			Entity:   entity,
+			Property: property,
+			Start:    start,
+			End:      end,
+		})
+	}
+
+	if err := validators.ValidateTimeRangeFilters(sourceEntity, timeRangeFilters); err != nil {
+		return nil, err
+	}
+
+	return timeRangeFilters, nil
+}
@@ -2,13 +2,19 @@ package impl
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"github.com/flyteorg/flytestdlib/promutils/labeled"
 
+	"github.com/flyteorg/datacatalog/pkg/auth"
 	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	"github.com/flyteorg/datacatalog/pkg/repositories"
 	repo_errors "github.com/flyteorg/datacatalog/pkg/repositories/errors"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
@@ -17,26 +23,51 @@ import (
 	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 type reservationMetrics struct {
 	scope                        promutils.Scope
 	reservationAcquired          labeled.Counter
+	reservationExtended          labeled.Counter
 	reservationReleased          labeled.Counter
 	reservationAlreadyInProgress labeled.Counter
 	acquireReservationFailure    labeled.Counter
 	releaseReservationFailure    labeled.Counter
 	reservationDoesNotExist      labeled.Counter
+	listReservationFailure       labeled.Counter
+	reservationForceReleased     labeled.Counter
+	waitForArtifactFailure       labeled.Counter
+	reservationHandedOff         labeled.Counter
+	handOffReservationFailure    labeled.Counter
 }
 
 type NowFunc func() time.Time
 
+// Requests to release a reservation regardless of its owner if set to true
+const forceReleaseHeader = "datacatalog-force-release"
+
+// The metadata the owner attaches to its reservation when acquiring or extending it, e.g. its progress. Every value of
+// the header holds a single entry, formatted as "<key>=<value>". The metadata is kept if the header is not set.
+const reservationMetadataHeader = "datacatalog-reservation-metadata"
+
+const reservationMetadataSeparator = "="
+
+// How many owners may hold the reservation at the same time, each in its own slot. The capacity is persisted with the
+// slots of the reservation, owners which don't set the header get the capacity of the held slots or a capacity of one.
+const reservationCapacityHeader = "datacatalog-reservation-capacity"
+
 type reservationManager struct {
 	repo                           repositories.RepositoryInterface
 	heartbeatGracePeriodMultiplier time.Duration
 	maxHeartbeatInterval           time.Duration
 	now                            NowFunc
+	notifier                       notifications.Notifier
+	maxArtifactWait                time.Duration
+	queueWaiters                   bool
 	systemMetrics                  reservationMetrics
+	telemetry                      *reservationTelemetry
 }
 
 // Creates a new reservation manager with the specified properties
@@ -45,6 +76,9 @@ func NewReservationManager(
 	heartbeatGracePeriodMultiplier time.Duration,
 	maxHeartbeatInterval time.Duration,
 	nowFunc NowFunc, // Easier to mock time.Time for testing
+	notifier notifications.Notifier,
+	maxArtifactWait time.Duration,
+	queueWaiters bool, // Hand reservations to owners in the order they requested them
 	reservationScope promutils.Scope,
 ) interfaces.ReservationManager {
 	systemMetrics := reservationMetrics{
@@ -53,13 +87,17 @@ func NewReservationManager(
 			"reservation_acquired",
 			"Number of times a reservation was acquired",
 			reservationScope),
+		reservationExtended: labeled.NewCounter(
+			"reservation_extended",
+			"Number of times a reservation was extended by its owner",
+			reservationScope),
 		reservationReleased: labeled.NewCounter(
 			"reservation_released",
 			"Number of times a reservation was released",
 			reservationScope),
 		reservationAlreadyInProgress: labeled.NewCounter(
 			"reservation_already_in_progress",
-			"Number of times we try of acquire a reservation but the reservation is in progress",
+			"Number of times we try of acquire a reservation but the reservation is held by another owner",
 			reservationScope,
 		),
 		acquireReservationFailure: labeled.NewCounter(
@@ -77,6 +115,31 @@ func NewReservationManager(
 			"Number of times we attempt to modify a reservation that does not exist",
 			reservationScope,
 		),
+		listReservationFailure: labeled.NewCounter(
+			"list_reservation_failure",
+			"Number of times we failed to list reservations",
+			reservationScope,
+		),
+		reservationForceReleased: labeled.NewCounter(
+			"reservation_force_released",
+			"Number of times a reservation was released by an administrator regardless of its owner",
+			reservationScope,
+		),
+		waitForArtifactFailure: labeled.NewCounter(
+			"wait_for_artifact_failure",
+			"Number of times we failed to wait for an artifact",
+			reservationScope,
+		),
+		reservationHandedOff: labeled.NewCounter(
+			"reservation_handed_off",
+			"Number of times a reservation was handed to the first owner queued for it",
+			reservationScope,
+		),
+		handOffReservationFailure: labeled.NewCounter(
+			"hand_off_reservation_failure",
+			"Number of times we failed to hand a released reservation to the first owner queued for it",
+			reservationScope,
+		),
 	}
 
 	return &reservationManager{
@@ -84,7 +147,11 @@ func NewReservationManager(
 		heartbeatGracePeriodMultiplier: heartbeatGracePeriodMultiplier,
 		maxHeartbeatInterval:           maxHeartbeatInterval,
 		now:                            nowFunc,
+		notifier:                       notifier,
+		maxArtifactWait:                maxArtifactWait,
+		queueWaiters:                   queueWaiters,
 		systemMetrics:                  systemMetrics,
+		telemetry:                      newReservationTelemetry(reservationScope),
 	}
 }
 
@@ -92,6 +159,7 @@ func NewReservationManager(
 // acquire it. If you are the owner of the active reservation, extend it. If another owner, return the existing reservation.
 func (r *reservationManager) GetOrExtendReservation(ctx context.Context, request *datacatalog.GetOrExtendReservationRequest) (*datacatalog.GetOrExtendReservationResponse, error) {
 	reservationID := request.ReservationId
+	ctx = contextutils.WithProjectDomain(ctx, reservationID.GetDatasetId().GetProject(), reservationID.GetDatasetId().GetDomain())
 
 	// Use minimum of maxHeartbeatInterval and requested heartbeat interval
 	heartbeatInterval := r.maxHeartbeatInterval
@@ -100,7 +168,28 @@ func (r *reservationManager) GetOrExtendReservation(ctx context.Context, request
 		heartbeatInterval = requestHeartbeatInterval.AsDuration()
 	}
 
-	reservation, err := r.tryAcquireReservation(ctx, reservationID, request.OwnerId, heartbeatInterval)
+	metadata, err := getReservationMetadata(ctx)
+	if err != nil {
+		r.systemMetrics.acquireReservationFailure.Inc(ctx)
+		return nil, err
+	}
+	if err := validators.ValidateReservationMetadata(metadata); err != nil {
+		r.systemMetrics.acquireReservationFailure.Inc(ctx)
+		return nil, err
+	}
+	serializedMetadata, err := transformers.SerializeReservationMetadata(metadata)
+	if err != nil {
+		r.systemMetrics.acquireReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	capacity, err := getReservationCapacity(ctx)
+	if err != nil {
+		r.systemMetrics.acquireReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	reservation, err := r.tryAcquireReservation(ctx, reservationID, request.OwnerId, heartbeatInterval, capacity, serializedMetadata)
 	if err != nil {
 		r.systemMetrics.acquireReservationFailure.Inc(ctx)
 		return nil, err
@@ -117,34 +206,79 @@ func (r *reservationManager) GetOrExtendReservation(ctx context.Context, request
 // to do a GET here because we want to know who owns the reservation
 // and show it to users on the UI. However, the reservation is held by a single
 // task most of the times and there is no need to do a write.
-func (r *reservationManager) tryAcquireReservation(ctx context.Context, reservationID *datacatalog.ReservationID, ownerID string, heartbeatInterval time.Duration) (datacatalog.Reservation, error) {
+// Reservations with a capacity of more than one are acquired in the slot
+// chosen by findReservationSlot, each slot is held and extended independently.
+func (r *reservationManager) tryAcquireReservation(ctx context.Context, reservationID *datacatalog.ReservationID, ownerID string, heartbeatInterval time.Duration, capacity int, serializedMetadata []byte) (datacatalog.Reservation, error) {
 	repo := r.repo.ReservationRepo()
-	reservationKey := transformers.FromReservationID(reservationID)
-	repoReservation, err := repo.Get(ctx, reservationKey)
-
-	reservationExists := true
+	now := r.now()
+	reservationKey, repoReservation, reservationExists, capacity, err := r.findReservationSlot(ctx, transformers.FromReservationID(reservationID), ownerID, capacity, now)
 	if err != nil {
-		if errors.IsDoesNotExistError(err) {
-			// Reservation does not exist yet so let's create one
-			reservationExists = false
-		} else {
-			return datacatalog.Reservation{}, err
-		}
+		return datacatalog.Reservation{}, err
 	}
 
-	now := r.now()
 	newRepoReservation := models.Reservation{
-		ReservationKey: reservationKey,
-		OwnerID:        ownerID,
-		ExpiresAt:      now.Add(heartbeatInterval * r.heartbeatGracePeriodMultiplier),
+		ReservationKey:     reservationKey,
+		OwnerID:            ownerID,
+		ExpiresAt:          now.Add(heartbeatInterval * r.heartbeatGracePeriodMultiplier),
+		SerializedMetadata: serializedMetadata,
+		AcquiredAt:         &now,
+		Capacity:           capacity,
+	}
+	extended := reservationExists && repoReservation.OwnerID == ownerID
+	free := !reservationExists || repoReservation.ExpiresAt.Before(now)
+
+	// With queueing, a free reservation is handed to the first queued owner
+	// unless the requesting owner is first itself. Owners which find the
+	// reservation held are queued.
+	var waiters []models.ReservationWaiter
+	var handedOffTo *models.ReservationWaiter
+	if r.queueWaiters && !extended {
+		waiters, err = repo.ListWaiters(ctx, reservationKey, now)
+		if err != nil {
+			return datacatalog.Reservation{}, err
+		}
+		if free && len(waiters) > 0 && waiters[0].OwnerID != ownerID {
+			handedOffTo = &waiters[0]
+			waiters = waiters[1:]
+			// the queued owner has the maximum heartbeat interval to claim the reservation
+			newRepoReservation.OwnerID = handedOffTo.OwnerID
+			newRepoReservation.ExpiresAt = now.Add(r.maxHeartbeatInterval * r.heartbeatGracePeriodMultiplier)
+			newRepoReservation.SerializedMetadata = nil
+			serializedMetadata = nil
+		}
 	}
 
 	// Conditional upsert on reservation. Race conditions are handled
 	// within the reservation repository Create and Update function calls.
+	// The fencing token is kept while the owner extends the reservation and
+	// a new token is taken from the sequence whenever a new owner acquires
+	// it, so that it exceeds the tokens of all previous owners.
 	var repoErr error
 	if !reservationExists {
+		newRepoReservation.FencingToken, err = repo.NextFencingToken(ctx)
+		if err != nil {
+			return datacatalog.Reservation{}, err
+		}
 		repoErr = repo.Create(ctx, newRepoReservation, now)
-	} else if repoReservation.ExpiresAt.Before(now) || repoReservation.OwnerID == ownerID {
+	} else if free || extended {
+		newRepoReservation.FencingToken = repoReservation.FencingToken
+		if !extended {
+			newRepoReservation.FencingToken, err = repo.NextFencingToken(ctx)
+			if err != nil {
+				return datacatalog.Reservation{}, err
+			}
+		}
+		// the owner keeps its metadata unless it supplies new metadata, a new owner must not inherit it
+		if serializedMetadata == nil {
+			if extended {
+				newRepoReservation.SerializedMetadata = repoReservation.SerializedMetadata
+			} else {
+				newRepoReservation.SerializedMetadata = []byte{}
+			}
+		}
+		if extended {
+			newRepoReservation.AcquiredAt = repoReservation.AcquiredAt
+		}
 		repoErr = repo.Update(ctx, newRepoReservation, now)
 	} else {
 		logger.Debugf(ctx, "Reservation: %+v is held by %s", reservationKey, repoReservation.OwnerID)
@@ -154,6 +288,13 @@ func (r *reservationManager) tryAcquireReservation(ctx context.Context, reservat
 			return reservation, err
 		}
 
+		if r.queueWaiters {
+			if err := r.enqueueWaiter(ctx, &reservation, reservationKey, waiters, ownerID, heartbeatInterval, now); err != nil {
+				return datacatalog.Reservation{}, err
+			}
+		}
+
+		r.telemetry.observeWaiting(reservationKey, ownerID, now)
 		r.systemMetrics.reservationAlreadyInProgress.Inc(ctx)
 		return reservation, nil
 	}
@@ -172,6 +313,9 @@ func (r *reservationManager) tryAcquireReservation(ctx context.Context, reservat
 				return reservation, err
 			}
 
+			if rsv1.OwnerID != ownerID {
+				r.telemetry.observeWaiting(reservationKey, ownerID, now)
+			}
 			r.systemMetrics.reservationAlreadyInProgress.Inc(ctx)
 			return reservation, nil
 		}
@@ -185,16 +329,236 @@ func (r *reservationManager) tryAcquireReservation(ctx context.Context, reservat
 		return reservation, err
 	}
 
-	r.systemMetrics.reservationAlreadyInProgress.Inc(ctx)
+	if extended {
+		r.systemMetrics.reservationExtended.Inc(ctx)
+		return reservation, nil
+	}
+
+	if reservationExists {
+		r.telemetry.observeTakeover(ctx, repoReservation)
+	}
+	r.telemetry.observeAcquired(ctx, reservationKey, newRepoReservation.OwnerID, now)
+
+	if handedOffTo != nil || len(waiters) > 0 {
+		// the acquiring owner leaves the queue
+		if err := repo.Dequeue(ctx, reservationKey, newRepoReservation.OwnerID); err != nil {
+			return datacatalog.Reservation{}, err
+		}
+	}
+
+	if handedOffTo != nil {
+		if err := r.enqueueWaiter(ctx, &reservation, reservationKey, waiters, ownerID, heartbeatInterval, now); err != nil {
+			return datacatalog.Reservation{}, err
+		}
+
+		r.telemetry.observeWaiting(reservationKey, ownerID, now)
+		r.systemMetrics.reservationHandedOff.Inc(ctx)
+		return reservation, nil
+	}
+
+	r.systemMetrics.reservationAcquired.Inc(ctx)
 	return reservation, nil
 }
 
-// Release an active reservation with the specified owner. If one does not exist, gracefully return.
+// Queue the owner for the reservation held by another owner and report its position in the queue of the waiters, in
+// the metadata of the returned reservation. Owners which are queued already keep their position.
+func (r *reservationManager) enqueueWaiter(ctx context.Context, reservation *datacatalog.Reservation, reservationKey models.ReservationKey, waiters []models.ReservationWaiter, ownerID string, heartbeatInterval time.Duration, now time.Time) error {
+	// owners are queued for all slots of the key
+	reservationKey.Slot = 0
+	err := r.repo.ReservationRepo().Enqueue(ctx, models.ReservationWaiter{
+		ReservationKey: reservationKey,
+		OwnerID:        ownerID,
+		EnqueuedAt:     now,
+		ExpiresAt:      now.Add(heartbeatInterval * r.heartbeatGracePeriodMultiplier),
+	})
+	if err != nil {
+		return err
+	}
+
+	position := len(waiters) + 1
+	for i, waiter := range waiters {
+		if waiter.OwnerID == ownerID {
+			position = i + 1
+			break
+		}
+	}
+
+	reservation.Metadata.KeyMap[interfaces.ReservationMetadataQueuePosition] = strconv.Itoa(position)
+	return nil
+}
+
+// ReservationHandOff hands released reservations to the owners queued for them. Reservations are released by the
+// ArtifactManager as well, when they are completed.
+type ReservationHandOff interface {
+	HandOffReservation(ctx context.Context, reservationKey models.ReservationKey)
+}
+
+// Hand the released slot of the reservation to the first owner queued for it, so that it is not acquired by an owner
+// which requested it later. The queued owner has the maximum heartbeat interval to claim the reservation. Failing to
+// hand it off does not fail the release, the reservation is then acquired by the next owner requesting it.
+func (r *reservationManager) HandOffReservation(ctx context.Context, reservationKey models.ReservationKey) {
+	if !r.queueWaiters {
+		return
+	}
+
+	repo := r.repo.ReservationRepo()
+	now := r.now()
+	waiters, err := repo.ListWaiters(ctx, reservationKey, now)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list the waiters of reservation %+v, err: %v", reservationKey, err)
+		r.systemMetrics.handOffReservationFailure.Inc(ctx)
+		return
+	}
+	if len(waiters) == 0 {
+		return
+	}
+
+	// the slot is handed off with the capacity of the slots still held, if none are held the capacity is left to the
+	// next owner acquiring the reservation
+	repoReservations, err := repo.GetSlots(ctx, reservationKey)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get the slots of reservation %+v, err: %v", reservationKey, err)
+		r.systemMetrics.handOffReservationFailure.Inc(ctx)
+		return
+	}
+
+	ownerID := waiters[0].OwnerID
+	fencingToken, err := repo.NextFencingToken(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get a fencing token for reservation %+v, err: %v", reservationKey, err)
+		r.systemMetrics.handOffReservationFailure.Inc(ctx)
+		return
+	}
+	err = repo.Create(ctx, models.Reservation{
+		ReservationKey: reservationKey,
+		OwnerID:        ownerID,
+		ExpiresAt:      now.Add(r.maxHeartbeatInterval * r.heartbeatGracePeriodMultiplier),
+		FencingToken:   fencingToken,
+		AcquiredAt:     &now,
+		Capacity:       getHeldCapacity(repoReservations, now),
+	}, now)
+	if err != nil {
+		// another owner may have acquired the reservation in the meantime
+		logger.Warnf(ctx, "Failed to hand reservation %+v to %s, err: %v", reservationKey, ownerID, err)
+		r.systemMetrics.handOffReservationFailure.Inc(ctx)
+		return
+	}
+
+	if err := repo.Dequeue(ctx, reservationKey, ownerID); err != nil {
+		logger.Errorf(ctx, "Failed to remove %s from the queue of reservation %+v, err: %v", ownerID, reservationKey, err)
+	}
+
+	logger.Debugf(ctx, "Reservation %+v was handed to %s", reservationKey, ownerID)
+	r.telemetry.observeAcquired(ctx, reservationKey, ownerID, now)
+	r.systemMetrics.reservationHandedOff.Inc(ctx)
+}
+
+// Find the slot of the reservation key to be acquired or extended by the owner. The owner keeps the slot it holds or
+// gets the first slot which is not held or has expired, reservations with a capacity of one are held in slot 0. If all
+// slots are held by other owners the one expiring first is returned. Returns the key of the slot, its reservation,
+// whether the reservation exists and the capacity of the reservation.
+func (r *reservationManager) findReservationSlot(ctx context.Context, reservationKey models.ReservationKey, ownerID string, requestedCapacity int, now time.Time) (models.ReservationKey, models.Reservation, bool, int, error) {
+	repoReservations, err := r.repo.ReservationRepo().GetSlots(ctx, reservationKey)
+	if err != nil {
+		return reservationKey, models.Reservation{}, false, 0, err
+	}
+
+	// the owner keeps its slot even if it is beyond the capacity the reservation was acquired with after it expired. It
+	// extends the slot with the capacity the reservation is held with, regardless of the capacity it requests.
+	for _, repoReservation := range repoReservations {
+		if repoReservation.OwnerID == ownerID {
+			capacity := getHeldCapacity(repoReservations, now)
+			if capacity == 0 {
+				capacity = requestedCapacity
+			}
+			if capacity == 0 {
+				capacity = 1
+			}
+			return repoReservation.ReservationKey, repoReservation, true, capacity, nil
+		}
+	}
+
+	capacity, err := getReservationSlotsCapacity(reservationKey, repoReservations, requestedCapacity, now)
+	if err != nil {
+		return reservationKey, models.Reservation{}, false, 0, err
+	}
+
+	slots := make(map[int]models.Reservation, len(repoReservations))
+	for _, repoReservation := range repoReservations {
+		slots[repoReservation.Slot] = repoReservation
+	}
+
+	var firstExpiring models.Reservation
+	for slot := 0; slot < capacity; slot++ {
+		repoReservation, ok := slots[slot]
+		if !ok {
+			reservationKey.Slot = slot
+			return reservationKey, models.Reservation{}, false, capacity, nil
+		}
+		if repoReservation.ExpiresAt.Before(now) {
+			return repoReservation.ReservationKey, repoReservation, true, capacity, nil
+		}
+		if slot == 0 || repoReservation.ExpiresAt.Before(firstExpiring.ExpiresAt) {
+			firstExpiring = repoReservation
+		}
+	}
+
+	return firstExpiring.ReservationKey, firstExpiring, true, capacity, nil
+}
+
+// Get the capacity of the reservation, so that all owners hold it with the same capacity. Requesting a different
+// capacity than the held slots were acquired with fails until the slots are released or expire. The requested capacity,
+// or one if none was requested, applies if no slots are held.
+func getReservationSlotsCapacity(reservationKey models.ReservationKey, repoReservations []models.Reservation, requestedCapacity int, now time.Time) (int, error) {
+	capacity := getHeldCapacity(repoReservations, now)
+	switch {
+	case capacity == 0 && requestedCapacity == 0:
+		return 1, nil
+	case capacity == 0:
+		return requestedCapacity, nil
+	case requestedCapacity == 0 || requestedCapacity == capacity:
+		return capacity, nil
+	default:
+		return 0, errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+			"reservation %+v is held with a capacity of %d, requested a capacity of %d", reservationKey, capacity,
+			requestedCapacity)
+	}
+}
+
+// Get the capacity the held slots of the reservation were acquired with, zero if none are held
+func getHeldCapacity(repoReservations []models.Reservation, now time.Time) int {
+	capacity := 0
+	for _, repoReservation := range repoReservations {
+		if !repoReservation.ExpiresAt.Before(now) && repoReservation.Capacity > capacity {
+			capacity = repoReservation.Capacity
+		}
+	}
+	return capacity
+}
+
+// Release an active reservation with the specified owner. If one does not exist, gracefully return. Administrators can
+// set the force release header to release the reservation regardless of its owner, so that clients of the flyteidl
+// DataCatalog service can force the release without ForceReleaseReservation. The owner of the request is ignored then.
 func (r *reservationManager) ReleaseReservation(ctx context.Context, request *datacatalog.ReleaseReservationRequest) (*datacatalog.ReleaseReservationResponse, error) {
+	if isForceRelease(ctx) {
+		_, err := r.ForceReleaseReservation(ctx, &interfaces.ForceReleaseReservationRequest{
+			ReservationID: request.ReservationId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &datacatalog.ReleaseReservationResponse{}, nil
+	}
+
+	ctx = contextutils.WithProjectDomain(ctx, request.ReservationId.GetDatasetId().GetProject(), request.ReservationId.GetDatasetId().GetDomain())
 	repo := r.repo.ReservationRepo()
 	reservationKey := transformers.FromReservationID(request.ReservationId)
 
-	err := repo.Delete(ctx, reservationKey, request.OwnerId)
+	// the reservation is looked up before it is deleted to record how long it was held, the owner may hold any slot
+	repoReservations, err := repo.GetSlots(ctx, reservationKey)
+	if err == nil {
+		err = repo.Delete(ctx, reservationKey, request.OwnerId)
+	}
 	if err != nil {
 		if errors.IsDoesNotExistError(err) {
 			logger.Warnf(ctx, "Reservation does not exist id: %+v, err %v", request.ReservationId, err)
@@ -207,6 +571,306 @@ func (r *reservationManager) ReleaseReservation(ctx context.Context, request *da
 		return nil, err
 	}
 
+	releasedKey := reservationKey
+	for _, repoReservation := range repoReservations {
+		if repoReservation.OwnerID == request.OwnerId {
+			r.telemetry.observeHeld(ctx, repoReservation, r.now())
+			releasedKey = repoReservation.ReservationKey
+		}
+	}
+
+	r.HandOffReservation(ctx, releasedKey)
+	r.notifier.Publish(ctx, notifications.ReservationKey(request.ReservationId))
 	r.systemMetrics.reservationReleased.Inc(ctx)
 	return &datacatalog.ReleaseReservationResponse{}, nil
 }
+
+// List the reservations matching the filters of the request, regardless of their owner. Expired reservations are
+// kept until they are taken over or released, so they can be listed as well.
+func (r *reservationManager) ListReservations(ctx context.Context, request *interfaces.ListReservationsRequest) (*interfaces.ListReservationsResponse, error) {
+	if err := validators.ValidateListReservationsRequest(request); err != nil {
+		logger.Warningf(ctx, "Invalid list reservations request %+v, err: %v", request, err)
+		r.systemMetrics.listReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	listInput := transformers.ToReservationListInput(request, r.now())
+	if err := transformers.ApplyPagination(request.Pagination, &listInput); err != nil {
+		logger.Warningf(ctx, "Invalid pagination options in list reservations request %+v, err: %v", request, err)
+		r.systemMetrics.listReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	repoReservations, err := r.repo.ReservationRepo().List(ctx, listInput)
+	if err != nil {
+		logger.Errorf(ctx, "Unable to list reservations, err: %v", err)
+		r.systemMetrics.listReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	reservations := make([]*datacatalog.Reservation, 0, len(repoReservations))
+	for i := range repoReservations {
+		reservation, err := transformers.CreateReservation(&repoReservations[i], 0)
+		if err != nil {
+			r.systemMetrics.listReservationFailure.Inc(ctx)
+			return nil, err
+		}
+
+		// the heartbeat interval is only known to the owner of a reservation
+		reservation.HeartbeatInterval = nil
+		reservations = append(reservations, &reservation)
+	}
+
+	return &interfaces.ListReservationsResponse{
+		Reservations: reservations,
+		NextToken:    strconv.Itoa(listInput.Offset + len(reservations)),
+	}, nil
+}
+
+// Release a reservation regardless of its owner, e.g. if the owner is gone and its reservation would block other
+// owners until it expires. Only authenticated administrators may force the release, which is recorded along with
+// their identity.
+func (r *reservationManager) ForceReleaseReservation(ctx context.Context, request *interfaces.ForceReleaseReservationRequest) (*interfaces.ForceReleaseReservationResponse, error) {
+	releasedBy := auth.IdentityFromContext(ctx)
+	if releasedBy == "" {
+		r.systemMetrics.releaseReservationFailure.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.Unauthenticated, "force releasing reservations requires an authenticated identity")
+	}
+
+	if !auth.IsAdmin(ctx) {
+		r.systemMetrics.releaseReservationFailure.Inc(ctx)
+		return nil, errors.NewDataCatalogErrorf(codes.PermissionDenied, "force releasing reservations requires admin privileges")
+	}
+
+	if err := validators.ValidateForceReleaseReservationRequest(request); err != nil {
+		r.systemMetrics.releaseReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	repo := r.repo.ReservationRepo()
+	reservationKey := transformers.FromReservationID(request.ReservationID)
+	reservationKey.Slot = request.Slot
+
+	reservation, err := repo.Get(ctx, reservationKey)
+	if err != nil {
+		if errors.IsDoesNotExistError(err) {
+			r.systemMetrics.reservationDoesNotExist.Inc(ctx)
+		} else {
+			r.systemMetrics.releaseReservationFailure.Inc(ctx)
+		}
+		return nil, err
+	}
+
+	// deleting with the current owner fails if the reservation has been taken over in the meantime
+	err = r.repo.Transaction(ctx, func(ctx context.Context) error {
+		if err := repo.Delete(ctx, reservationKey, reservation.OwnerID); err != nil {
+			return err
+		}
+
+		return repo.CreateForceRelease(ctx, models.ReservationForceRelease{
+			ReservationKey: reservationKey,
+			ReleasedAt:     r.now(),
+			ReleasedBy:     releasedBy,
+			Reason:         request.Reason,
+			OwnerID:        reservation.OwnerID,
+			ExpiresAt:      reservation.ExpiresAt,
+		})
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to force release reservation: %+v, err: %v", reservationKey, err)
+		r.systemMetrics.releaseReservationFailure.Inc(ctx)
+		return nil, err
+	}
+
+	logger.Infof(ctx, "Reservation %+v held by %s until %v was force released by %s, reason: %s", reservationKey,
+		reservation.OwnerID, reservation.ExpiresAt, releasedBy, request.Reason)
+	r.HandOffReservation(ctx, reservationKey)
+	r.notifier.Publish(ctx, notifications.ReservationKey(request.ReservationID))
+	r.systemMetrics.reservationForceReleased.Inc(ctx)
+	return &interfaces.ForceReleaseReservationResponse{
+		ReleasedOwnerID: reservation.OwnerID,
+	}, nil
+}
+
+// Wait until the artifact of the reservation is tagged or the reservation is no longer held, i.e. it is released or
+// expires, so that waiting owners don't have to poll for either. Waiters are woken up by the notifications of the
+// tag and release writes and when the reservation is due to expire. Returns the reservation still holding the
+// artifact once the timeout has passed.
+func (r *reservationManager) WaitForArtifact(ctx context.Context, request *interfaces.WaitForArtifactRequest) (*interfaces.WaitForArtifactResponse, error) {
+	if err := validators.ValidateWaitForArtifactRequest(request); err != nil {
+		r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+		return nil, err
+	}
+
+	timeout := r.maxArtifactWait
+	if request.Timeout > 0 && request.Timeout < timeout {
+		timeout = request.Timeout
+	}
+	timeoutTimer := time.NewTimer(timeout)
+	defer timeoutTimer.Stop()
+
+	// subscribe before the lookups so that no write in between is missed
+	subscription := r.notifier.Subscribe(notifications.ReservationKey(request.ReservationID))
+	defer subscription.Close()
+
+	datasetID := request.ReservationID.DatasetId
+	tagKey := transformers.ToTagKey(datasetID, request.ReservationID.TagName)
+	reservationKey := transformers.FromReservationID(request.ReservationID)
+	for {
+		tag, err := r.repo.TagRepo().Get(ctx, tagKey)
+		if err == nil {
+			artifact, err := transformers.FromArtifactModel(tag.Artifact)
+			if err != nil {
+				r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+				return nil, err
+			}
+
+			return &interfaces.WaitForArtifactResponse{
+				Result:   interfaces.WaitForArtifactResultAvailable,
+				Artifact: artifact,
+			}, nil
+		} else if !errors.IsDoesNotExistError(err) {
+			r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+			return nil, err
+		}
+
+		// released slots are deleted, so with multiple slots the reservation is only reported released once all of
+		// them are released, while it is reported expired once any slot expires below
+		repoReservations, err := r.repo.ReservationRepo().GetSlots(ctx, reservationKey)
+		if err != nil {
+			r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+			return nil, err
+		}
+		if len(repoReservations) == 0 {
+			return &interfaces.WaitForArtifactResponse{
+				Result: interfaces.WaitForArtifactResultReleased,
+			}, nil
+		}
+
+		// queued owners stop waiting once the reservation has been handed to them
+		if request.OwnerID != "" {
+			for i := range repoReservations {
+				if repoReservations[i].OwnerID != request.OwnerID {
+					continue
+				}
+
+				reservation, err := transformers.CreateReservation(&repoReservations[i], 0)
+				if err != nil {
+					r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+					return nil, err
+				}
+				reservation.HeartbeatInterval = nil
+				return &interfaces.WaitForArtifactResponse{
+					Result:      interfaces.WaitForArtifactResultAcquired,
+					Reservation: &reservation,
+				}, nil
+			}
+		}
+
+		repoReservation := repoReservations[0]
+		for _, slotReservation := range repoReservations[1:] {
+			if slotReservation.ExpiresAt.Before(repoReservation.ExpiresAt) {
+				repoReservation = slotReservation
+			}
+		}
+
+		reservation, err := transformers.CreateReservation(&repoReservation, 0)
+		if err != nil {
+			r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+			return nil, err
+		}
+		// the heartbeat interval is only known to the owner of a reservation
+		reservation.HeartbeatInterval = nil
+
+		expiresIn := repoReservation.ExpiresAt.Sub(r.now())
+		if expiresIn <= 0 {
+			return &interfaces.WaitForArtifactResponse{
+				Result:      interfaces.WaitForArtifactResultExpired,
+				Reservation: &reservation,
+			}, nil
+		}
+
+		// extensions of the reservation are not notified, so it is looked up again once it was due to expire
+		expiryTimer := time.NewTimer(expiresIn)
+		select {
+		case <-subscription.C:
+		case <-expiryTimer.C:
+		case <-timeoutTimer.C:
+			expiryTimer.Stop()
+			return &interfaces.WaitForArtifactResponse{
+				Result:      interfaces.WaitForArtifactResultTimedOut,
+				Reservation: &reservation,
+			}, nil
+		case <-ctx.Done():
+			expiryTimer.Stop()
+			r.systemMetrics.waitForArtifactFailure.Inc(ctx)
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, errors.NewDataCatalogErrorf(codes.DeadlineExceeded, "deadline exceeded while waiting for artifact %+v", reservationKey)
+			}
+			return nil, errors.NewDataCatalogErrorf(codes.Canceled, "canceled while waiting for artifact %+v", reservationKey)
+		}
+		expiryTimer.Stop()
+	}
+}
+
+// Get the metadata the owner supplied for its reservation, nil if none was supplied
+func getReservationMetadata(ctx context.Context) (*datacatalog.Metadata, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(reservationMetadataHeader)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	keyMap := make(map[string]string, len(values))
+	for _, headerValue := range values {
+		key, value, found := strings.Cut(headerValue, reservationMetadataSeparator)
+		if !found || key == "" {
+			return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+				"invalid reservation metadata [%v], expected format <key>%v<value>", headerValue, reservationMetadataSeparator)
+		}
+		keyMap[key] = value
+	}
+
+	return &datacatalog.Metadata{KeyMap: keyMap}, nil
+}
+
+// Get the capacity of the reservation requested by the owner, zero if none was requested
+func getReservationCapacity(ctx context.Context) (int, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+
+	values := md.Get(reservationCapacityHeader)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	capacity, err := strconv.Atoi(values[0])
+	if err != nil || capacity < 1 {
+		return 0, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+			"invalid reservation capacity [%v], the capacity must be a positive integer", values[0])
+	}
+
+	return capacity, nil
+}
+
+// The release reservation request has no field to force the release, so it is requested with a header instead
+func isForceRelease(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(forceReleaseHeader)
+	if len(values) == 0 {
+		return false
+	}
+
+	forceRelease, err := strconv.ParseBool(values[0])
+	return err == nil && forceRelease
+}
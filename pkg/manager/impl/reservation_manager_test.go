@@ -3,21 +3,27 @@ package impl
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	mockScope "github.com/flyteorg/flytestdlib/promutils"
 
 	"testing"
 	"time"
 
+	"github.com/flyteorg/datacatalog/pkg/auth"
 	errors2 "github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	errors3 "github.com/flyteorg/datacatalog/pkg/repositories/errors"
 	"github.com/flyteorg/datacatalog/pkg/repositories/mocks"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 var tagName = "tag"
@@ -42,13 +48,15 @@ var maxHeartbeatIntervalPb = ptypes.DurationProto(maxHeartbeatInterval)
 var heartbeatGracePeriodMultiplier = time.Second * 3
 var prevOwner = "prevOwner"
 var currentOwner = "currentOwner"
+var prevFencingToken int64 = 1
+var maxArtifactWait = time.Second * 5
 
 func TestGetOrExtendReservation_CreateReservation(t *testing.T) {
 	dcRepo := getDatacatalogRepo()
 
 	setUpTagRepoGetNotFound(&dcRepo)
 
-	dcRepo.MockReservationRepo.On("Get",
+	dcRepo.MockReservationRepo.On("GetSlots",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
 		mock.MatchedBy(func(key models.ReservationKey) bool {
 			return key.DatasetProject == datasetID.Project &&
@@ -56,7 +64,7 @@ func TestGetOrExtendReservation_CreateReservation(t *testing.T) {
 				key.DatasetVersion == datasetID.Version &&
 				key.DatasetName == datasetID.Name &&
 				key.TagName == tagName
-		})).Return(models.Reservation{}, errors2.NewDataCatalogErrorf(codes.NotFound, "entry not found"))
+		})).Return([]models.Reservation{}, nil)
 
 	now := time.Now()
 
@@ -69,14 +77,15 @@ func TestGetOrExtendReservation_CreateReservation(t *testing.T) {
 				reservation.DatasetVersion == datasetID.Version &&
 				reservation.TagName == tagName &&
 				reservation.OwnerID == currentOwner &&
-				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier)
+				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier) &&
+				reservation.FencingToken == prevFencingToken+1
 		}),
 		mock.MatchedBy(func(now time.Time) bool { return true }),
 	).Return(nil)
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.GetOrExtendReservationRequest{
 		ReservationId:     &reservationID,
@@ -89,6 +98,7 @@ func TestGetOrExtendReservation_CreateReservation(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
 	assert.Equal(t, heartbeatIntervalPb, resp.GetReservation().HeartbeatInterval)
+	assert.Equal(t, strconv.FormatInt(prevFencingToken+1, 10), resp.GetReservation().Metadata.KeyMap["fencing_token"])
 }
 
 func TestGetOrExtendReservation_MaxHeartbeatInterval(t *testing.T) {
@@ -96,7 +106,7 @@ func TestGetOrExtendReservation_MaxHeartbeatInterval(t *testing.T) {
 
 	setUpTagRepoGetNotFound(&dcRepo)
 
-	dcRepo.MockReservationRepo.On("Get",
+	dcRepo.MockReservationRepo.On("GetSlots",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
 		mock.MatchedBy(func(key models.ReservationKey) bool {
 			return key.DatasetProject == datasetID.Project &&
@@ -104,7 +114,7 @@ func TestGetOrExtendReservation_MaxHeartbeatInterval(t *testing.T) {
 				key.DatasetVersion == datasetID.Version &&
 				key.DatasetName == datasetID.Name &&
 				key.TagName == tagName
-		})).Return(models.Reservation{}, errors2.NewDataCatalogErrorf(codes.NotFound, "entry not found"))
+		})).Return([]models.Reservation{}, nil)
 
 	now := time.Now()
 
@@ -124,7 +134,7 @@ func TestGetOrExtendReservation_MaxHeartbeatInterval(t *testing.T) {
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, heartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.GetOrExtendReservationRequest{
 		ReservationId:     &reservationID,
@@ -158,14 +168,16 @@ func TestGetOrExtendReservation_ExtendReservation(t *testing.T) {
 				reservation.DatasetVersion == datasetID.Version &&
 				reservation.TagName == tagName &&
 				reservation.OwnerID == prevOwner &&
-				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier)
+				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier) &&
+				reservation.FencingToken == prevFencingToken &&
+				reservation.AcquiredAt == nil
 		}),
 		mock.MatchedBy(func(now time.Time) bool { return true }),
 	).Return(nil)
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.GetOrExtendReservationRequest{
 		ReservationId:     &reservationID,
@@ -177,6 +189,7 @@ func TestGetOrExtendReservation_ExtendReservation(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, prevOwner, resp.GetReservation().OwnerId)
+	assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap["fencing_token"])
 }
 
 func TestGetOrExtendReservation_TakeOverReservation(t *testing.T) {
@@ -198,14 +211,16 @@ func TestGetOrExtendReservation_TakeOverReservation(t *testing.T) {
 				reservation.DatasetVersion == datasetID.Version &&
 				reservation.TagName == tagName &&
 				reservation.OwnerID == currentOwner &&
-				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier)
+				reservation.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier) &&
+				reservation.FencingToken == prevFencingToken+1 &&
+				reservation.AcquiredAt != nil && reservation.AcquiredAt.Equal(now)
 		}),
 		mock.MatchedBy(func(now time.Time) bool { return true }),
 	).Return(nil)
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.GetOrExtendReservationRequest{
 		ReservationId:     &reservationID,
@@ -217,6 +232,7 @@ func TestGetOrExtendReservation_TakeOverReservation(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+	assert.Equal(t, "2", resp.GetReservation().Metadata.KeyMap["fencing_token"])
 }
 
 func TestGetOrExtendReservation_ReservationExists(t *testing.T) {
@@ -231,7 +247,7 @@ func TestGetOrExtendReservation_ReservationExists(t *testing.T) {
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.GetOrExtendReservationRequest{
 		ReservationId:     &reservationID,
@@ -245,10 +261,469 @@ func TestGetOrExtendReservation_ReservationExists(t *testing.T) {
 	assert.Equal(t, prevOwner, resp.GetReservation().OwnerId)
 }
 
+func TestGetOrExtendReservation_Metadata(t *testing.T) {
+	now := time.Now()
+	req := datacatalog.GetOrExtendReservationRequest{
+		ReservationId:     &reservationID,
+		OwnerId:           prevOwner,
+		HeartbeatInterval: heartbeatIntervalPb,
+	}
+	withMetadata := func(entries ...string) context.Context {
+		pairs := make([]string, 0, 2*len(entries))
+		for _, entry := range entries {
+			pairs = append(pairs, reservationMetadataHeader, entry)
+		}
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+	}
+
+	t.Run("Extend with new metadata", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Second*10))
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.OwnerID == prevOwner && len(reservation.SerializedMetadata) > 0
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		ctx := withMetadata("execution_id=exec", "progress=40")
+		resp, err := reservationManager.GetOrExtendReservation(ctx, &req)
+		assert.NoError(t, err)
+		assert.Equal(t, "exec", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataExecutionID])
+		assert.Equal(t, "40", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataProgress])
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataFencingToken])
+	})
+
+	t.Run("Extend keeps metadata", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		serializedMetadata, err := transformers.SerializeReservationMetadata(&datacatalog.Metadata{
+			KeyMap: map[string]string{interfaces.ReservationMetadataProgress: "40"},
+		})
+		assert.NoError(t, err)
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, mock.Anything).Return([]models.Reservation{{
+			ReservationKey:     getReservationKey(),
+			OwnerID:            prevOwner,
+			ExpiresAt:          now.Add(time.Second * 10),
+			SerializedMetadata: serializedMetadata,
+		}}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return string(reservation.SerializedMetadata) == string(serializedMetadata)
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, "40", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataProgress])
+	})
+
+	t.Run("Take over clears metadata", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		serializedMetadata, err := transformers.SerializeReservationMetadata(&datacatalog.Metadata{
+			KeyMap: map[string]string{interfaces.ReservationMetadataProgress: "40"},
+		})
+		assert.NoError(t, err)
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, mock.Anything).Return([]models.Reservation{{
+			ReservationKey:     getReservationKey(),
+			OwnerID:            prevOwner,
+			ExpiresAt:          now.Add(-time.Second),
+			SerializedMetadata: serializedMetadata,
+		}}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.OwnerID == currentOwner &&
+					reservation.SerializedMetadata != nil && len(reservation.SerializedMetadata) == 0
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &datacatalog.GetOrExtendReservationRequest{
+			ReservationId:     &reservationID,
+			OwnerId:           currentOwner,
+			HeartbeatInterval: heartbeatIntervalPb,
+		})
+		assert.NoError(t, err)
+		assert.NotContains(t, resp.GetReservation().Metadata.KeyMap, interfaces.ReservationMetadataProgress)
+	})
+
+	for _, invalid := range []string{"progress=140", "started_at=yesterday", "fencing_token=3", "no separator"} {
+		t.Run("Invalid "+invalid, func(t *testing.T) {
+			dcRepo := getDatacatalogRepo()
+
+			reservationManager := NewReservationManager(&dcRepo,
+				heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+				func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+			_, err := reservationManager.GetOrExtendReservation(withMetadata(invalid), &req)
+			assert.Error(t, err)
+			dcErr, ok := err.(errors2.DataCatalogError)
+			assert.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, dcErr.Code())
+			dcRepo.MockReservationRepo.AssertNotCalled(t, "GetSlots", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetOrExtendReservation_Slots(t *testing.T) {
+	now := time.Now()
+	req := datacatalog.GetOrExtendReservationRequest{
+		ReservationId:     &reservationID,
+		OwnerId:           currentOwner,
+		HeartbeatInterval: heartbeatIntervalPb,
+	}
+	withCapacity := func(capacity string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(reservationCapacityHeader, capacity))
+	}
+	slotReservation := func(slot int, ownerID string, expiresAt time.Time) models.Reservation {
+		reservationKey := getReservationKey()
+		reservationKey.Slot = slot
+		return models.Reservation{
+			ReservationKey: reservationKey,
+			OwnerID:        ownerID,
+			ExpiresAt:      expiresAt,
+			FencingToken:   prevFencingToken,
+		}
+	}
+
+	t.Run("Acquire free slot", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return(
+			[]models.Reservation{slotReservation(0, prevOwner, now.Add(time.Minute))}, nil)
+		dcRepo.MockReservationRepo.On("Create",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 1 && reservation.OwnerID == currentOwner && reservation.Capacity == 2
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("2"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataSlot])
+	})
+
+	t.Run("Acquire with persisted capacity", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		heldSlot := slotReservation(0, prevOwner, now.Add(time.Minute))
+		heldSlot.Capacity = 2
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return(
+			[]models.Reservation{heldSlot}, nil)
+		dcRepo.MockReservationRepo.On("Create",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 1 && reservation.OwnerID == currentOwner && reservation.Capacity == 2
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		// the owner does not request a capacity
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataSlot])
+	})
+
+	t.Run("Conflicting capacity", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		heldSlot := slotReservation(0, prevOwner, now.Add(time.Minute))
+		heldSlot.Capacity = 2
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return(
+			[]models.Reservation{heldSlot}, nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		_, err := reservationManager.GetOrExtendReservation(withCapacity("3"), &req)
+		assert.Error(t, err)
+		dcErr, ok := err.(errors2.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.FailedPrecondition, dcErr.Code())
+		dcRepo.MockReservationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Capacity of expired slots", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		expiredSlot := slotReservation(0, prevOwner, now.Add(-time.Minute))
+		expiredSlot.Capacity = 2
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return(
+			[]models.Reservation{expiredSlot}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 0 && reservation.OwnerID == currentOwner && reservation.Capacity == 3
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		// the capacity of the expired slots does not apply anymore
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("3"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+	})
+
+	t.Run("Take over expired slot", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return([]models.Reservation{
+			slotReservation(0, prevOwner, now.Add(time.Minute)),
+			slotReservation(1, "otherOwner", now.Add(-time.Minute)),
+		}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 1 && reservation.OwnerID == currentOwner &&
+					reservation.FencingToken == prevFencingToken+1
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("2"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataSlot])
+	})
+
+	t.Run("Extend held slot", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return([]models.Reservation{
+			slotReservation(0, prevOwner, now.Add(-time.Minute)),
+			slotReservation(1, currentOwner, now.Add(time.Minute)),
+		}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 1 && reservation.OwnerID == currentOwner &&
+					reservation.FencingToken == prevFencingToken
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("2"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataSlot])
+	})
+
+	t.Run("Extend held slot with mismatched capacity", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		heldSlot := slotReservation(0, prevOwner, now.Add(time.Minute))
+		heldSlot.Capacity = 2
+		ownSlot := slotReservation(1, currentOwner, now.Add(time.Minute))
+		ownSlot.Capacity = 2
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return(
+			[]models.Reservation{heldSlot, ownSlot}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.Slot == 1 && reservation.OwnerID == currentOwner && reservation.Capacity == 2
+			}),
+			mock.Anything,
+		).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		// the owner keeps the slot it holds with the capacity the reservation is held with
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("3"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataSlot])
+		dcRepo.MockReservationRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("All slots held", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return([]models.Reservation{
+			slotReservation(0, prevOwner, now.Add(time.Hour)),
+			slotReservation(1, "otherOwner", now.Add(time.Minute)),
+		}, nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(withCapacity("2"), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, "otherOwner", resp.GetReservation().OwnerId)
+		dcRepo.MockReservationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		dcRepo.MockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid capacity", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		_, err := reservationManager.GetOrExtendReservation(withCapacity("0"), &req)
+		assert.Error(t, err)
+		dcErr, ok := err.(errors2.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, dcErr.Code())
+	})
+}
+
+func TestGetOrExtendReservation_Queue(t *testing.T) {
+	now := time.Now()
+	req := datacatalog.GetOrExtendReservationRequest{
+		ReservationId:     &reservationID,
+		OwnerId:           currentOwner,
+		HeartbeatInterval: heartbeatIntervalPb,
+	}
+	waiter := func(ownerID string) models.ReservationWaiter {
+		return models.ReservationWaiter{
+			ReservationKey: getReservationKey(),
+			OwnerID:        ownerID,
+			EnqueuedAt:     now.Add(-time.Minute),
+			ExpiresAt:      now.Add(time.Minute),
+		}
+	}
+	enqueued := mock.MatchedBy(func(waiter models.ReservationWaiter) bool {
+		return waiter.OwnerID == currentOwner && waiter.ExpiresAt == now.Add(heartbeatInterval*heartbeatGracePeriodMultiplier)
+	})
+
+	t.Run("Queued while held", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Minute))
+		dcRepo.MockReservationRepo.On("ListWaiters", mock.Anything, getReservationKey(), now).Return(
+			[]models.ReservationWaiter{waiter("firstOwner")}, nil)
+		dcRepo.MockReservationRepo.On("Enqueue", mock.Anything, enqueued).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, true, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, prevOwner, resp.GetReservation().OwnerId)
+		assert.Equal(t, "2", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataQueuePosition])
+	})
+
+	t.Run("Handed to first waiter after expiry", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpReservationRepoGet(&dcRepo, now.Add(-time.Second))
+		dcRepo.MockReservationRepo.On("ListWaiters", mock.Anything, getReservationKey(), now).Return(
+			[]models.ReservationWaiter{waiter("firstOwner"), waiter(currentOwner)}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.OwnerID == "firstOwner" &&
+					reservation.ExpiresAt == now.Add(maxHeartbeatInterval*heartbeatGracePeriodMultiplier) &&
+					reservation.FencingToken == prevFencingToken+1
+			}),
+			mock.Anything,
+		).Return(nil)
+		dcRepo.MockReservationRepo.On("Dequeue", mock.Anything, getReservationKey(), "firstOwner").Return(nil)
+		dcRepo.MockReservationRepo.On("Enqueue", mock.Anything, enqueued).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, true, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, "firstOwner", resp.GetReservation().OwnerId)
+		assert.Equal(t, "1", resp.GetReservation().Metadata.KeyMap[interfaces.ReservationMetadataQueuePosition])
+		dcRepo.MockReservationRepo.AssertCalled(t, "Dequeue", mock.Anything, getReservationKey(), "firstOwner")
+	})
+
+	t.Run("Acquired by first waiter", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpReservationRepoGet(&dcRepo, now.Add(-time.Second))
+		dcRepo.MockReservationRepo.On("ListWaiters", mock.Anything, getReservationKey(), now).Return(
+			[]models.ReservationWaiter{waiter(currentOwner), waiter("secondOwner")}, nil)
+		dcRepo.MockReservationRepo.On("Update",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.OwnerID == currentOwner
+			}),
+			mock.Anything,
+		).Return(nil)
+		dcRepo.MockReservationRepo.On("Dequeue", mock.Anything, getReservationKey(), currentOwner).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, true, mockScope.NewTestScope())
+
+		resp, err := reservationManager.GetOrExtendReservation(context.Background(), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, currentOwner, resp.GetReservation().OwnerId)
+		assert.NotContains(t, resp.GetReservation().Metadata.KeyMap, interfaces.ReservationMetadataQueuePosition)
+		dcRepo.MockReservationRepo.AssertCalled(t, "Dequeue", mock.Anything, getReservationKey(), currentOwner)
+	})
+
+	t.Run("Handed to first waiter on release", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Minute))
+		dcRepo.MockReservationRepo.On("Delete", mock.Anything, getReservationKey(), prevOwner).Return(nil)
+		dcRepo.MockReservationRepo.On("ListWaiters", mock.Anything, getReservationKey(), now).Return(
+			[]models.ReservationWaiter{waiter(currentOwner)}, nil)
+		dcRepo.MockReservationRepo.On("Create",
+			mock.Anything,
+			mock.MatchedBy(func(reservation models.Reservation) bool {
+				return reservation.OwnerID == currentOwner &&
+					reservation.ExpiresAt == now.Add(maxHeartbeatInterval*heartbeatGracePeriodMultiplier)
+			}),
+			mock.Anything,
+		).Return(nil)
+		dcRepo.MockReservationRepo.On("Dequeue", mock.Anything, getReservationKey(), currentOwner).Return(nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, true, mockScope.NewTestScope())
+
+		_, err := reservationManager.ReleaseReservation(context.Background(), &datacatalog.ReleaseReservationRequest{
+			ReservationId: &reservationID,
+			OwnerId:       prevOwner,
+		})
+		assert.NoError(t, err)
+		dcRepo.MockReservationRepo.AssertCalled(t, "Dequeue", mock.Anything, getReservationKey(), currentOwner)
+	})
+}
+
 func TestReleaseReservation(t *testing.T) {
 	dcRepo := getDatacatalogRepo()
 
 	now := time.Now()
+	setUpReservationRepoGet(&dcRepo, now.Add(time.Minute))
 
 	dcRepo.MockReservationRepo.On("Delete",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
@@ -264,9 +739,13 @@ func TestReleaseReservation(t *testing.T) {
 		}),
 	).Return(nil)
 
+	notifier := notifications.NewInProcessNotifier()
+	subscription := notifier.Subscribe(notifications.ReservationKey(&reservationID))
+	defer subscription.Close()
+
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifier, maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.ReleaseReservationRequest{
 		ReservationId: &reservationID,
@@ -276,6 +755,7 @@ func TestReleaseReservation(t *testing.T) {
 	_, err := reservationManager.ReleaseReservation(context.Background(), &req)
 
 	assert.Nil(t, err)
+	assert.Len(t, subscription.C, 1)
 }
 
 func TestReleaseReservation_Failure(t *testing.T) {
@@ -283,6 +763,7 @@ func TestReleaseReservation_Failure(t *testing.T) {
 
 	now := time.Now()
 	reservationErr := fmt.Errorf("unknown error")
+	setUpReservationRepoGet(&dcRepo, now.Add(time.Minute))
 
 	dcRepo.MockReservationRepo.On("Delete",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
@@ -300,7 +781,7 @@ func TestReleaseReservation_Failure(t *testing.T) {
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.ReleaseReservationRequest{
 		ReservationId: &reservationID,
@@ -322,6 +803,7 @@ func TestReleaseReservation_GracefulFailure(t *testing.T) {
 			TagName:   tagName,
 		})
 
+	dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, getReservationKey()).Return([]models.Reservation{}, nil)
 	dcRepo.MockReservationRepo.On("Delete",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
 		mock.MatchedBy(func(reservationKey models.ReservationKey) bool {
@@ -338,7 +820,7 @@ func TestReleaseReservation_GracefulFailure(t *testing.T) {
 
 	reservationManager := NewReservationManager(&dcRepo,
 		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
-		func() time.Time { return now }, mockScope.NewTestScope())
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
 
 	req := datacatalog.ReleaseReservationRequest{
 		ReservationId: &reservationID,
@@ -350,29 +832,364 @@ func TestReleaseReservation_GracefulFailure(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestListReservations(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+	now := time.Now()
+
+	dcRepo.MockReservationRepo.On("List",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		mock.MatchedBy(func(listInput models.ListModelsInput) bool {
+			if len(listInput.ModelFilters) != 1 || len(listInput.ModelFilters[0].ValueFilters) != 2 {
+				return false
+			}
+			ownerExpr, _ := listInput.ModelFilters[0].ValueFilters[0].GetDBQueryExpression("reservations")
+			expiresExpr, _ := listInput.ModelFilters[0].ValueFilters[1].GetDBQueryExpression("reservations")
+			return ownerExpr.Args == currentOwner &&
+				expiresExpr.Query == "reservations.expires_at >= ?" && expiresExpr.Args == now &&
+				listInput.Limit == 10
+		}),
+	).Return([]models.Reservation{
+		{
+			ReservationKey: getReservationKey(),
+			OwnerID:        currentOwner,
+			ExpiresAt:      now.Add(time.Minute),
+		},
+	}, nil)
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	resp, err := reservationManager.ListReservations(context.Background(), &interfaces.ListReservationsRequest{
+		OwnerID:    currentOwner,
+		State:      interfaces.ReservationStateActive,
+		Pagination: &datacatalog.PaginationOptions{Limit: 10},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Reservations, 1)
+	assert.Equal(t, currentOwner, resp.Reservations[0].OwnerId)
+	assert.Equal(t, tagName, resp.Reservations[0].ReservationId.TagName)
+	assert.Nil(t, resp.Reservations[0].HeartbeatInterval)
+	assert.Equal(t, "1", resp.NextToken)
+}
+
+func TestListReservations_InvalidState(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		time.Now, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	_, err := reservationManager.ListReservations(context.Background(), &interfaces.ListReservationsRequest{
+		State: "stale",
+	})
+	assert.Error(t, err)
+	dcErr, ok := err.(errors2.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, dcErr.Code())
+}
+
+func TestForceReleaseReservation(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+	now := time.Now()
+
+	setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+	dcRepo.MockReservationRepo.On("Delete",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		getReservationKey(),
+		prevOwner,
+	).Return(nil)
+	dcRepo.MockReservationRepo.On("CreateForceRelease",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		models.ReservationForceRelease{
+			ReservationKey: getReservationKey(),
+			ReleasedAt:     now,
+			ReleasedBy:     "admin",
+			Reason:         "owner pod is gone",
+			OwnerID:        prevOwner,
+			ExpiresAt:      now.Add(time.Hour),
+		},
+	).Return(nil)
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	ctx := auth.WithIdentity(auth.WithAdmin(context.Background(), true), "admin")
+	resp, err := reservationManager.ForceReleaseReservation(ctx, &interfaces.ForceReleaseReservationRequest{
+		ReservationID: &reservationID,
+		Reason:        "owner pod is gone",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, prevOwner, resp.ReleasedOwnerID)
+	dcRepo.MockReservationRepo.AssertCalled(t, "CreateForceRelease", mock.Anything, mock.Anything)
+}
+
+func TestForceReleaseReservation_Unauthenticated(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		time.Now, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	// the owner of the request is not taken as the identity of the caller
+	ctx := metadata.NewIncomingContext(auth.WithAdmin(context.Background(), true),
+		metadata.Pairs(forceReleaseHeader, "true"))
+	_, err := reservationManager.ReleaseReservation(ctx, &datacatalog.ReleaseReservationRequest{
+		ReservationId: &reservationID,
+		OwnerId:       currentOwner,
+	})
+	assert.Error(t, err)
+	dcErr, ok := err.(errors2.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, dcErr.Code())
+	dcRepo.MockReservationRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestForceReleaseReservation_NotAdmin(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		time.Now, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	ctx := auth.WithIdentity(context.Background(), "user")
+	_, err := reservationManager.ForceReleaseReservation(ctx, &interfaces.ForceReleaseReservationRequest{
+		ReservationID: &reservationID,
+	})
+	assert.Error(t, err)
+	dcErr, ok := err.(errors2.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, dcErr.Code())
+	dcRepo.MockReservationRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseReservation_ForceReleaseHeader(t *testing.T) {
+	dcRepo := getDatacatalogRepo()
+	now := time.Now()
+
+	setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+	dcRepo.MockReservationRepo.On("Delete",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		getReservationKey(),
+		prevOwner,
+	).Return(nil)
+
+	reservationManager := NewReservationManager(&dcRepo,
+		heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+		func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+	dcRepo.MockReservationRepo.On("CreateForceRelease", mock.Anything, mock.MatchedBy(
+		func(forceRelease models.ReservationForceRelease) bool { return forceRelease.ReleasedBy == "admin" })).Return(nil)
+
+	ctx := metadata.NewIncomingContext(auth.WithIdentity(auth.WithAdmin(context.Background(), true), "admin"),
+		metadata.Pairs(forceReleaseHeader, "true"))
+	_, err := reservationManager.ReleaseReservation(ctx, &datacatalog.ReleaseReservationRequest{
+		ReservationId: &reservationID,
+		OwnerId:       currentOwner,
+	})
+	assert.NoError(t, err)
+	dcRepo.MockReservationRepo.AssertCalled(t, "Delete", mock.Anything, getReservationKey(), prevOwner)
+}
+
+func TestWaitForArtifact(t *testing.T) {
+	now := time.Now()
+	notFound := errors2.NewDataCatalogErrorf(codes.NotFound, "entry not found")
+	request := &interfaces.WaitForArtifactRequest{
+		ReservationID: &reservationID,
+	}
+	availableTag := models.Tag{
+		Artifact: models.Artifact{
+			ArtifactKey: models.ArtifactKey{
+				DatasetProject: project,
+				DatasetName:    name,
+				DatasetDomain:  domain,
+				DatasetVersion: version,
+				ArtifactID:     "artifact",
+			},
+			SerializedMetadata: []byte{},
+		},
+	}
+
+	t.Run("Available", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(availableTag, nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultAvailable, resp.Result)
+		assert.Equal(t, "artifact", resp.Artifact.Id)
+		dcRepo.MockReservationRepo.AssertNotCalled(t, "GetSlots", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Released", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpTagRepoGetNotFound(&dcRepo)
+		dcRepo.MockReservationRepo.On("GetSlots", mock.Anything, mock.Anything).Return([]models.Reservation{}, nil)
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultReleased, resp.Result)
+		assert.Nil(t, resp.Reservation)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpTagRepoGetNotFound(&dcRepo)
+		setUpReservationRepoGet(&dcRepo, now.Add(-time.Second))
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultExpired, resp.Result)
+		assert.Equal(t, prevOwner, resp.Reservation.OwnerId)
+	})
+
+	t.Run("Timed out", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpTagRepoGetNotFound(&dcRepo)
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), &interfaces.WaitForArtifactRequest{
+			ReservationID: &reservationID,
+			Timeout:       time.Millisecond * 10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultTimedOut, resp.Result)
+		assert.Equal(t, prevOwner, resp.Reservation.OwnerId)
+		assert.Nil(t, resp.Reservation.HeartbeatInterval)
+	})
+
+	t.Run("Deadline exceeded", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpTagRepoGetNotFound(&dcRepo)
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		_, err := reservationManager.WaitForArtifact(ctx, request)
+		assert.Error(t, err)
+		dcErr, ok := err.(errors2.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.DeadlineExceeded, dcErr.Code())
+	})
+
+	t.Run("Acquired", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		setUpTagRepoGetNotFound(&dcRepo)
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, true, mockScope.NewTestScope())
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), &interfaces.WaitForArtifactRequest{
+			ReservationID: &reservationID,
+			OwnerID:       prevOwner,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultAcquired, resp.Result)
+		assert.Equal(t, prevOwner, resp.Reservation.OwnerId)
+	})
+
+	t.Run("Notified when tagged", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(models.Tag{}, notFound).Once()
+		dcRepo.MockTagRepo.On("Get", mock.Anything, mock.Anything).Return(availableTag, nil)
+		setUpReservationRepoGet(&dcRepo, now.Add(time.Hour))
+
+		notifier := notifications.NewInProcessNotifier()
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifier, maxArtifactWait, false, mockScope.NewTestScope())
+
+		// keep notifying as the waiter may not have subscribed yet
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(time.Millisecond):
+					notifier.Publish(context.Background(), notifications.ReservationKey(&reservationID))
+				}
+			}
+		}()
+
+		resp, err := reservationManager.WaitForArtifact(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, interfaces.WaitForArtifactResultAvailable, resp.Result)
+	})
+
+	t.Run("Invalid timeout", func(t *testing.T) {
+		dcRepo := getDatacatalogRepo()
+
+		reservationManager := NewReservationManager(&dcRepo,
+			heartbeatGracePeriodMultiplier, maxHeartbeatInterval,
+			func() time.Time { return now }, notifications.NewInProcessNotifier(), maxArtifactWait, false, mockScope.NewTestScope())
+
+		_, err := reservationManager.WaitForArtifact(context.Background(), &interfaces.WaitForArtifactRequest{
+			ReservationID: &reservationID,
+			Timeout:       -time.Second,
+		})
+		assert.Error(t, err)
+		dcErr, ok := err.(errors2.DataCatalogError)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, dcErr.Code())
+	})
+}
+
 func getDatacatalogRepo() mocks.DataCatalogRepo {
+	reservationRepo := &mocks.ReservationRepo{}
+	// the sequence hands out the token following the one of the existing reservation
+	reservationRepo.OnNextFencingTokenMatch(mock.Anything).Return(prevFencingToken+1, nil)
 	return mocks.DataCatalogRepo{
-		MockReservationRepo: &mocks.ReservationRepo{},
+		MockReservationRepo: reservationRepo,
 		MockTagRepo:         &mocks.TagRepo{},
 	}
 }
 
 func setUpReservationRepoGet(dcRepo *mocks.DataCatalogRepo, prevExpiresAt time.Time) {
+	reservationKeyMatcher := mock.MatchedBy(func(key models.ReservationKey) bool {
+		return key.DatasetProject == datasetID.Project &&
+			key.DatasetDomain == datasetID.Domain &&
+			key.DatasetVersion == datasetID.Version &&
+			key.DatasetName == datasetID.Name &&
+			key.TagName == tagName
+	})
+	prevReservation := models.Reservation{
+		ReservationKey: getReservationKey(),
+		OwnerID:        prevOwner,
+		ExpiresAt:      prevExpiresAt,
+		FencingToken:   prevFencingToken,
+	}
+
 	dcRepo.MockReservationRepo.On("Get",
 		mock.MatchedBy(func(ctx context.Context) bool { return true }),
-		mock.MatchedBy(func(key models.ReservationKey) bool {
-			return key.DatasetProject == datasetID.Project &&
-				key.DatasetDomain == datasetID.Domain &&
-				key.DatasetVersion == datasetID.Version &&
-				key.DatasetName == datasetID.Name &&
-				key.TagName == tagName
-		})).Return(
-		models.Reservation{
-			ReservationKey: getReservationKey(),
-			OwnerID:        prevOwner,
-			ExpiresAt:      prevExpiresAt,
-		}, nil,
-	)
+		reservationKeyMatcher).Return(prevReservation, nil)
+	dcRepo.MockReservationRepo.On("GetSlots",
+		mock.MatchedBy(func(ctx context.Context) bool { return true }),
+		reservationKeyMatcher).Return([]models.Reservation{prevReservation}, nil)
 }
 
 func setUpTagRepoGetNotFound(dcRepo *mocks.DataCatalogRepo) {
@@ -0,0 +1,117 @@
+package impl
+
+import (
+	"context"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+)
+
+type reservationReaperMetrics struct {
+	scope              promutils.Scope
+	reapResponseTime   labeled.StopWatch
+	reservationsReaped labeled.Counter
+	waitersReaped      labeled.Counter
+	reapFailureCounter labeled.Counter
+}
+
+// ReservationReaper periodically deletes reservations which expired longer than the grace period ago. Expired
+// reservations are only replaced when their key is acquired again, so without the reaper they would pile up. The same
+// applies to the expired waiters of reservation queues.
+type ReservationReaper struct {
+	repo          repositories.RepositoryInterface
+	interval      time.Duration
+	gracePeriod   time.Duration
+	batchSize     int
+	now           NowFunc
+	systemMetrics reservationReaperMetrics
+}
+
+// Run reaps expired reservations every interval until the context is done
+func (r *ReservationReaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Reap(ctx); err != nil {
+				logger.Errorf(ctx, "Failed to reap expired reservations, err: %v", err)
+			}
+		}
+	}
+}
+
+// Reap deletes the reservations and queued waiters which expired longer than the grace period ago in batches,
+// returning the number of deleted reservations.
+func (r *ReservationReaper) Reap(ctx context.Context) (int64, error) {
+	timer := r.systemMetrics.reapResponseTime.Start(ctx)
+	defer timer.Stop()
+
+	expiredBefore := r.now().Add(-r.gracePeriod)
+	reaped, err := r.reapBatches(ctx, expiredBefore, r.repo.ReservationRepo().DeleteExpired, r.systemMetrics.reservationsReaped)
+	if err != nil {
+		return reaped, err
+	}
+
+	waitersReaped, err := r.reapBatches(ctx, expiredBefore, r.repo.ReservationRepo().DeleteExpiredWaiters, r.systemMetrics.waitersReaped)
+	if err != nil {
+		return reaped, err
+	}
+
+	logger.Debugf(ctx, "Reaped %d reservations and %d waiters which expired before %v", reaped, waitersReaped, expiredBefore)
+	return reaped, nil
+}
+
+func (r *ReservationReaper) reapBatches(ctx context.Context, expiredBefore time.Time,
+	deleteExpired func(context.Context, time.Time, int) (int64, error), reapedCounter labeled.Counter) (int64, error) {
+	var reaped int64
+	for ctx.Err() == nil {
+		deleted, err := deleteExpired(ctx, expiredBefore, r.batchSize)
+		if err != nil {
+			r.systemMetrics.reapFailureCounter.Inc(ctx)
+			return reaped, err
+		}
+
+		reaped += deleted
+		reapedCounter.Add(ctx, float64(deleted))
+
+		// a partial batch means there is nothing expired left
+		if deleted < int64(r.batchSize) {
+			break
+		}
+	}
+
+	return reaped, nil
+}
+
+func NewReservationReaper(
+	repo repositories.RepositoryInterface,
+	interval time.Duration,
+	gracePeriod time.Duration,
+	batchSize int,
+	nowFunc NowFunc, // Easier to mock time.Time for testing
+	reaperScope promutils.Scope,
+) *ReservationReaper {
+	systemMetrics := reservationReaperMetrics{
+		scope:              reaperScope,
+		reapResponseTime:   labeled.NewStopWatch("reap_duration", "The duration of reaping expired reservations.", time.Millisecond, reaperScope, labeled.EmitUnlabeledMetric),
+		reservationsReaped: labeled.NewCounter("reservations_reaped", "The number of expired reservations deleted", reaperScope, labeled.EmitUnlabeledMetric),
+		waitersReaped:      labeled.NewCounter("waiters_reaped", "The number of expired waiters deleted from reservation queues", reaperScope, labeled.EmitUnlabeledMetric),
+		reapFailureCounter: labeled.NewCounter("reap_failure_count", "The number of times we failed to reap expired reservations", reaperScope, labeled.EmitUnlabeledMetric),
+	}
+
+	return &ReservationReaper{
+		repo:          repo,
+		interval:      interval,
+		gracePeriod:   gracePeriod,
+		batchSize:     batchSize,
+		now:           nowFunc,
+		systemMetrics: systemMetrics,
+	}
+}
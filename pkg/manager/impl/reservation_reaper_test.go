@@ -0,0 +1,60 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories/mocks"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReapReservations(t *testing.T) {
+	now := time.Now()
+	gracePeriod := time.Hour
+	batchSize := 10
+
+	t.Run("Reaps in batches", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{MockReservationRepo: &mocks.ReservationRepo{}}
+		dcRepo.MockReservationRepo.On("DeleteExpired", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(10), nil).Twice()
+		dcRepo.MockReservationRepo.On("DeleteExpired", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(3), nil).Once()
+		dcRepo.MockReservationRepo.On("DeleteExpiredWaiters", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(10), nil).Once()
+		dcRepo.MockReservationRepo.On("DeleteExpiredWaiters", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(1), nil).Once()
+
+		reaper := NewReservationReaper(dcRepo, time.Minute, gracePeriod, batchSize,
+			func() time.Time { return now }, mockScope.NewTestScope())
+		reaped, err := reaper.Reap(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(23), reaped)
+		dcRepo.MockReservationRepo.AssertNumberOfCalls(t, "DeleteExpired", 3)
+		dcRepo.MockReservationRepo.AssertNumberOfCalls(t, "DeleteExpiredWaiters", 2)
+	})
+
+	t.Run("Nothing to reap", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{MockReservationRepo: &mocks.ReservationRepo{}}
+		dcRepo.MockReservationRepo.On("DeleteExpired", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(0), nil)
+		dcRepo.MockReservationRepo.On("DeleteExpiredWaiters", mock.Anything, now.Add(-gracePeriod), batchSize).Return(int64(0), nil)
+
+		reaper := NewReservationReaper(dcRepo, time.Minute, gracePeriod, batchSize,
+			func() time.Time { return now }, mockScope.NewTestScope())
+		reaped, err := reaper.Reap(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), reaped)
+		dcRepo.MockReservationRepo.AssertNumberOfCalls(t, "DeleteExpired", 1)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		dcRepo := &mocks.DataCatalogRepo{MockReservationRepo: &mocks.ReservationRepo{}}
+		dcRepo.MockReservationRepo.On("DeleteExpired", mock.Anything, mock.Anything, mock.Anything).Return(int64(10), nil).Once()
+		dcRepo.MockReservationRepo.On("DeleteExpired", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("db unavailable"))
+
+		reaper := NewReservationReaper(dcRepo, time.Minute, gracePeriod, batchSize,
+			func() time.Time { return now }, mockScope.NewTestScope())
+		reaped, err := reaper.Reap(context.Background())
+		assert.Error(t, err)
+		assert.Equal(t, int64(10), reaped)
+	})
+}
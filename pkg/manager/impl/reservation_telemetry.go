@@ -0,0 +1,132 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+)
+
+// Waiting owners which have not requested their reservation for this long are no longer tracked, e.g. because they
+// found the artifact after the reservation was released
+const reservationWaiterTTL = time.Hour
+
+// Limits the memory used to track waiting owners, further keys are not tracked until waiters are acquired or expire
+const maxTrackedReservationKeys = 10000
+
+// reservationTelemetry records how long owners wait to acquire reservations, how many owners contend for them and how
+// long they are held, labeled with the project and domain of the request like the other metrics. They are not labeled
+// with the owner, whose IDs are unique per execution, the hold duration per owner is logged instead. Waiting owners
+// are tracked in memory, so with multiple replicas each replica only accounts for the requests it serves.
+type reservationTelemetry struct {
+	timeToAcquire labeled.StopWatch
+	holdDuration  labeled.StopWatch
+	contention    labeled.Summary
+	takeovers     labeled.Counter
+
+	mutex sync.Mutex
+	// The time of the first request of each owner waiting for a reservation held by another owner
+	waiters map[models.ReservationKey]map[string]time.Time
+}
+
+// Record that the owner requested the reservation while it was held by another owner
+func (t *reservationTelemetry) observeWaiting(key models.ReservationKey, ownerID string, now time.Time) {
+	// owners contend for all slots of the key
+	key.Slot = 0
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	keyWaiters, ok := t.waiters[key]
+	if !ok {
+		if len(t.waiters) >= maxTrackedReservationKeys {
+			t.pruneWaiters(now)
+			if len(t.waiters) >= maxTrackedReservationKeys {
+				return
+			}
+		}
+		keyWaiters = make(map[string]time.Time)
+		t.waiters[key] = keyWaiters
+	}
+
+	if _, ok := keyWaiters[ownerID]; !ok {
+		keyWaiters[ownerID] = now
+	}
+}
+
+// Record that the owner acquired the reservation, either because it did not exist or because it expired
+func (t *reservationTelemetry) observeAcquired(ctx context.Context, key models.ReservationKey, ownerID string, now time.Time) {
+	key.Slot = 0
+	t.mutex.Lock()
+	keyWaiters := t.waiters[key]
+	waitingSince, waited := keyWaiters[ownerID]
+	// all owners which requested the reservation contended for it, including the acquiring one
+	requesters := len(keyWaiters)
+	if !waited {
+		requesters++
+	}
+	delete(keyWaiters, ownerID)
+	if len(keyWaiters) == 0 {
+		delete(t.waiters, key)
+	}
+	t.mutex.Unlock()
+
+	if !waited {
+		waitingSince = now
+	}
+
+	t.timeToAcquire.Observe(ctx, waitingSince, now)
+	t.contention.Observe(ctx, float64(requesters))
+}
+
+// Record that the reservation held by its owner ended at the given time, because it was released or taken over
+func (t *reservationTelemetry) observeHeld(ctx context.Context, reservation models.Reservation, endedAt time.Time) {
+	// reservations acquired before the acquisition time was recorded can't be accounted for
+	if reservation.AcquiredAt == nil {
+		return
+	}
+
+	logger.Debugf(ctx, "Reservation %+v was held by %s for %v", reservation.ReservationKey, reservation.OwnerID,
+		endedAt.Sub(*reservation.AcquiredAt))
+	t.holdDuration.Observe(ctx, *reservation.AcquiredAt, endedAt)
+}
+
+// Record that the owner took over the expired reservation of another owner
+func (t *reservationTelemetry) observeTakeover(ctx context.Context, expiredReservation models.Reservation) {
+	t.takeovers.Inc(ctx)
+	t.observeHeld(ctx, expiredReservation, expiredReservation.ExpiresAt)
+}
+
+// Stop tracking waiting owners which have not been acquired within the TTL. Must be called with the mutex held.
+func (t *reservationTelemetry) pruneWaiters(now time.Time) {
+	for key, keyWaiters := range t.waiters {
+		for ownerID, waitingSince := range keyWaiters {
+			if now.Sub(waitingSince) > reservationWaiterTTL {
+				delete(keyWaiters, ownerID)
+			}
+		}
+		if len(keyWaiters) == 0 {
+			delete(t.waiters, key)
+		}
+	}
+}
+
+func newReservationTelemetry(scope promutils.Scope) *reservationTelemetry {
+	return &reservationTelemetry{
+		timeToAcquire: labeled.NewStopWatch("time_to_acquire",
+			"How long owners waited for a reservation from their first request until acquiring it",
+			time.Second, scope, labeled.EmitUnlabeledMetric),
+		holdDuration: labeled.NewStopWatch("hold_duration",
+			"How long owners held a reservation until releasing it or until it expired and was taken over",
+			time.Second, scope, labeled.EmitUnlabeledMetric),
+		contention: labeled.NewSummary("contention",
+			"The number of distinct owners which requested a reservation until it was acquired",
+			scope, labeled.EmitUnlabeledMetric),
+		takeovers: labeled.NewCounter("reservation_taken_over",
+			"Number of times an expired reservation was taken over by another owner", scope),
+		waiters: make(map[models.ReservationKey]map[string]time.Time),
+	}
+}
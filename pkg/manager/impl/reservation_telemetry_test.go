@@ -0,0 +1,84 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	mockScope "github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Get the unlabeled summary of a stopwatch or summary
+func getSummary(t *testing.T, summary prometheus.Observer) *dto.Summary {
+	metric := &dto.Metric{}
+	assert.NoError(t, summary.(prometheus.Metric).Write(metric))
+	return metric.GetSummary()
+}
+
+func TestReservationTelemetry(t *testing.T) {
+	ctx := contextutils.WithProjectDomain(context.Background(), project, domain)
+	key := getReservationKey()
+	now := time.Now()
+
+	t.Run("Uncontended", func(t *testing.T) {
+		telemetry := newReservationTelemetry(mockScope.NewTestScope())
+
+		telemetry.observeAcquired(ctx, key, currentOwner, now)
+
+		timeToAcquire := getSummary(t, telemetry.timeToAcquire.StopWatch.Observer)
+		assert.Equal(t, uint64(1), timeToAcquire.GetSampleCount())
+		assert.Equal(t, float64(0), timeToAcquire.GetSampleSum())
+		assert.Equal(t, float64(1), getSummary(t, telemetry.contention.Summary).GetSampleSum())
+		assert.Empty(t, telemetry.waiters)
+	})
+
+	t.Run("Contended", func(t *testing.T) {
+		telemetry := newReservationTelemetry(mockScope.NewTestScope())
+
+		telemetry.observeWaiting(key, currentOwner, now)
+		// repeated requests of a waiting owner count once
+		telemetry.observeWaiting(key, currentOwner, now.Add(time.Second))
+		telemetry.observeWaiting(key, "otherOwner", now.Add(time.Second))
+		telemetry.observeAcquired(ctx, key, currentOwner, now.Add(time.Minute))
+
+		assert.Equal(t, time.Minute.Seconds(), getSummary(t, telemetry.timeToAcquire.StopWatch.Observer).GetSampleSum())
+		assert.Equal(t, float64(2), getSummary(t, telemetry.contention.Summary).GetSampleSum())
+		assert.Len(t, telemetry.waiters[key], 1)
+	})
+
+	t.Run("Takeover", func(t *testing.T) {
+		telemetry := newReservationTelemetry(mockScope.NewTestScope())
+		acquiredAt := now.Add(-time.Hour)
+
+		telemetry.observeTakeover(ctx, models.Reservation{
+			ReservationKey: key,
+			OwnerID:        prevOwner,
+			ExpiresAt:      now.Add(-time.Minute),
+			AcquiredAt:     &acquiredAt,
+		})
+
+		assert.Equal(t, (time.Hour - time.Minute).Seconds(), getSummary(t, telemetry.holdDuration.StopWatch.Observer).GetSampleSum())
+	})
+
+	t.Run("Unknown acquisition time", func(t *testing.T) {
+		telemetry := newReservationTelemetry(mockScope.NewTestScope())
+
+		telemetry.observeHeld(ctx, models.Reservation{ReservationKey: key, OwnerID: prevOwner}, now)
+
+		assert.Equal(t, uint64(0), getSummary(t, telemetry.holdDuration.StopWatch.Observer).GetSampleCount())
+	})
+
+	t.Run("Prune stale waiters", func(t *testing.T) {
+		telemetry := newReservationTelemetry(mockScope.NewTestScope())
+
+		telemetry.observeWaiting(key, currentOwner, now)
+		telemetry.pruneWaiters(now.Add(reservationWaiterTTL + time.Second))
+
+		assert.Empty(t, telemetry.waiters)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
 	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	"github.com/flyteorg/datacatalog/pkg/repositories"
 
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
@@ -32,6 +33,7 @@ type tagMetrics struct {
 type tagManager struct {
 	repo          repositories.RepositoryInterface
 	store         *storage.DataStore
+	notifier      notifications.Notifier
 	systemMetrics tagMetrics
 }
 
@@ -64,13 +66,24 @@ func (m *tagManager) AddTag(ctx context.Context, request *datacatalog.AddTagRequ
 	}
 
 	tagKey := transformers.ToTagKey(datasetID, request.Tag.Name)
-	err = m.repo.TagRepo().Create(ctx, models.Tag{
-		TagKey:      tagKey,
-		ArtifactID:  request.Tag.ArtifactId,
-		DatasetUUID: dataset.UUID,
+	fenced := false
+	err = m.repo.Transaction(ctx, func(ctx context.Context) error {
+		// the reservation stays locked until the tag is committed, so that it can't be taken over in between
+		if err := checkFencingToken(ctx, m.repo.ReservationRepo(), datasetID, time.Now()); err != nil {
+			fenced = true
+			return err
+		}
+		return m.repo.TagRepo().Create(ctx, models.Tag{
+			TagKey:      tagKey,
+			ArtifactID:  request.Tag.ArtifactId,
+			DatasetUUID: dataset.UUID,
+		})
 	})
 	if err != nil {
-		if errors.IsAlreadyExistsError(err) {
+		if fenced {
+			logger.Warnf(ctx, "Rejected tag %+v of stale reservation owner, err: %v", request.Tag, err)
+			m.systemMetrics.addTagFailureCounter.Inc(ctx)
+		} else if errors.IsAlreadyExistsError(err) {
 			logger.Warnf(ctx, "Tag already exists key: %+v, err %v", request, err)
 			m.systemMetrics.alreadyExistsCounter.Inc(ctx)
 		} else {
@@ -81,11 +94,16 @@ func (m *tagManager) AddTag(ctx context.Context, request *datacatalog.AddTagRequ
 		return nil, err
 	}
 
+	// wake up the waiters for the tagged artifact
+	m.notifier.Publish(ctx, notifications.ReservationKey(&datacatalog.ReservationID{
+		DatasetId: datasetID,
+		TagName:   request.Tag.Name,
+	}))
 	m.systemMetrics.addTagSuccessCounter.Inc(ctx)
 	return &datacatalog.AddTagResponse{}, nil
 }
 
-func NewTagManager(repo repositories.RepositoryInterface, store *storage.DataStore, tagScope promutils.Scope) interfaces.TagManager {
+func NewTagManager(repo repositories.RepositoryInterface, store *storage.DataStore, notifier notifications.Notifier, tagScope promutils.Scope) interfaces.TagManager {
 	systemMetrics := tagMetrics{
 		scope:                  tagScope,
 		createResponseTime:     labeled.NewStopWatch("create_duration", "The duration of the add tag calls.", time.Millisecond, tagScope, labeled.EmitUnlabeledMetric),
@@ -98,6 +116,7 @@ func NewTagManager(repo repositories.RepositoryInterface, store *storage.DataSto
 	return &tagManager{
 		repo:          repo,
 		store:         store,
+		notifier:      notifier,
 		systemMetrics: systemMetrics,
 	}
 }
@@ -3,7 +3,9 @@ package impl
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/flyteorg/datacatalog/pkg/notifications"
 	"github.com/flyteorg/datacatalog/pkg/repositories/mocks"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
@@ -14,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -37,9 +40,10 @@ func getTestTag() models.Tag {
 
 func TestAddTag(t *testing.T) {
 	dcRepo := &mocks.DataCatalogRepo{
-		MockDatasetRepo:  &mocks.DatasetRepo{},
-		MockArtifactRepo: &mocks.ArtifactRepo{},
-		MockTagRepo:      &mocks.TagRepo{},
+		MockDatasetRepo:     &mocks.DatasetRepo{},
+		MockArtifactRepo:    &mocks.ArtifactRepo{},
+		MockTagRepo:         &mocks.TagRepo{},
+		MockReservationRepo: &mocks.ReservationRepo{},
 	}
 
 	expectedTag := getTestTag()
@@ -92,7 +96,19 @@ func TestAddTag(t *testing.T) {
 					datasetKey.Version == expectedTag.DatasetVersion
 			})).Return(dataset, nil)
 
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		notifier := notifications.NewInProcessNotifier()
+		subscription := notifier.Subscribe(notifications.ReservationKey(&datacatalog.ReservationID{
+			DatasetId: &datacatalog.DatasetID{
+				Project: expectedTag.DatasetProject,
+				Domain:  expectedTag.DatasetDomain,
+				Version: expectedTag.DatasetVersion,
+				Name:    expectedTag.DatasetName,
+			},
+			TagName: expectedTag.TagName,
+		}))
+		defer subscription.Close()
+
+		tagManager := NewTagManager(dcRepo, nil, notifier, mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), &datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:       expectedTag.TagName,
@@ -108,10 +124,12 @@ func TestAddTag(t *testing.T) {
 		})
 
 		assert.NoError(t, err)
+		// waiters for the tagged artifact are notified
+		assert.Len(t, subscription.C, 1)
 	})
 
 	t.Run("NoDataset", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, nil, notifications.NewInProcessNotifier(), mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), &datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:       "noDataset",
@@ -125,7 +143,7 @@ func TestAddTag(t *testing.T) {
 	})
 
 	t.Run("NoTagName", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, nil, notifications.NewInProcessNotifier(), mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), &datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				ArtifactId: "noArtifact",
@@ -139,7 +157,7 @@ func TestAddTag(t *testing.T) {
 	})
 
 	t.Run("NoArtifactID", func(t *testing.T) {
-		tagManager := NewTagManager(dcRepo, nil, mockScope.NewTestScope())
+		tagManager := NewTagManager(dcRepo, nil, notifications.NewInProcessNotifier(), mockScope.NewTestScope())
 		_, err := tagManager.AddTag(context.Background(), &datacatalog.AddTagRequest{
 			Tag: &datacatalog.Tag{
 				Name:    "noArtifact",
@@ -151,4 +169,66 @@ func TestAddTag(t *testing.T) {
 		responseCode := status.Code(err)
 		assert.Equal(t, codes.InvalidArgument, responseCode)
 	})
+
+	t.Run("FencingToken", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		dcRepo.MockReservationRepo.On("GetSlotsForUpdate", mock.MatchedBy(func(ctx context.Context) bool { return true }),
+			mock.MatchedBy(func(reservationKey models.ReservationKey) bool {
+				return reservationKey.DatasetProject == expectedTag.DatasetProject &&
+					reservationKey.DatasetDomain == expectedTag.DatasetDomain &&
+					reservationKey.DatasetName == expectedTag.DatasetName &&
+					reservationKey.DatasetVersion == expectedTag.DatasetVersion &&
+					reservationKey.TagName == expectedTag.TagName
+			})).Return([]models.Reservation{
+			{OwnerID: "newOwner", FencingToken: 3, ExpiresAt: expiresAt},
+			{ReservationKey: models.ReservationKey{Slot: 1}, OwnerID: "otherOwner", FencingToken: 5, ExpiresAt: expiresAt},
+			{ReservationKey: models.ReservationKey{Slot: 2}, OwnerID: "expiredOwner", FencingToken: 4, ExpiresAt: time.Now().Add(-time.Minute)},
+		}, nil)
+
+		request := &datacatalog.AddTagRequest{
+			Tag: &datacatalog.Tag{
+				Name:       expectedTag.TagName,
+				ArtifactId: expectedTag.ArtifactID,
+				Dataset: &datacatalog.DatasetID{
+					Project: expectedTag.DatasetProject,
+					Domain:  expectedTag.DatasetDomain,
+					Version: expectedTag.DatasetVersion,
+					Name:    expectedTag.DatasetName,
+					UUID:    expectedTag.DatasetUUID,
+				},
+			},
+		}
+		tagManager := NewTagManager(dcRepo, nil, notifications.NewInProcessNotifier(), mockScope.NewTestScope())
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag=3"))
+		_, err := tagManager.AddTag(ctx, request)
+		assert.NoError(t, err)
+
+		// the owners of other slots are not fenced by newer tokens of the other slots
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag=5"))
+		_, err = tagManager.AddTag(ctx, request)
+		assert.NoError(t, err)
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag=2"))
+		_, err = tagManager.AddTag(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// the reservation of the token expired
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag=4"))
+		_, err = tagManager.AddTag(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		// the reservation of the token has been released and no newer token has been handed out
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag=6"))
+		_, err = tagManager.AddTag(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(fencingTokenHeader, "test-tag"))
+		_, err = tagManager.AddTag(ctx, request)
+		assert.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 )
 
@@ -93,6 +94,33 @@ func ValidateListArtifactRequest(request *datacatalog.ListArtifactsRequest) erro
 	return nil
 }
 
+func ValidateSearchArtifactsRequest(request *interfaces.SearchArtifactsRequest) error {
+	if err := ValidateEmptyStringField(request.Project, datasetProject); err != nil {
+		return err
+	}
+
+	if err := ValidateEmptyStringField(request.Domain, datasetDomain); err != nil {
+		return err
+	}
+
+	if err := ValidateArtifactFilterTypes(request.Filter.GetFilters()); err != nil {
+		return err
+	}
+
+	if err := ValidateTimeRangeFilters(common.Artifact, request.TimeRangeFilters); err != nil {
+		return err
+	}
+
+	if request.Pagination != nil {
+		err := ValidatePagination(request.Pagination)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Artifacts cannot be filtered across Datasets
 func ValidateArtifactFilterTypes(filters []*datacatalog.SinglePropertyFilter) error {
 	for _, filter := range filters {
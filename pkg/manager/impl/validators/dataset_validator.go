@@ -1,8 +1,13 @@
 package validators
 
 import (
+	"fmt"
+
 	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -54,3 +59,46 @@ func ValidateListDatasetsRequest(request *datacatalog.ListDatasetsRequest) error
 	}
 	return nil
 }
+
+// Ensure the update Dataset request is properly constructed
+func ValidateUpdateDatasetRequest(request *interfaces.UpdateDatasetRequest) error {
+	if err := ValidateDatasetID(request.Dataset); err != nil {
+		return err
+	}
+
+	for idx, partitionKey := range request.PartitionKeys {
+		if err := ValidateEmptyStringField(partitionKey, fmt.Sprintf("%v[%v]", partitionKeyName, idx)); err != nil {
+			return err
+		}
+	}
+
+	return ValidateUniquePartitionKeys(request.PartitionKeys)
+}
+
+// Validate that updating the partition keys of a dataset keeps its artifacts consistent. New keys can always be added,
+// but a key can only be removed if no artifact of the dataset is partitioned by it.
+func ValidatePartitionKeysUpdate(existingKeys []string, updatedKeys []string, keysInUse []string) error {
+	updatedKeySet := make(map[string]bool, len(updatedKeys))
+	for _, key := range updatedKeys {
+		updatedKeySet[key] = true
+	}
+
+	keysInUseSet := make(map[string]bool, len(keysInUse))
+	for _, key := range keysInUse {
+		keysInUseSet[key] = true
+	}
+
+	removedKeysInUse := make([]string, 0)
+	for _, key := range existingKeys {
+		if !updatedKeySet[key] && keysInUseSet[key] {
+			removedKeysInUse = append(removedKeysInUse, key)
+		}
+	}
+
+	if len(removedKeysInUse) > 0 {
+		return errors.NewDataCatalogErrorf(codes.FailedPrecondition,
+			"Cannot remove partition keys %v, they are still used by artifacts of the dataset", removedKeysInUse)
+	}
+
+	return nil
+}
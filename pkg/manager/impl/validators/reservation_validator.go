@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	reservationEntity = "reservationId"
+	reservationState  = "state"
+	waitTimeout       = "timeout"
+	metadataKey       = "metadata"
+	reservationSlot   = "slot"
+	ownerID           = "ownerId"
+)
+
+// Validate that the ReservationID has all the fields filled
+func ValidateReservationID(reservationID *datacatalog.ReservationID) error {
+	if reservationID == nil {
+		return NewMissingArgumentError(reservationEntity)
+	}
+	if err := ValidateDatasetID(reservationID.DatasetId); err != nil {
+		return err
+	}
+	return ValidateEmptyStringField(reservationID.TagName, tagName)
+}
+
+func ValidateForceReleaseReservationRequest(request *interfaces.ForceReleaseReservationRequest) error {
+	if request.Slot < 0 {
+		return NewInvalidArgumentError(reservationSlot, strconv.Itoa(request.Slot))
+	}
+
+	return ValidateReservationID(request.ReservationID)
+}
+
+func ValidateListReservationsRequest(request *interfaces.ListReservationsRequest) error {
+	switch request.State {
+	case interfaces.ReservationStateAny, interfaces.ReservationStateActive, interfaces.ReservationStateExpired:
+	default:
+		return NewInvalidArgumentError(reservationState, string(request.State))
+	}
+
+	if request.Pagination != nil {
+		err := ValidatePagination(request.Pagination)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ValidateWaitForArtifactRequest(request *interfaces.WaitForArtifactRequest) error {
+	if request.Timeout < 0 {
+		return NewInvalidArgumentError(waitTimeout, request.Timeout.String())
+	}
+
+	return ValidateReservationID(request.ReservationID)
+}
+
+// Validate that the artifact completing the reservation belongs to the dataset of the reservation
+func ValidateCompleteReservationRequest(request *interfaces.CompleteReservationRequest) error {
+	if err := ValidateReservationID(request.ReservationID); err != nil {
+		return err
+	}
+	if err := ValidateEmptyStringField(request.OwnerID, ownerID); err != nil {
+		return err
+	}
+	if err := ValidateArtifact(request.Artifact); err != nil {
+		return err
+	}
+	if !proto.Equal(request.Artifact.Dataset, request.ReservationID.DatasetId) {
+		return NewInvalidArgumentError(datasetEntity, request.Artifact.Dataset.String())
+	}
+
+	for _, name := range request.TagNames {
+		if err := ValidateEmptyStringField(name, tagName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate the metadata supplied by the owner of a reservation. The values of the well-known keys must be well-formed
+// and the keys set by the service must not be supplied.
+func ValidateReservationMetadata(metadata *datacatalog.Metadata) error {
+	for key, value := range metadata.GetKeyMap() {
+		switch key {
+		case interfaces.ReservationMetadataFencingToken, interfaces.ReservationMetadataSlot,
+			interfaces.ReservationMetadataQueuePosition:
+			return NewInvalidArgumentError(metadataKey, key)
+		case interfaces.ReservationMetadataProgress:
+			progress, err := strconv.ParseFloat(value, 64)
+			if err != nil || progress < 0 || progress > 100 {
+				return NewInvalidArgumentError(key, value)
+			}
+		case interfaces.ReservationMetadataStartedAt:
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return NewInvalidArgumentError(key, value)
+			}
+		}
+	}
+
+	return nil
+}
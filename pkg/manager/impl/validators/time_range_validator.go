@@ -0,0 +1,59 @@
+package validators
+
+import (
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	timeRangeStart = "timeRangeStart"
+	timeRangeEnd   = "timeRangeEnd"
+	timeRangeProp  = "timeRangeProperty"
+)
+
+// The entities whose timestamps can be filtered when listing the source entity
+var timeRangeFilterEntities = map[common.Entity]map[common.Entity]bool{
+	common.Artifact: {common.Artifact: true, common.Tag: true},
+	common.Dataset:  {common.Dataset: true},
+}
+
+// Validate the time range filters of a list request on the source entity. Bounds have to be RFC3339 timestamps and
+// at least one of them has to be set.
+func ValidateTimeRangeFilters(sourceEntity common.Entity, filters []common.TimeRangeFilter) error {
+	for _, filter := range filters {
+		if !timeRangeFilterEntities[sourceEntity][filter.Entity] {
+			return NewInvalidFilterError(sourceEntity, filter.Entity)
+		}
+
+		if filter.Property != common.CreatedAt && filter.Property != common.UpdatedAt {
+			return NewInvalidArgumentError(timeRangeProp, filter.Property)
+		}
+
+		if filter.Start == "" && filter.End == "" {
+			return NewMissingArgumentError("one of " + timeRangeStart + "/" + timeRangeEnd)
+		}
+
+		var start, end time.Time
+		var err error
+		if filter.Start != "" {
+			if start, err = time.Parse(time.RFC3339, filter.Start); err != nil {
+				return NewInvalidArgumentError(timeRangeStart, filter.Start)
+			}
+		}
+		if filter.End != "" {
+			if end, err = time.Parse(time.RFC3339, filter.End); err != nil {
+				return NewInvalidArgumentError(timeRangeEnd, filter.End)
+			}
+		}
+
+		if filter.Start != "" && filter.End != "" && !start.Before(end) {
+			return errors.NewDataCatalogErrorf(codes.InvalidArgument,
+				"time range start [%v] must be before its end [%v]", filter.Start, filter.End)
+		}
+	}
+
+	return nil
+}
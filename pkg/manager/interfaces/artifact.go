@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 
+	"github.com/flyteorg/datacatalog/pkg/common"
 	idl_datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 )
 
@@ -13,4 +14,51 @@ type ArtifactManager interface {
 	GetArtifact(ctx context.Context, request *idl_datacatalog.GetArtifactRequest) (*idl_datacatalog.GetArtifactResponse, error)
 	ListArtifacts(ctx context.Context, request *idl_datacatalog.ListArtifactsRequest) (*idl_datacatalog.ListArtifactsResponse, error)
 	UpdateArtifact(ctx context.Context, request *idl_datacatalog.UpdateArtifactRequest) (*idl_datacatalog.UpdateArtifactResponse, error)
+	SearchArtifacts(ctx context.Context, request *SearchArtifactsRequest) (*SearchArtifactsResponse, error)
+	CompleteReservation(ctx context.Context, request *CompleteReservationRequest) (*CompleteReservationResponse, error)
+}
+
+// Request message for searching artifacts across all datasets of a project and domain.
+type SearchArtifactsRequest struct {
+	// The project to search in
+	Project string `json:"project"`
+	// The domain to search in
+	Domain string `json:"domain"`
+	// If set, only searches the versions of the dataset with this name
+	DatasetName string `json:"datasetName,omitempty"`
+	// Partition and tag filters the artifacts have to match
+	Filter *idl_datacatalog.FilterExpression `json:"filter,omitempty"`
+	// Metadata key/value pairs the artifacts have to contain
+	MetadataFilters []*idl_datacatalog.KeyValuePair `json:"metadataFilters,omitempty"`
+	// Time ranges the creation or update time of the artifacts or their tags have to be in
+	TimeRangeFilters []common.TimeRangeFilter `json:"timeRangeFilters,omitempty"`
+	// Pagination of the results, sorted by creation time in descending order by default
+	Pagination *idl_datacatalog.PaginationOptions `json:"pagination,omitempty"`
+}
+
+// Response message for searching artifacts. The artifacts are returned without their data, which can be retrieved
+// with GetArtifact.
+type SearchArtifactsResponse struct {
+	Artifacts []*idl_datacatalog.Artifact `json:"artifacts"`
+	// Token to use for retrieving the next page of results
+	NextToken string `json:"nextToken"`
+}
+
+// Request message for completing a reservation by creating its artifact, tagging it and releasing the reservation of
+// the owner at once.
+type CompleteReservationRequest struct {
+	// The reservation held by the owner. The artifact is always tagged with the tag of the reservation.
+	ReservationID *idl_datacatalog.ReservationID `json:"reservationId"`
+	// The owner holding the reservation
+	OwnerID string `json:"ownerId"`
+	// The artifact computed by the owner, it must belong to the dataset of the reservation
+	Artifact *idl_datacatalog.Artifact `json:"artifact"`
+	// Further tags to apply to the artifact
+	TagNames []string `json:"tagNames,omitempty"`
+}
+
+// Response message for completing a reservation.
+type CompleteReservationResponse struct {
+	// The tags applied to the artifact, starting with the tag of the reservation
+	TagNames []string `json:"tagNames"`
 }
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	idl_datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 )
@@ -10,4 +11,46 @@ type DatasetManager interface {
 	CreateDataset(ctx context.Context, request *idl_datacatalog.CreateDatasetRequest) (*idl_datacatalog.CreateDatasetResponse, error)
 	GetDataset(ctx context.Context, request *idl_datacatalog.GetDatasetRequest) (*idl_datacatalog.GetDatasetResponse, error)
 	ListDatasets(ctx context.Context, request *idl_datacatalog.ListDatasetsRequest) (*idl_datacatalog.ListDatasetsResponse, error)
+	UpdateDataset(ctx context.Context, request *UpdateDatasetRequest) (*UpdateDatasetResponse, error)
+	GetDatasetStats(ctx context.Context, request *GetDatasetStatsRequest) (*GetDatasetStatsResponse, error)
+}
+
+// Request message for updating the metadata and partition keys of an existing Dataset.
+type UpdateDatasetRequest struct {
+	// The dataset to update
+	Dataset *idl_datacatalog.DatasetID `json:"dataset"`
+	// If set, replaces the metadata of the dataset
+	Metadata *idl_datacatalog.Metadata `json:"metadata,omitempty"`
+	// If set, the complete list of partition keys of the dataset. Keys not known yet are appended, omitted keys
+	// are removed as long as no artifact of the dataset is partitioned by them.
+	PartitionKeys []string `json:"partitionKeys,omitempty"`
+}
+
+// Response message for updating a Dataset, containing the updated Dataset.
+type UpdateDatasetResponse struct {
+	Dataset *idl_datacatalog.Dataset `json:"dataset"`
+}
+
+// Request message for retrieving statistics about the artifacts stored in a Dataset.
+type GetDatasetStatsRequest struct {
+	Dataset *idl_datacatalog.DatasetID `json:"dataset"`
+}
+
+// Response message containing the statistics of a Dataset. The statistics might be served from a cache, ComputedAt
+// indicates when they have been computed.
+type GetDatasetStatsResponse struct {
+	// Number of artifacts in the dataset
+	ArtifactCount int64 `json:"artifactCount"`
+	// Number of tags in the dataset
+	TagCount int64 `json:"tagCount"`
+	// Number of distinct partition values per partition key
+	PartitionValueCounts map[string]int64 `json:"partitionValueCounts"`
+	// Creation timestamp of the oldest artifact, unset for datasets without artifacts
+	OldestArtifactCreatedAt *time.Time `json:"oldestArtifactCreatedAt,omitempty"`
+	// Creation timestamp of the newest artifact, unset for datasets without artifacts
+	NewestArtifactCreatedAt *time.Time `json:"newestArtifactCreatedAt,omitempty"`
+	// Total size of the offloaded artifact data in bytes, as recorded when it was stored
+	TotalStoredBytes int64 `json:"totalStoredBytes"`
+	// When the statistics have been computed
+	ComputedAt time.Time `json:"computedAt"`
 }
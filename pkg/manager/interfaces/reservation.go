@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 )
@@ -12,4 +13,108 @@ import (
 type ReservationManager interface {
 	GetOrExtendReservation(context.Context, *datacatalog.GetOrExtendReservationRequest) (*datacatalog.GetOrExtendReservationResponse, error)
 	ReleaseReservation(context.Context, *datacatalog.ReleaseReservationRequest) (*datacatalog.ReleaseReservationResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	ForceReleaseReservation(context.Context, *ForceReleaseReservationRequest) (*ForceReleaseReservationResponse, error)
+	WaitForArtifact(context.Context, *WaitForArtifactRequest) (*WaitForArtifactResponse, error)
+}
+
+// Well-known keys of the reservation metadata. Owners may supply any metadata when acquiring or extending their
+// reservation, e.g. for the UI to show the progress of the execution computing the reserved artifact.
+const (
+	// The fencing token of the reservation, set by the service
+	ReservationMetadataFencingToken = "fencing_token"
+	// The slot of the reservation, set by the service. Reservations with a capacity of one are held in slot 0.
+	ReservationMetadataSlot = "slot"
+	// The position of the owner in the queue of a held reservation, set by the service if owners are queued
+	ReservationMetadataQueuePosition = "queue_position"
+	// The execution of the owner
+	ReservationMetadataExecutionID = "execution_id"
+	// The node of the execution of the owner
+	ReservationMetadataNodeID = "node_id"
+	// How far the owner has computed the artifact, a percentage between 0 and 100
+	ReservationMetadataProgress = "progress"
+	// When the owner started computing the artifact, an RFC3339 timestamp
+	ReservationMetadataStartedAt = "started_at"
+)
+
+// The state of a reservation, used to filter listed reservations
+type ReservationState string
+
+const (
+	// Matches all reservations
+	ReservationStateAny ReservationState = ""
+	// Matches reservations which have not expired yet
+	ReservationStateActive ReservationState = "active"
+	// Matches reservations which have expired but have not been taken over or released
+	ReservationStateExpired ReservationState = "expired"
+)
+
+// Request message for listing reservations. All filters are optional.
+type ListReservationsRequest struct {
+	Project     string `json:"project,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	DatasetName string `json:"datasetName,omitempty"`
+	// The ID of the owner holding the reservations
+	OwnerID string           `json:"ownerId,omitempty"`
+	State   ReservationState `json:"state,omitempty"`
+	// Pagination of the results, sorted by creation time in descending order by default
+	Pagination *datacatalog.PaginationOptions `json:"pagination,omitempty"`
+}
+
+// Response message for listing reservations. The heartbeat interval of the reservations is not persisted and
+// therefore left unset.
+type ListReservationsResponse struct {
+	Reservations []*datacatalog.Reservation `json:"reservations"`
+	// Token to use for retrieving the next page of results
+	NextToken string `json:"nextToken"`
+}
+
+// Request message for releasing a reservation regardless of its owner. Requires the caller to be an administrator.
+type ForceReleaseReservationRequest struct {
+	ReservationID *datacatalog.ReservationID `json:"reservationId"`
+	// The slot of the reservation to release if it has a capacity of more than one
+	Slot int `json:"slot,omitempty"`
+	// Why the reservation is released, recorded along with the identity of the caller
+	Reason string `json:"reason,omitempty"`
+}
+
+// Response message for force releasing a reservation.
+type ForceReleaseReservationResponse struct {
+	// The owner which held the released reservation
+	ReleasedOwnerID string `json:"releasedOwnerId"`
+}
+
+// Request message for waiting until the artifact of a reservation is available or the reservation is no longer held.
+type WaitForArtifactRequest struct {
+	// Identifies the dataset and the tag of the awaited artifact
+	ReservationID *datacatalog.ReservationID `json:"reservationId"`
+	// How long to wait at most, limited by the maximum wait duration of the service if unset or exceeding it
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// The owner waiting for the artifact. If set, the wait returns once the reservation is handed to the queued owner.
+	OwnerID string `json:"ownerId,omitempty"`
+}
+
+// The reason a wait for an artifact returned
+type WaitForArtifactResult string
+
+const (
+	// The artifact has been tagged
+	WaitForArtifactResultAvailable WaitForArtifactResult = "available"
+	// The reservation has been released or never existed, the artifact can be reserved
+	WaitForArtifactResultReleased WaitForArtifactResult = "released"
+	// The reservation has expired, the artifact can be reserved
+	WaitForArtifactResultExpired WaitForArtifactResult = "expired"
+	// The reservation is still held after waiting for the timeout
+	WaitForArtifactResultTimedOut WaitForArtifactResult = "timedOut"
+	// The reservation has been handed to the waiting owner, which was queued for it
+	WaitForArtifactResultAcquired WaitForArtifactResult = "acquired"
+)
+
+// Response message for waiting for an artifact.
+type WaitForArtifactResponse struct {
+	Result WaitForArtifactResult `json:"result"`
+	// The tagged artifact if available. Its data is not included, get the artifact by its tag to read it.
+	Artifact *datacatalog.Artifact `json:"artifact,omitempty"`
+	// The last known reservation if it has expired, is still held or has been acquired
+	Reservation *datacatalog.Reservation `json:"reservation,omitempty"`
 }
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	interfaces "github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,6 +16,47 @@ type ArtifactManager struct {
 	mock.Mock
 }
 
+type ArtifactManager_CompleteReservation struct {
+	*mock.Call
+}
+
+func (_m ArtifactManager_CompleteReservation) Return(_a0 *interfaces.CompleteReservationResponse, _a1 error) *ArtifactManager_CompleteReservation {
+	return &ArtifactManager_CompleteReservation{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ArtifactManager) OnCompleteReservation(ctx context.Context, request *interfaces.CompleteReservationRequest) *ArtifactManager_CompleteReservation {
+	c_call := _m.On("CompleteReservation", ctx, request)
+	return &ArtifactManager_CompleteReservation{Call: c_call}
+}
+
+func (_m *ArtifactManager) OnCompleteReservationMatch(matchers ...interface{}) *ArtifactManager_CompleteReservation {
+	c_call := _m.On("CompleteReservation", matchers...)
+	return &ArtifactManager_CompleteReservation{Call: c_call}
+}
+
+// CompleteReservation provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) CompleteReservation(ctx context.Context, request *interfaces.CompleteReservationRequest) (*interfaces.CompleteReservationResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *interfaces.CompleteReservationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.CompleteReservationRequest) *interfaces.CompleteReservationResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.CompleteReservationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.CompleteReservationRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ArtifactManager_CreateArtifact struct {
 	*mock.Call
 }
@@ -138,6 +180,47 @@ func (_m *ArtifactManager) ListArtifacts(ctx context.Context, request *datacatal
 	return r0, r1
 }
 
+type ArtifactManager_SearchArtifacts struct {
+	*mock.Call
+}
+
+func (_m ArtifactManager_SearchArtifacts) Return(_a0 *interfaces.SearchArtifactsResponse, _a1 error) *ArtifactManager_SearchArtifacts {
+	return &ArtifactManager_SearchArtifacts{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ArtifactManager) OnSearchArtifacts(ctx context.Context, request *interfaces.SearchArtifactsRequest) *ArtifactManager_SearchArtifacts {
+	c_call := _m.On("SearchArtifacts", ctx, request)
+	return &ArtifactManager_SearchArtifacts{Call: c_call}
+}
+
+func (_m *ArtifactManager) OnSearchArtifactsMatch(matchers ...interface{}) *ArtifactManager_SearchArtifacts {
+	c_call := _m.On("SearchArtifacts", matchers...)
+	return &ArtifactManager_SearchArtifacts{Call: c_call}
+}
+
+// SearchArtifacts provides a mock function with given fields: ctx, request
+func (_m *ArtifactManager) SearchArtifacts(ctx context.Context, request *interfaces.SearchArtifactsRequest) (*interfaces.SearchArtifactsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *interfaces.SearchArtifactsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.SearchArtifactsRequest) *interfaces.SearchArtifactsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.SearchArtifactsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.SearchArtifactsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ArtifactManager_UpdateArtifact struct {
 	*mock.Call
 }
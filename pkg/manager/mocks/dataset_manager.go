@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	interfaces "github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
 	mock "github.com/stretchr/testify/mock"
@@ -97,6 +98,47 @@ func (_m *DatasetManager) GetDataset(ctx context.Context, request *datacatalog.G
 	return r0, r1
 }
 
+type DatasetManager_GetDatasetStats struct {
+	*mock.Call
+}
+
+func (_m DatasetManager_GetDatasetStats) Return(_a0 *interfaces.GetDatasetStatsResponse, _a1 error) *DatasetManager_GetDatasetStats {
+	return &DatasetManager_GetDatasetStats{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *DatasetManager) OnGetDatasetStats(ctx context.Context, request *interfaces.GetDatasetStatsRequest) *DatasetManager_GetDatasetStats {
+	c_call := _m.On("GetDatasetStats", ctx, request)
+	return &DatasetManager_GetDatasetStats{Call: c_call}
+}
+
+func (_m *DatasetManager) OnGetDatasetStatsMatch(matchers ...interface{}) *DatasetManager_GetDatasetStats {
+	c_call := _m.On("GetDatasetStats", matchers...)
+	return &DatasetManager_GetDatasetStats{Call: c_call}
+}
+
+// GetDatasetStats provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) GetDatasetStats(ctx context.Context, request *interfaces.GetDatasetStatsRequest) (*interfaces.GetDatasetStatsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *interfaces.GetDatasetStatsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.GetDatasetStatsRequest) *interfaces.GetDatasetStatsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.GetDatasetStatsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.GetDatasetStatsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type DatasetManager_ListDatasets struct {
 	*mock.Call
 }
@@ -137,3 +179,44 @@ func (_m *DatasetManager) ListDatasets(ctx context.Context, request *datacatalog
 
 	return r0, r1
 }
+
+type DatasetManager_UpdateDataset struct {
+	*mock.Call
+}
+
+func (_m DatasetManager_UpdateDataset) Return(_a0 *interfaces.UpdateDatasetResponse, _a1 error) *DatasetManager_UpdateDataset {
+	return &DatasetManager_UpdateDataset{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *DatasetManager) OnUpdateDataset(ctx context.Context, request *interfaces.UpdateDatasetRequest) *DatasetManager_UpdateDataset {
+	c_call := _m.On("UpdateDataset", ctx, request)
+	return &DatasetManager_UpdateDataset{Call: c_call}
+}
+
+func (_m *DatasetManager) OnUpdateDatasetMatch(matchers ...interface{}) *DatasetManager_UpdateDataset {
+	c_call := _m.On("UpdateDataset", matchers...)
+	return &DatasetManager_UpdateDataset{Call: c_call}
+}
+
+// UpdateDataset provides a mock function with given fields: ctx, request
+func (_m *DatasetManager) UpdateDataset(ctx context.Context, request *interfaces.UpdateDatasetRequest) (*interfaces.UpdateDatasetResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *interfaces.UpdateDatasetResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.UpdateDatasetRequest) *interfaces.UpdateDatasetResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.UpdateDatasetResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.UpdateDatasetRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
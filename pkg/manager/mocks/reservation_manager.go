@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	interfaces "github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
 	mock "github.com/stretchr/testify/mock"
@@ -15,6 +16,47 @@ type ReservationManager struct {
 	mock.Mock
 }
 
+type ReservationManager_ForceReleaseReservation struct {
+	*mock.Call
+}
+
+func (_m ReservationManager_ForceReleaseReservation) Return(_a0 *interfaces.ForceReleaseReservationResponse, _a1 error) *ReservationManager_ForceReleaseReservation {
+	return &ReservationManager_ForceReleaseReservation{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationManager) OnForceReleaseReservation(_a0 context.Context, _a1 *interfaces.ForceReleaseReservationRequest) *ReservationManager_ForceReleaseReservation {
+	c_call := _m.On("ForceReleaseReservation", _a0, _a1)
+	return &ReservationManager_ForceReleaseReservation{Call: c_call}
+}
+
+func (_m *ReservationManager) OnForceReleaseReservationMatch(matchers ...interface{}) *ReservationManager_ForceReleaseReservation {
+	c_call := _m.On("ForceReleaseReservation", matchers...)
+	return &ReservationManager_ForceReleaseReservation{Call: c_call}
+}
+
+// ForceReleaseReservation provides a mock function with given fields: _a0, _a1
+func (_m *ReservationManager) ForceReleaseReservation(_a0 context.Context, _a1 *interfaces.ForceReleaseReservationRequest) (*interfaces.ForceReleaseReservationResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *interfaces.ForceReleaseReservationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.ForceReleaseReservationRequest) *interfaces.ForceReleaseReservationResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.ForceReleaseReservationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.ForceReleaseReservationRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ReservationManager_GetOrExtendReservation struct {
 	*mock.Call
 }
@@ -56,6 +98,47 @@ func (_m *ReservationManager) GetOrExtendReservation(_a0 context.Context, _a1 *d
 	return r0, r1
 }
 
+type ReservationManager_ListReservations struct {
+	*mock.Call
+}
+
+func (_m ReservationManager_ListReservations) Return(_a0 *interfaces.ListReservationsResponse, _a1 error) *ReservationManager_ListReservations {
+	return &ReservationManager_ListReservations{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationManager) OnListReservations(_a0 context.Context, _a1 *interfaces.ListReservationsRequest) *ReservationManager_ListReservations {
+	c_call := _m.On("ListReservations", _a0, _a1)
+	return &ReservationManager_ListReservations{Call: c_call}
+}
+
+func (_m *ReservationManager) OnListReservationsMatch(matchers ...interface{}) *ReservationManager_ListReservations {
+	c_call := _m.On("ListReservations", matchers...)
+	return &ReservationManager_ListReservations{Call: c_call}
+}
+
+// ListReservations provides a mock function with given fields: _a0, _a1
+func (_m *ReservationManager) ListReservations(_a0 context.Context, _a1 *interfaces.ListReservationsRequest) (*interfaces.ListReservationsResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *interfaces.ListReservationsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.ListReservationsRequest) *interfaces.ListReservationsResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.ListReservationsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.ListReservationsRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ReservationManager_ReleaseReservation struct {
 	*mock.Call
 }
@@ -96,3 +179,44 @@ func (_m *ReservationManager) ReleaseReservation(_a0 context.Context, _a1 *datac
 
 	return r0, r1
 }
+
+type ReservationManager_WaitForArtifact struct {
+	*mock.Call
+}
+
+func (_m ReservationManager_WaitForArtifact) Return(_a0 *interfaces.WaitForArtifactResponse, _a1 error) *ReservationManager_WaitForArtifact {
+	return &ReservationManager_WaitForArtifact{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationManager) OnWaitForArtifact(_a0 context.Context, _a1 *interfaces.WaitForArtifactRequest) *ReservationManager_WaitForArtifact {
+	c_call := _m.On("WaitForArtifact", _a0, _a1)
+	return &ReservationManager_WaitForArtifact{Call: c_call}
+}
+
+func (_m *ReservationManager) OnWaitForArtifactMatch(matchers ...interface{}) *ReservationManager_WaitForArtifact {
+	c_call := _m.On("WaitForArtifact", matchers...)
+	return &ReservationManager_WaitForArtifact{Call: c_call}
+}
+
+// WaitForArtifact provides a mock function with given fields: _a0, _a1
+func (_m *ReservationManager) WaitForArtifact(_a0 context.Context, _a1 *interfaces.WaitForArtifactRequest) (*interfaces.WaitForArtifactResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *interfaces.WaitForArtifactResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *interfaces.WaitForArtifactRequest) *interfaces.WaitForArtifactResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*interfaces.WaitForArtifactResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *interfaces.WaitForArtifactRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
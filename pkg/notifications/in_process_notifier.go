@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcessNotifier notifies subscribers of the current process only
+type InProcessNotifier struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func (n *InProcessNotifier) Publish(_ context.Context, key string) {
+	n.notify(key)
+}
+
+func (n *InProcessNotifier) Subscribe(key string) *Subscription {
+	// a single buffered notification suffices as subscribers look up the current state when notified
+	c := make(chan struct{}, 1)
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.subscribers[key] == nil {
+		n.subscribers[key] = make(map[chan struct{}]struct{})
+	}
+	n.subscribers[key][c] = struct{}{}
+
+	return &Subscription{
+		C: c,
+		close: func() {
+			n.mutex.Lock()
+			defer n.mutex.Unlock()
+			delete(n.subscribers[key], c)
+			if len(n.subscribers[key]) == 0 {
+				delete(n.subscribers, key)
+			}
+		},
+	}
+}
+
+// Close is a no-op, notifications are delivered in process without any connections
+func (n *InProcessNotifier) Close() error {
+	return nil
+}
+
+// Notify the subscribers of the key
+func (n *InProcessNotifier) notify(key string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for c := range n.subscribers[key] {
+		send(c)
+	}
+}
+
+// Notify the subscribers of all keys, e.g. if notifications may have been missed
+func (n *InProcessNotifier) notifyAll() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	for _, subscribers := range n.subscribers {
+		for c := range subscribers {
+			send(c)
+		}
+	}
+}
+
+// Send a notification without blocking, a pending notification already covers this one
+func send(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+func NewInProcessNotifier() *InProcessNotifier {
+	return &InProcessNotifier{
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
@@ -0,0 +1,70 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInProcessNotifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Notifies subscribers of the key", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		subscription := notifier.Subscribe("key")
+		defer subscription.Close()
+		otherSubscription := notifier.Subscribe("otherKey")
+		defer otherSubscription.Close()
+
+		notifier.Publish(ctx, "key")
+		assert.Len(t, subscription.C, 1)
+		assert.Len(t, otherSubscription.C, 0)
+	})
+
+	t.Run("Coalesces pending notifications", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		subscription := notifier.Subscribe("key")
+		defer subscription.Close()
+
+		notifier.Publish(ctx, "key")
+		notifier.Publish(ctx, "key")
+		assert.Len(t, subscription.C, 1)
+	})
+
+	t.Run("Stops notifying closed subscriptions", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		subscription := notifier.Subscribe("key")
+		subscription.Close()
+
+		notifier.Publish(ctx, "key")
+		assert.Len(t, subscription.C, 0)
+		assert.Empty(t, notifier.subscribers)
+	})
+
+	t.Run("Notifies all subscribers", func(t *testing.T) {
+		notifier := NewInProcessNotifier()
+		subscription := notifier.Subscribe("key")
+		defer subscription.Close()
+		otherSubscription := notifier.Subscribe("otherKey")
+		defer otherSubscription.Close()
+
+		notifier.notifyAll()
+		assert.Len(t, subscription.C, 1)
+		assert.Len(t, otherSubscription.C, 1)
+	})
+}
+
+func TestReservationKey(t *testing.T) {
+	key := ReservationKey(&datacatalog.ReservationID{
+		DatasetId: &datacatalog.DatasetID{
+			Project: "p",
+			Domain:  "d",
+			Name:    "n",
+			Version: "v",
+		},
+		TagName: "t",
+	})
+	assert.Equal(t, "p/d/n/v/t", key)
+}
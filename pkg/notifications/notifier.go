@@ -0,0 +1,56 @@
+// Package notifications notifies waiters within and across datacatalog replicas of writes to artifacts and
+// reservations, so they don't have to poll the database for them.
+package notifications
+
+import (
+	"context"
+	"strings"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories/config"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/database"
+	"github.com/flyteorg/flytestdlib/promutils"
+)
+
+// Notifier delivers notifications for keys to their subscribers. Notifications carry no payload, subscribers are
+// expected to look up the current state after being notified.
+type Notifier interface {
+	// Notify the subscribers of the key
+	Publish(ctx context.Context, key string)
+	// Subscribe to notifications for the key until the subscription is closed
+	Subscribe(key string) *Subscription
+	// Close stops receiving notifications and releases the connections of the notifier
+	Close() error
+}
+
+// Subscription receives notifications for a key. Notifications published while the previous one has not been received
+// yet are coalesced, so no notification is lost but subscribers may be notified fewer times than published.
+type Subscription struct {
+	// Receives a value for every notification
+	C <-chan struct{}
+
+	close func()
+}
+
+// Close stops the delivery of notifications to the subscription
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// ReservationKey returns the notification key for the writes to the tag and the reservation of the reservation ID
+func ReservationKey(reservationID *datacatalog.ReservationID) string {
+	datasetID := reservationID.DatasetId
+	return strings.Join([]string{datasetID.Project, datasetID.Domain, datasetID.Name, datasetID.Version,
+		reservationID.TagName}, "/")
+}
+
+// NewNotifier creates the notifier of the service. If distributed, notifications are published to all replicas with
+// Postgres, otherwise only the subscribers of the current process are notified.
+func NewNotifier(ctx context.Context, dbConfig database.DbConfig, distributed bool, scope promutils.Scope) (Notifier, error) {
+	if !distributed {
+		return NewInProcessNotifier(), nil
+	}
+
+	dsn := config.NewPostgresConfigProvider(dbConfig, scope.NewSubScope("postgres")).GetDSN()
+	return NewPostgresNotifier(ctx, dsn, scope)
+}
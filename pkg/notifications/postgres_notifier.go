@@ -0,0 +1,128 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/jackc/pgx/v4"
+
+	// registers the pgx driver for database/sql
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+// The Postgres channel notifications are published to
+const postgresChannel = "datacatalog_notifications"
+
+// How long to wait before listening again after the connection to Postgres failed
+const postgresReconnectDelay = time.Second * 5
+
+type postgresNotifierMetrics struct {
+	publishFailures labeled.Counter
+	listenFailures  labeled.Counter
+}
+
+// PostgresNotifier notifies subscribers of all replicas sharing the database with Postgres LISTEN/NOTIFY. The
+// notifications of each replica are received by its listener, including its own, and delivered in process.
+type PostgresNotifier struct {
+	db      *sql.DB
+	dsn     string
+	local   *InProcessNotifier
+	metrics postgresNotifierMetrics
+
+	stopListening context.CancelFunc
+	listening     chan struct{}
+}
+
+func (n *PostgresNotifier) Publish(ctx context.Context, key string) {
+	if _, err := n.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", postgresChannel, key); err != nil {
+		// waiters of other replicas fall back to their timeouts, but the local ones can still be notified
+		logger.Warnf(ctx, "Failed to publish notification for %v, err: %v", key, err)
+		n.metrics.publishFailures.Inc(ctx)
+		n.local.notify(key)
+	}
+}
+
+func (n *PostgresNotifier) Subscribe(key string) *Subscription {
+	return n.local.Subscribe(key)
+}
+
+// Close stops listening to notifications and closes the connections to Postgres
+func (n *PostgresNotifier) Close() error {
+	n.stopListening()
+	<-n.listening
+	return n.db.Close()
+}
+
+// Listen to the notifications of all replicas until the context is done, reconnecting whenever the connection fails
+func (n *PostgresNotifier) listen(ctx context.Context) {
+	defer close(n.listening)
+
+	for {
+		err := n.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Warnf(ctx, "Stopped listening to notifications, retrying in %v, err: %v", postgresReconnectDelay, err)
+		n.metrics.listenFailures.Inc(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(postgresReconnectDelay):
+		}
+	}
+}
+
+func (n *PostgresNotifier) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, n.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{postgresChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	// notifications may have been missed while not listening, let all subscribers look up the current state
+	n.local.notifyAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		n.local.notify(notification.Payload)
+	}
+}
+
+// NewPostgresNotifier creates a notifier for the Postgres database of the DSN, listening to notifications until the
+// context is done or the notifier is closed.
+func NewPostgresNotifier(ctx context.Context, dsn string, scope promutils.Scope) (*PostgresNotifier, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stopListening := context.WithCancel(ctx)
+	notifier := &PostgresNotifier{
+		db:            db,
+		dsn:           dsn,
+		local:         NewInProcessNotifier(),
+		stopListening: stopListening,
+		listening:     make(chan struct{}),
+		metrics: postgresNotifierMetrics{
+			publishFailures: labeled.NewCounter("publish_failure_count",
+				"The number of times a notification could not be published to Postgres", scope, labeled.EmitUnlabeledMetric),
+			listenFailures: labeled.NewCounter("listen_failure_count",
+				"The number of times listening to notifications from Postgres failed", scope, labeled.EmitUnlabeledMetric),
+		},
+	}
+	go notifier.listen(ctx)
+
+	return notifier, nil
+}
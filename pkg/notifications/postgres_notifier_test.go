@@ -0,0 +1,35 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.AppNameKey)
+}
+
+func TestPostgresNotifierClose(t *testing.T) {
+	// nothing listens on the port, so the notifier keeps reconnecting until it is closed
+	notifier, err := NewPostgresNotifier(context.Background(), "postgres://user@127.0.0.1:1/db?connect_timeout=1",
+		promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	closed := make(chan error)
+	go func() {
+		closed <- notifier.Close()
+	}()
+
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(postgresReconnectDelay * 2):
+		assert.Fail(t, "notifier did not stop listening")
+	}
+}
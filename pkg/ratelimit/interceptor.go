@@ -0,0 +1,131 @@
+// Package ratelimit limits the rate of requests of every tenant with token buckets.
+package ratelimit
+
+import (
+	"context"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Limit of the rate of requests. Tenants may send up to burst requests at once and requests-per-second afterwards.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// KeyFunc returns the tenant sending the request, whose requests share a token bucket
+type KeyFunc func(ctx context.Context, req interface{}) string
+
+// Limits the memory used for token buckets, idle buckets are dropped once exceeded
+const maxBuckets = 10000
+
+type bucketKey struct {
+	method string
+	tenant string
+}
+
+// Limiter holds the token buckets of the tenants for every limited method
+type Limiter struct {
+	limits  map[string]Limit
+	getKey  KeyFunc
+	now     func() time.Time
+	limited *prometheus.CounterVec
+
+	mutex   sync.Mutex
+	buckets map[bucketKey]*rate.Limiter
+}
+
+// Take a token from the bucket of the tenant for the method, returning how long to wait for the next token if there
+// is none left
+func (l *Limiter) take(method, tenant string, limit Limit) time.Duration {
+	now := l.now()
+	key := bucketKey{method: method, tenant: tenant}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.dropIdleBuckets(now)
+		}
+		bucket = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
+		l.buckets[key] = bucket
+	}
+
+	reservation := bucket.ReserveN(now, 1)
+	if !reservation.OK() {
+		return time.Duration(float64(time.Second) / limit.RequestsPerSecond)
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// Drop the buckets which have been refilled completely, they behave like new ones. Must be called with the mutex held.
+func (l *Limiter) dropIdleBuckets(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// UnaryServerInterceptor rejects the requests of tenants exceeding the limit of the method with ResourceExhausted.
+// The error details tell the tenant how long to wait before retrying.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		limit, ok := l.limits[strings.ToLower(method)]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		tenant := l.getKey(ctx, req)
+		retryDelay := l.take(method, tenant, limit)
+		if retryDelay <= 0 {
+			return handler(ctx, req)
+		}
+
+		logger.Debugf(ctx, "Rate limited %s request of %s, retry in %v", method, tenant, retryDelay)
+		l.limited.WithLabelValues(method).Inc()
+		st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)})
+		if err != nil {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return nil, st.Err()
+	}
+}
+
+// NewLimiter creates a limiter for the methods with a limit, identified by their case-insensitive name, as keys of
+// configured maps are lowercased. Limits without a positive rate and burst are ignored.
+func NewLimiter(limits map[string]Limit, getKey KeyFunc, now func() time.Time, limited *prometheus.CounterVec) *Limiter {
+	validLimits := make(map[string]Limit, len(limits))
+	for method, limit := range limits {
+		if limit.RequestsPerSecond > 0 && limit.Burst > 0 {
+			validLimits[strings.ToLower(method)] = limit
+		}
+	}
+
+	return &Limiter{
+		limits:  validLimits,
+		getKey:  getKey,
+		now:     now,
+		limited: limited,
+		buckets: make(map[bucketKey]*rate.Limiter),
+	}
+}
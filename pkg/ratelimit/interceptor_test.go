@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	createInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/CreateArtifact"}
+	getInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "served", nil
+	}
+	getKey := func(ctx context.Context, req interface{}) string {
+		return req.(string)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	newLimiter := func(now *time.Time) (*Limiter, *prometheus.CounterVec) {
+		limited := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rate_limited"}, []string{"method"})
+		limits := map[string]Limit{"createartifact": {RequestsPerSecond: 2, Burst: 2}}
+		return NewLimiter(limits, getKey, func() time.Time { return *now }, limited), limited
+	}
+
+	t.Run("burst exceeded", func(t *testing.T) {
+		now := start
+		limiter, limited := newLimiter(&now)
+		interceptor := limiter.UnaryServerInterceptor()
+
+		for i := 0; i < 2; i++ {
+			resp, err := interceptor(context.Background(), "p/d/svc", createInfo, handler)
+			assert.NoError(t, err)
+			assert.Equal(t, "served", resp)
+		}
+
+		resp, err := interceptor(context.Background(), "p/d/svc", createInfo, handler)
+		assert.Nil(t, resp)
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		assert.Len(t, st.Details(), 1)
+		retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+		assert.True(t, ok)
+		assert.Equal(t, 500*time.Millisecond, retryInfo.RetryDelay.AsDuration())
+		assert.Equal(t, 1.0, testutil.ToFloat64(limited.WithLabelValues("CreateArtifact")))
+
+		// the bucket is refilled after the retry delay
+		now = now.Add(500 * time.Millisecond)
+		_, err = interceptor(context.Background(), "p/d/svc", createInfo, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("tenants limited separately", func(t *testing.T) {
+		now := start
+		limiter, _ := newLimiter(&now)
+		interceptor := limiter.UnaryServerInterceptor()
+
+		for i := 0; i < 2; i++ {
+			_, err := interceptor(context.Background(), "p/d/svc", createInfo, handler)
+			assert.NoError(t, err)
+		}
+		_, err := interceptor(context.Background(), "p/d/svc", createInfo, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		_, err = interceptor(context.Background(), "p/d/other", createInfo, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unlimited method", func(t *testing.T) {
+		now := start
+		limiter, _ := newLimiter(&now)
+		interceptor := limiter.UnaryServerInterceptor()
+
+		for i := 0; i < 10; i++ {
+			_, err := interceptor(context.Background(), "p/d/svc", getInfo, handler)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("invalid limits ignored", func(t *testing.T) {
+		limited := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rate_limited"}, []string{"method"})
+		limiter := NewLimiter(map[string]Limit{"CreateArtifact": {RequestsPerSecond: 1}}, getKey, time.Now, limited)
+		assert.Empty(t, limiter.limits)
+	})
+}
+
+func TestDropIdleBuckets(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	limited := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rate_limited"}, []string{"method"})
+	limiter := NewLimiter(map[string]Limit{"createartifact": {RequestsPerSecond: 1, Burst: 1}}, nil,
+		func() time.Time { return now }, limited)
+
+	limiter.take("CreateArtifact", "idle", Limit{RequestsPerSecond: 1, Burst: 1})
+	now = now.Add(2 * time.Second)
+	limiter.take("CreateArtifact", "busy", Limit{RequestsPerSecond: 1, Burst: 1})
+
+	limiter.dropIdleBuckets(now)
+	assert.Len(t, limiter.buckets, 1)
+	assert.Contains(t, limiter.buckets, bucketKey{method: "CreateArtifact", tenant: "busy"})
+}
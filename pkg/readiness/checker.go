@@ -0,0 +1,185 @@
+// Package readiness checks periodically whether the dependencies of the catalog are available, so that no traffic is
+// routed to replicas unable to serve it.
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check returns an error if the dependency is unavailable
+type Check func(ctx context.Context) error
+
+// StatusSetter publishes the serving status of services, implemented by the gRPC health server
+type StatusSetter interface {
+	SetServingStatus(service string, servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus)
+}
+
+// CheckResult is the outcome of the last check of a dependency
+type CheckResult struct {
+	Ready     bool      `json:"ready"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Report of the readiness of the catalog, served by the readiness endpoint
+type Report struct {
+	Ready  bool                   `json:"ready"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs the checks of all dependencies periodically. Every dependency is reported with its name as service of
+// the gRPC health server, the catalog is ready if all of them are. The catalog is reported as not ready until the
+// checks completed for the first time and after it started shutting down.
+type Checker struct {
+	checks   map[string]Check
+	services []string
+	timeout  time.Duration
+	status   StatusSetter
+	now      func() time.Time
+
+	mutex        sync.RWMutex
+	results      map[string]CheckResult
+	shuttingDown bool
+}
+
+// Run the checks every interval until the context is cancelled
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckNow(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs all checks concurrently, each bound by the timeout, and publishes their results
+func (c *Checker) CheckNow(ctx context.Context) {
+	results := make(map[string]CheckResult, len(c.checks))
+	var resultsMutex sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			result := CheckResult{Ready: true}
+			if err := check(checkCtx); err != nil {
+				logger.Warnf(ctx, "Readiness check %s failed, err: %v", name, err)
+				result = CheckResult{Ready: false, Error: err.Error()}
+			}
+			result.CheckedAt = c.now()
+
+			resultsMutex.Lock()
+			results[name] = result
+			resultsMutex.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	// results are published with the lock held, so that they can't override the status set by Shutdown
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.shuttingDown {
+		return
+	}
+	c.results = results
+
+	ready := true
+	for name, result := range results {
+		c.status.SetServingStatus(name, servingStatus(result.Ready))
+		ready = ready && result.Ready
+	}
+	for _, service := range c.services {
+		c.status.SetServingStatus(service, servingStatus(ready))
+	}
+}
+
+// Shutdown reports the catalog as not ready from now on, so that no more traffic is routed to it while it drains the
+// requests in flight
+func (c *Checker) Shutdown() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.shuttingDown = true
+	for name := range c.checks {
+		c.status.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, service := range c.services {
+		c.status.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Report returns the results of the last checks
+func (c *Checker) Report() Report {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	report := Report{
+		Ready:  !c.shuttingDown && len(c.results) == len(c.checks),
+		Checks: make(map[string]CheckResult, len(c.results)),
+	}
+	for name, result := range c.results {
+		report.Checks[name] = result
+		report.Ready = report.Ready && result.Ready
+	}
+	return report
+}
+
+// ServeHTTP serves the report as JSON, with status 503 if the catalog is not ready
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Report()
+	body, err := json.Marshal(report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = w.Write(body)
+}
+
+func servingStatus(ready bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if ready {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
+
+// NewChecker creates a checker of the dependencies by their name. The services, e.g. "" for the overall health of
+// the server, are reported as serving if all dependencies are available.
+func NewChecker(checks map[string]Check, services []string, timeout time.Duration, status StatusSetter, now func() time.Time) *Checker {
+	for name := range checks {
+		status.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, service := range services {
+		status.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return &Checker{
+		checks:   checks,
+		services: services,
+		timeout:  timeout,
+		status:   status,
+		now:      now,
+		results:  make(map[string]CheckResult),
+	}
+}
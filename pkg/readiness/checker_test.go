@@ -0,0 +1,136 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func getServingStatus(t *testing.T, healthServer *health.Server, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := healthServer.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	assert.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var storageErr error
+	checks := map[string]Check{
+		"database": func(ctx context.Context) error { return nil },
+		"storage":  func(ctx context.Context) error { return storageErr },
+	}
+
+	healthServer := health.NewServer()
+	checker := NewChecker(checks, []string{"", "catalog"}, time.Second, healthServer, func() time.Time { return now })
+
+	t.Run("not ready before the first check", func(t *testing.T) {
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, ""))
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, "database"))
+		assert.False(t, checker.Report().Ready)
+
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		checker.CheckNow(context.Background())
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getServingStatus(t, healthServer, ""))
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getServingStatus(t, healthServer, "catalog"))
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getServingStatus(t, healthServer, "storage"))
+
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"ready": true, "checks": {
+			"database": {"ready": true, "checkedAt": "2022-01-01T00:00:00Z"},
+			"storage": {"ready": true, "checkedAt": "2022-01-01T00:00:00Z"}}}`, recorder.Body.String())
+	})
+
+	t.Run("dependency unavailable", func(t *testing.T) {
+		storageErr = fmt.Errorf("bucket not found")
+		defer func() { storageErr = nil }()
+
+		checker.CheckNow(context.Background())
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, ""))
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, "storage"))
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getServingStatus(t, healthServer, "database"))
+
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		var report Report
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+		assert.False(t, report.Ready)
+		assert.Equal(t, "bucket not found", report.Checks["storage"].Error)
+		assert.True(t, report.Checks["database"].Ready)
+	})
+
+	t.Run("check timeout", func(t *testing.T) {
+		slowChecker := NewChecker(map[string]Check{
+			"database": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, []string{""}, 10*time.Millisecond, healthServer, time.Now)
+
+		slowChecker.CheckNow(context.Background())
+		assert.False(t, slowChecker.Report().Ready)
+		assert.Equal(t, context.DeadlineExceeded.Error(), slowChecker.Report().Checks["database"].Error)
+	})
+}
+
+func TestRun(t *testing.T) {
+	healthServer := health.NewServer()
+	checked := make(chan struct{}, 10)
+	checker := NewChecker(map[string]Check{
+		"database": func(ctx context.Context) error {
+			checked <- struct{}{}
+			return nil
+		},
+	}, []string{""}, time.Second, healthServer, time.Now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	// checks right away and then periodically
+	<-checked
+	<-checked
+	cancel()
+	<-done
+	assert.True(t, checker.Report().Ready)
+}
+
+func TestShutdown(t *testing.T) {
+	healthServer := health.NewServer()
+	checker := NewChecker(map[string]Check{
+		"database": func(ctx context.Context) error { return nil },
+	}, []string{""}, time.Second, healthServer, time.Now)
+
+	checker.CheckNow(context.Background())
+	assert.True(t, checker.Report().Ready)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, getServingStatus(t, healthServer, ""))
+
+	checker.Shutdown()
+	assert.False(t, checker.Report().Ready)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, ""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, "database"))
+
+	// later checks don't report the catalog as ready again
+	checker.CheckNow(context.Background())
+	assert.False(t, checker.Report().Ready)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, getServingStatus(t, healthServer, ""))
+}
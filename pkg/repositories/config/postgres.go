@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/flyteorg/flytestdlib/database"
 	stdlibLogger "github.com/flyteorg/flytestdlib/logger"
@@ -65,6 +66,32 @@ func (p *PostgresConfigProvider) GetDBConfig() database.DbConfig {
 	return p.config
 }
 
+// Decorates a DbConnectionConfigProvider with the statement timeout of every connection
+type statementTimeoutConfigProvider struct {
+	DbConnectionConfigProvider
+	timeout time.Duration
+}
+
+func (p *statementTimeoutConfigProvider) GetDSN() string {
+	return fmt.Sprintf("%s options='-c statement_timeout=%d'", p.DbConnectionConfigProvider.GetDSN(), p.timeout.Milliseconds())
+}
+
+func (p *statementTimeoutConfigProvider) GetDialector() gorm.Dialector {
+	return postgres.Open(p.GetDSN())
+}
+
+// WithStatementTimeout sets the statement timeout of the connections opened with the config, so that it bounds every
+// query without a round trip per query. The config is returned as is if the timeout is zero.
+func WithStatementTimeout(config DbConnectionConfigProvider, timeout time.Duration) DbConnectionConfigProvider {
+	if timeout <= 0 {
+		return config
+	}
+	return &statementTimeoutConfigProvider{
+		DbConnectionConfigProvider: config,
+		timeout:                    timeout,
+	}
+}
+
 // Opens a connection to the database specified in the config.
 // You must call CloseDbConnection at the end of your session!
 func OpenDbConnection(ctx context.Context, config DbConnectionConfigProvider) (*gorm.DB, error) {
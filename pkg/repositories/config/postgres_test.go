@@ -45,6 +45,21 @@ func TestConstructGormArgsWithPassword(t *testing.T) {
 	assert.Equal(t, "host=localhost port=5432 dbname=postgres user=postgres password=pass sslmode=enable", postgresConfigProvider.GetDSN())
 }
 
+func TestWithStatementTimeout(t *testing.T) {
+	postgresConfigProvider := NewPostgresConfigProvider(database.DbConfig{Postgres: database.PostgresConfig{
+		Host:         "localhost",
+		Port:         5432,
+		DbName:       "postgres",
+		User:         "postgres",
+		ExtraOptions: "sslmode=disable",
+	},
+	}, mockScope.NewTestScope())
+
+	assert.Equal(t, postgresConfigProvider, WithStatementTimeout(postgresConfigProvider, 0))
+	assert.Equal(t, "host=localhost port=5432 dbname=postgres user=postgres sslmode=disable options='-c statement_timeout=5000'",
+		WithStatementTimeout(postgresConfigProvider, 5*time.Second).GetDSN())
+}
+
 func TestConstructGormArgsWithPasswordNoExtra(t *testing.T) {
 	postgresConfigProvider := NewPostgresConfigProvider(database.DbConfig{Postgres: database.PostgresConfig{
 		Host:     "localhost",
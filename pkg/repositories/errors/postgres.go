@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -18,6 +19,8 @@ import (
 const (
 	uniqueConstraintViolationCode = "23505"
 	undefinedTable                = "42P01"
+	// Statements cancelled by their statement timeout or the deadline of the request
+	queryCanceled = "57014"
 )
 
 type postgresErrorTransformer struct {
@@ -28,6 +31,8 @@ const (
 	uniqueConstraintViolation = "value with matching already exists (%s)"
 	defaultPgError            = "failed database operation with code [%s] and msg [%s]"
 	unsupportedTableOperation = "cannot query with specified table attributes: %s"
+	queryTimedOut             = "database operation timed out: %v"
+	queryCancelled            = "database operation cancelled: %v"
 )
 
 func (p *postgresErrorTransformer) fromGormError(err error) error {
@@ -40,6 +45,18 @@ func (p *postgresErrorTransformer) fromGormError(err error) error {
 }
 
 func (p *postgresErrorTransformer) ToDataCatalogError(err error) error {
+	// errors of the repos, e.g. invalid filters, are not database errors
+	if _, ok := err.(catalogErrors.DataCatalogError); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return catalogErrors.NewDataCatalogErrorf(codes.Canceled, queryCancelled, err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return catalogErrors.NewDataCatalogErrorf(codes.DeadlineExceeded, queryTimedOut, err)
+	}
+
 	if unwrappedErr := errors.Unwrap(err); unwrappedErr != nil {
 		err = unwrappedErr
 	}
@@ -56,6 +73,8 @@ func (p *postgresErrorTransformer) ToDataCatalogError(err error) error {
 		return catalogErrors.NewDataCatalogErrorf(codes.AlreadyExists, uniqueConstraintViolation, pqError.Message)
 	case undefinedTable:
 		return catalogErrors.NewDataCatalogErrorf(codes.InvalidArgument, unsupportedTableOperation, pqError.Message)
+	case queryCanceled:
+		return catalogErrors.NewDataCatalogErrorf(codes.DeadlineExceeded, queryTimedOut, pqError.Message)
 	default:
 		return catalogErrors.NewDataCatalogErrorf(codes.Unknown, fmt.Sprintf(defaultPgError, pqError.Code, pqError.Message))
 	}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/flyteorg/datacatalog/pkg/repositories/config"
 	"github.com/flyteorg/datacatalog/pkg/repositories/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/gormimpl"
 	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/runtime/configs"
 	"github.com/flyteorg/flytestdlib/promutils"
 )
 
@@ -30,18 +32,30 @@ type RepositoryInterface interface {
 	ArtifactRepo() interfaces.ArtifactRepo
 	TagRepo() interfaces.TagRepo
 	ReservationRepo() interfaces.ReservationRepo
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Ping(ctx context.Context) error
+	Close() error
 }
 
-func GetRepository(ctx context.Context, repoType RepoConfig, dbConfig database.DbConfig, scope promutils.Scope) RepositoryInterface {
+func GetRepository(ctx context.Context, repoType RepoConfig, dbConfig database.DbConfig, statementTimeouts configs.StatementTimeouts,
+	scope promutils.Scope) RepositoryInterface {
 	switch repoType {
 	case POSTGRES:
-		db, err := config.OpenDbConnection(ctx, config.NewPostgresConfigProvider(dbConfig, scope.NewSubScope("postgres")))
+		// the default statement timeout is set once per connection rather than per query
+		configProvider := config.WithStatementTimeout(config.NewPostgresConfigProvider(dbConfig, scope.NewSubScope("postgres")),
+			statementTimeouts.Default.Duration)
+		db, err := config.OpenDbConnection(ctx, configProvider)
 		if err != nil {
 			panic(err)
 		}
 		return NewPostgresRepo(
 			db,
 			errors.NewPostgresErrorTransformer(),
+			gormimpl.StatementTimeouts{
+				Get:   statementTimeouts.Get.Duration,
+				List:  statementTimeouts.List.Duration,
+				Write: statementTimeouts.Write.Duration,
+			},
 			scope.NewSubScope("repositories"))
 	default:
 		panic(fmt.Sprintf("Invalid repoType %v", repoType))
@@ -15,59 +15,75 @@ import (
 )
 
 type artifactRepo struct {
-	db               *gorm.DB
-	errorTransformer errors.ErrorTransformer
-	repoMetrics      gormMetrics
+	db                *gorm.DB
+	errorTransformer  errors.ErrorTransformer
+	statementTimeouts StatementTimeouts
+	repoMetrics       gormMetrics
 }
 
-func NewArtifactRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.ArtifactRepo {
+func NewArtifactRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, statementTimeouts StatementTimeouts, scope promutils.Scope) interfaces.ArtifactRepo {
 	return &artifactRepo{
-		db:               db,
-		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		db:                db,
+		errorTransformer:  errorTransformer,
+		statementTimeouts: statementTimeouts,
+		repoMetrics:       newGormMetrics(scope),
 	}
 }
 
-// Create the artifact in a transaction because ArtifactData will be created and associated along with it
+// Create the artifact in a transaction because ArtifactData will be created and associated along with it, and the
+// artifact is counted in the usage of its project. Within the transaction of the context the artifact is created in a
+// nested transaction.
 func (h *artifactRepo) Create(ctx context.Context, artifact models.Artifact) error {
+	ctx, span := startSpan(ctx, "artifactRepo.Create")
+	defer span.End()
+
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
 	defer timer.Stop()
 
-	tx := h.db.Begin()
-
-	tx = tx.Create(&artifact)
-
-	if tx.Error != nil {
-		tx.Rollback()
-		return h.errorTransformer.ToDataCatalogError(tx.Error)
-	}
+	err := getDB(ctx, h.db).Transaction(func(tx *gorm.DB) error {
+		if err := setStatementTimeout(tx, h.statementTimeouts.Write); err != nil {
+			return err
+		}
+		if err := tx.Create(&artifact).Error; err != nil {
+			return err
+		}
 
-	tx = tx.Commit()
-	if tx.Error != nil {
-		return h.errorTransformer.ToDataCatalogError(tx.Error)
+		var dataBytes int64
+		for _, artifactData := range artifact.ArtifactData {
+			dataBytes += artifactData.Size
+		}
+		return addProjectUsage(tx, artifact.DatasetProject, 1, dataBytes)
+	})
+	if err != nil {
+		return h.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return nil
 }
 
 func (h *artifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.Artifact, error) {
+	ctx, span := startSpan(ctx, "artifactRepo.Get")
+	defer span.End()
+
 	timer := h.repoMetrics.GetDuration.Start(ctx)
 	defer timer.Stop()
 
 	var artifact models.Artifact
-	result := h.db.Preload("ArtifactData").
-		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
-			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
-		}).
-		Preload("Tags").
-		Order("artifacts.created_at DESC").
-		First(
-			&artifact,
-			&models.Artifact{ArtifactKey: in},
-		)
-
-	if result.Error != nil {
-		if result.Error.Error() == gorm.ErrRecordNotFound.Error() {
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Preload("ArtifactData").
+			Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+				return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+			}).
+			Preload("Tags").
+			Order("artifacts.created_at DESC").
+			First(
+				&artifact,
+				&models.Artifact{ArtifactKey: in},
+			).Error
+	})
+
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
 			return models.Artifact{}, errors.GetMissingEntityError("Artifact", &datacatalog.Artifact{
 				Dataset: &datacatalog.DatasetID{
 					Project: in.DatasetProject,
@@ -79,13 +95,16 @@ func (h *artifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.A
 			})
 		}
 
-		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(result.Error)
+		return models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return artifact, nil
 }
 
 func (h *artifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error) {
+	ctx, span := startSpan(ctx, "artifactRepo.List")
+	defer span.End()
+
 	timer := h.repoMetrics.ListDuration.Start(ctx)
 	defer timer.Stop()
 
@@ -100,33 +119,92 @@ func (h *artifactRepo) List(ctx context.Context, datasetKey models.DatasetKey, i
 	}
 	in.ModelFilters = append(in.ModelFilters, datasetFilter)
 
-	// apply filters and joins
-	tx, err := applyListModelsInput(h.db, sourceEntity, in)
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.List, func(db *gorm.DB) error {
+		// apply filters and joins
+		tx, err := applyListModelsInput(db, sourceEntity, in)
+		if err != nil {
+			return err
+		} else if tx.Error != nil {
+			return tx.Error
+		}
 
+		return tx.Preload("ArtifactData").
+			Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+				return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+			}).
+			Preload("Tags").Find(&artifacts).Error
+	})
 	if err != nil {
-		return nil, err
-	} else if tx.Error != nil {
-		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
 	}
+	return artifacts, nil
+}
+
+// Search artifacts across datasets. The non-empty fields of the dataset key scope the search, so an empty version
+// matches all versions of a dataset and an empty name all datasets of the project and domain. The artifact data is
+// not loaded, as search results are meant to locate artifacts rather than retrieve them.
+func (h *artifactRepo) Search(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error) {
+	ctx, span := startSpan(ctx, "artifactRepo.Search")
+	defer span.End()
 
-	tx = tx.Preload("ArtifactData").
-		Preload("Partitions", func(db *gorm.DB) *gorm.DB {
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer timer.Stop()
+
+	artifacts := make([]models.Artifact, 0)
+	sourceEntity := common.Artifact
+
+	// add filters for the dataset scope
+	scopeFields := []struct {
+		field string
+		value string
+	}{
+		{"dataset_project", datasetKey.Project},
+		{"dataset_domain", datasetKey.Domain},
+		{"dataset_name", datasetKey.Name},
+		{"dataset_version", datasetKey.Version},
+	}
+	scopeFilters := make([]models.ModelValueFilter, 0, len(scopeFields))
+	for _, scopeField := range scopeFields {
+		if scopeField.value != "" {
+			scopeFilters = append(scopeFilters, NewGormValueFilter(common.Equal, scopeField.field, scopeField.value))
+		}
+	}
+	in.ModelFilters = append(in.ModelFilters, models.ModelFilter{
+		Entity:       common.Artifact,
+		ValueFilters: scopeFilters,
+	})
+
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.List, func(db *gorm.DB) error {
+		// apply filters and joins
+		tx, err := applyListModelsInput(db, sourceEntity, in)
+		if err != nil {
+			return err
+		} else if tx.Error != nil {
+			return tx.Error
+		}
+
+		return tx.Preload("Partitions", func(db *gorm.DB) *gorm.DB {
 			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
 		}).
-		Preload("Tags").Find(&artifacts)
-	if tx.Error != nil {
-		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+			Preload("Tags").Find(&artifacts).Error
+	})
+	if err != nil {
+		return []models.Artifact{}, h.errorTransformer.ToDataCatalogError(err)
 	}
 	return artifacts, nil
 }
 
 // Update updates the given artifact and its associated ArtifactData in database. The ArtifactData entries are upserted
 // (ignoring conflicts, as no updates to the database model are to be expected) and any longer existing data is deleted.
+// The usage of the project is adjusted by the difference in the size of the data.
 func (h *artifactRepo) Update(ctx context.Context, artifact models.Artifact) error {
+	ctx, span := startSpan(ctx, "artifactRepo.Update")
+	defer span.End()
+
 	timer := h.repoMetrics.UpdateDuration.Start(ctx)
 	defer timer.Stop()
 
-	tx := h.db.Begin()
+	tx := h.db.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
@@ -137,6 +215,11 @@ func (h *artifactRepo) Update(ctx context.Context, artifact models.Artifact) err
 		return err
 	}
 
+	if err := setStatementTimeout(tx, h.statementTimeouts.Write); err != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(err)
+	}
+
 	// ensure all artifact fields in DB are up-to-date
 	if res := tx.Model(&models.Artifact{ArtifactKey: artifact.ArtifactKey}).Updates(artifact); res.Error != nil {
 		tx.Rollback()
@@ -155,6 +238,12 @@ func (h *artifactRepo) Update(ctx context.Context, artifact models.Artifact) err
 		})
 	}
 
+	dataBytesBefore, err := sumArtifactDataSize(tx, artifact.ArtifactKey)
+	if err != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(err)
+	}
+
 	artifactDataNames := make([]string, len(artifact.ArtifactData))
 	for i := range artifact.ArtifactData {
 		artifactDataNames[i] = artifact.ArtifactData[i].Name
@@ -174,9 +263,89 @@ func (h *artifactRepo) Update(ctx context.Context, artifact models.Artifact) err
 		return h.errorTransformer.ToDataCatalogError(err)
 	}
 
+	dataBytesAfter, err := sumArtifactDataSize(tx, artifact.ArtifactKey)
+	if err != nil {
+		tx.Rollback()
+		return h.errorTransformer.ToDataCatalogError(err)
+	}
+
+	if dataBytesAfter != dataBytesBefore {
+		if err := addProjectUsage(tx, artifact.DatasetProject, 0, dataBytesAfter-dataBytesBefore); err != nil {
+			tx.Rollback()
+			return h.errorTransformer.ToDataCatalogError(err)
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return h.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return nil
 }
+
+// GetProjectUsage gets the usage counted for the project, which is empty if no artifacts of the project were counted
+func (h *artifactRepo) GetProjectUsage(ctx context.Context, project string) (models.ProjectUsage, error) {
+	ctx, span := startSpan(ctx, "artifactRepo.GetProjectUsage")
+	defer span.End()
+
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	var usages []models.ProjectUsage
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Where(&models.ProjectUsage{Project: project}).Limit(1).Find(&usages).Error
+	})
+	if err != nil {
+		return models.ProjectUsage{}, h.errorTransformer.ToDataCatalogError(err)
+	}
+
+	if len(usages) == 0 {
+		return models.ProjectUsage{Project: project}, nil
+	}
+	return usages[0], nil
+}
+
+// GetProjectUsageForUpdate gets the usage counted for the project and locks it until the transaction of the context
+// ends, so that concurrent artifacts of the project can't exceed its quota
+func (h *artifactRepo) GetProjectUsageForUpdate(ctx context.Context, project string) (models.ProjectUsage, error) {
+	ctx, span := startSpan(ctx, "artifactRepo.GetProjectUsageForUpdate")
+	defer span.End()
+
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	var usage models.ProjectUsage
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Get, func(db *gorm.DB) error {
+		// the usage of a project without artifacts is created empty, so that there is a row to lock
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProjectUsage{Project: project}).Error; err != nil {
+			return err
+		}
+
+		return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&models.ProjectUsage{Project: project}).Take(&usage).Error
+	})
+	if err != nil {
+		return models.ProjectUsage{}, h.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return usage, nil
+}
+
+// Add the artifacts and bytes of data to the usage of the project, creating it for the first artifact of the project
+func addProjectUsage(tx *gorm.DB, project string, artifacts int64, dataBytes int64) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("excluded.updated_at")},
+			{Column: clause.Column{Name: "artifact_count"}, Value: gorm.Expr("project_usages.artifact_count + excluded.artifact_count")},
+			{Column: clause.Column{Name: "data_bytes"}, Value: gorm.Expr("project_usages.data_bytes + excluded.data_bytes")},
+		},
+	}).Create(&models.ProjectUsage{Project: project, ArtifactCount: artifacts, DataBytes: dataBytes}).Error
+}
+
+// Sum up the size of the data of the artifact
+func sumArtifactDataSize(tx *gorm.DB, artifactKey models.ArtifactKey) (int64, error) {
+	var dataBytes int64
+	err := tx.Model(&models.ArtifactData{}).Select("COALESCE(SUM(size), 0)").
+		Where(&models.ArtifactData{ArtifactKey: artifactKey}).Scan(&dataBytes).Error
+	return dataBytes, err
+}
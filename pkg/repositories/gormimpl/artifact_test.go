@@ -123,7 +123,7 @@ func TestCreateArtifact(t *testing.T) {
 	)
 
 	GlobalMock.NewMock().WithQuery(
-		`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10),($11,$12,$13,$14,$15,$16,$17,$18,$19,$20) ON CONFLICT ("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name") DO UPDATE SET "dataset_project"="excluded"."dataset_project","dataset_name"="excluded"."dataset_name","dataset_domain"="excluded"."dataset_domain","dataset_version"="excluded"."dataset_version","artifact_id"="excluded"."artifact_id"`).WithCallback(
+		`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","size") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22) ON CONFLICT ("dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name") DO UPDATE SET "dataset_project"="excluded"."dataset_project","dataset_name"="excluded"."dataset_name","dataset_domain"="excluded"."dataset_domain","dataset_version"="excluded"."dataset_version","artifact_id"="excluded"."artifact_id"`).WithCallback(
 		func(s string, values []driver.NamedValue) {
 			// Batch insert
 			numArtifactDataCreated += 2
@@ -137,14 +137,24 @@ func TestCreateArtifact(t *testing.T) {
 		},
 	)
 
+	var usageValues []driver.NamedValue
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "project_usages" ("created_at","updated_at","deleted_at","project","artifact_count","data_bytes") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("project") DO UPDATE SET "updated_at"=excluded.updated_at,"artifact_count"=project_usages.artifact_count + excluded.artifact_count,"data_bytes"=project_usages.data_bytes + excluded.data_bytes`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			usageValues = values
+		},
+	)
+
 	data := make([]models.ArtifactData, 2)
 	data[0] = models.ArtifactData{
 		Name:     "test",
 		Location: "dataloc",
+		Size:     100,
 	}
 	data[1] = models.ArtifactData{
 		Name:     "test2",
 		Location: "dataloc2",
+		Size:     20,
 	}
 
 	artifact.ArtifactData = data
@@ -154,12 +164,17 @@ func TestCreateArtifact(t *testing.T) {
 
 	artifact.Partitions = partitions
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := artifactRepo.Create(context.Background(), artifact)
 	assert.NoError(t, err)
 	assert.True(t, artifactCreated)
 	assert.Equal(t, 2, numArtifactDataCreated)
 	assert.Equal(t, 1, numPartitionsCreated)
+	// the artifact and its data are counted in the usage of the project
+	assert.Len(t, usageValues, 6)
+	assert.Equal(t, "testProject", usageValues[3].Value)
+	assert.EqualValues(t, 1, usageValues[4].Value)
+	assert.EqualValues(t, 120, usageValues[5].Value)
 }
 
 func TestGetArtifact(t *testing.T) {
@@ -190,7 +205,7 @@ func TestGetArtifact(t *testing.T) {
 		ArtifactID:     artifact.ArtifactID,
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	response, err := artifactRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
@@ -228,7 +243,7 @@ func TestGetArtifactByID(t *testing.T) {
 		ArtifactID: artifact.ArtifactID,
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	response, err := artifactRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
@@ -249,7 +264,7 @@ func TestGetArtifactDoesNotExist(t *testing.T) {
 	}
 
 	// by default mocket will return nil for any queries
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	_, err := artifactRepo.Get(context.Background(), getInput)
 	assert.Error(t, err)
 	dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -269,7 +284,7 @@ func TestCreateArtifactAlreadyExists(t *testing.T) {
 		getAlreadyExistsErr(),
 	)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := artifactRepo.Create(context.Background(), artifact)
 	assert.Error(t, err)
 	dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -297,7 +312,7 @@ func TestListArtifactsWithPartition(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "tags" WHERE ("tags"."artifact_id","tags"."dataset_uuid") IN (($1,$2))%!!(string=test-uuid)(EXTRA string=123)`).WithReply(expectedTagResponse)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		ModelFilters: []models.ModelFilter{
 			{Entity: common.Partition,
@@ -339,7 +354,7 @@ func TestListArtifactsNoPartitions(t *testing.T) {
 	GlobalMock.NewMock().WithQuery(
 		`SELECT * FROM "partitions" WHERE "partitions"."artifact_id" = $1 ORDER BY partitions.created_at ASC%!(EXTRA string=123)`).WithReply(expectedPartitionResponse)
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		Offset: 10,
 		Limit:  10,
@@ -373,11 +388,21 @@ func TestUpdateArtifact(t *testing.T) {
 			artifactDataDeleted = true
 		})
 	artifactDataUpserted := false
-	GlobalMock.NewMock().WithQuery(`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10),($11,$12,$13,$14,$15,$16,$17,$18,$19,$20) ON CONFLICT DO NOTHING`).
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","size") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22) ON CONFLICT DO NOTHING`).
 		WithRowsNum(1).
 		WithCallback(func(s string, values []driver.NamedValue) {
 			artifactDataUpserted = true
 		})
+	// the data is summed up before and after it is updated
+	GlobalMock.NewMock().WithQuery(`SELECT COALESCE(SUM(size), 0) FROM "artifact_data" WHERE "artifact_data"."artifact_id" = $1`).
+		OneTime().WithReply([]map[string]interface{}{{"coalesce": 100}})
+	GlobalMock.NewMock().WithQuery(`SELECT COALESCE(SUM(size), 0) FROM "artifact_data" WHERE "artifact_data"."artifact_id" = $1`).
+		OneTime().WithReply([]map[string]interface{}{{"coalesce": 250}})
+	var usageValues []driver.NamedValue
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "project_usages"`).
+		WithCallback(func(s string, values []driver.NamedValue) {
+			usageValues = values
+		})
 
 	updateInput := models.Artifact{
 		ArtifactKey: models.ArtifactKey{
@@ -395,12 +420,14 @@ func TestUpdateArtifact(t *testing.T) {
 		},
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := artifactRepo.Update(ctx, updateInput)
 	assert.NoError(t, err)
 	assert.True(t, artifactUpdated)
 	assert.True(t, artifactDataDeleted)
 	assert.True(t, artifactDataUpserted)
+	assert.NotEmpty(t, usageValues)
+	assert.EqualValues(t, 150, usageValues[len(usageValues)-1].Value)
 }
 
 func TestUpdateArtifactDoesNotExist(t *testing.T) {
@@ -426,7 +453,7 @@ func TestUpdateArtifactDoesNotExist(t *testing.T) {
 		},
 	}
 
-	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := artifactRepo.Update(ctx, updateInput)
 	assert.Error(t, err)
 	dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -462,7 +489,7 @@ func TestUpdateArtifactError(t *testing.T) {
 			},
 		}
 
-		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 		err := artifactRepo.Update(ctx, updateInput)
 		assert.Error(t, err)
 		dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -502,7 +529,7 @@ func TestUpdateArtifactError(t *testing.T) {
 			},
 		}
 
-		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 		err := artifactRepo.Update(ctx, updateInput)
 		assert.Error(t, err)
 		dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -530,7 +557,7 @@ func TestUpdateArtifactError(t *testing.T) {
 			WithCallback(func(s string, values []driver.NamedValue) {
 				artifactDataDeleted = true
 			})
-		GlobalMock.NewMock().WithQuery(`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10),($11,$12,$13,$14,$15,$16,$17,$18,$19,$20) ON CONFLICT DO NOTHING`).
+		GlobalMock.NewMock().WithQuery(`INSERT INTO "artifact_data" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","artifact_id","name","location","size") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22) ON CONFLICT DO NOTHING`).
 			WithExecException()
 
 		updateInput := models.Artifact{
@@ -549,7 +576,7 @@ func TestUpdateArtifactError(t *testing.T) {
 			},
 		}
 
-		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+		artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 		err := artifactRepo.Update(ctx, updateInput)
 		assert.Error(t, err)
 		dcErr, ok := err.(apiErrors.DataCatalogError)
@@ -559,3 +586,95 @@ func TestUpdateArtifactError(t *testing.T) {
 		assert.True(t, artifactDataDeleted)
 	})
 }
+
+func TestSearchArtifacts(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	artifact := getTestArtifact()
+	expectedArtifactResponse := getDBArtifactResponse(artifact)
+	expectedPartitionResponse := getDBPartitionResponse(artifact)
+	expectedTagResponse := getDBTagResponse(artifact)
+	artifactQueried := false
+	GlobalMock.NewMock().WithQuery(
+		`SELECT "artifacts"."created_at","artifacts"."updated_at","artifacts"."deleted_at","artifacts"."dataset_project","artifacts"."dataset_name","artifacts"."dataset_domain","artifacts"."dataset_version","artifacts"."artifact_id","artifacts"."dataset_uuid","artifacts"."serialized_metadata" FROM "artifacts" JOIN partitions partitions0 ON artifacts.artifact_id = partitions0.artifact_id WHERE partitions0.key = $1 AND partitions0.value = $2 AND artifacts.dataset_project = $3 AND artifacts.dataset_domain = $4 AND artifacts.dataset_name = $5 ORDER BY artifacts.created_at desc LIMIT 10`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			artifactQueried = true
+		}).WithReply(expectedArtifactResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "partitions" WHERE "partitions"."artifact_id" = $1 ORDER BY partitions.created_at ASC%!(EXTRA string=123)`).WithReply(expectedPartitionResponse)
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "tags" WHERE ("tags"."artifact_id","tags"."dataset_uuid") IN (($1,$2))%!!(string=test-uuid)(EXTRA string=123)`).WithReply(expectedTagResponse)
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{Entity: common.Partition,
+				JoinCondition: NewGormJoinCondition(common.Artifact, common.Partition),
+				ValueFilters: []models.ModelValueFilter{
+					NewGormValueFilter(common.Equal, "key", "region"),
+					NewGormValueFilter(common.Equal, "value", "SEA"),
+				},
+			},
+		},
+		Limit:         10,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_DESCENDING),
+	}
+	datasetKey := models.DatasetKey{Project: "testProject", Domain: "testDomain", Name: "testName"}
+	artifacts, err := artifactRepo.Search(context.Background(), datasetKey, listInput)
+	assert.NoError(t, err)
+	assert.True(t, artifactQueried)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, artifact.ArtifactID, artifacts[0].ArtifactID)
+	assert.Len(t, artifacts[0].ArtifactData, 0)
+	assert.Len(t, artifacts[0].Partitions, 1)
+	assert.Len(t, artifacts[0].Tags, 1)
+}
+
+func TestGetProjectUsage(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	query := `SELECT * FROM "project_usages" WHERE "project_usages"."project" = $1 LIMIT 1`
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+
+	t.Run("counted", func(t *testing.T) {
+		GlobalMock.NewMock().WithQuery(query).OneTime().
+			WithReply([]map[string]interface{}{{"project": "testProject", "artifact_count": 3, "data_bytes": 1024}})
+
+		usage, err := artifactRepo.GetProjectUsage(context.Background(), "testProject")
+		assert.NoError(t, err)
+		assert.Equal(t, "testProject", usage.Project)
+		assert.EqualValues(t, 3, usage.ArtifactCount)
+		assert.EqualValues(t, 1024, usage.DataBytes)
+	})
+
+	t.Run("not counted", func(t *testing.T) {
+		usage, err := artifactRepo.GetProjectUsage(context.Background(), "testProject")
+		assert.NoError(t, err)
+		assert.Equal(t, models.ProjectUsage{Project: "testProject"}, usage)
+	})
+}
+
+func TestGetProjectUsageForUpdate(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	usageCreated := false
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "project_usages" ("created_at","updated_at","deleted_at","project","artifact_count","data_bytes") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT DO NOTHING`).
+		WithCallback(func(s string, values []driver.NamedValue) {
+			usageCreated = true
+		})
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "project_usages" WHERE "project_usages"."project" = $1 LIMIT 1 FOR UPDATE`).
+		WithReply([]map[string]interface{}{{"project": "testProject", "artifact_count": 3, "data_bytes": 1024}})
+
+	artifactRepo := NewArtifactRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	usage, err := artifactRepo.GetProjectUsageForUpdate(context.Background(), "testProject")
+	assert.NoError(t, err)
+	assert.True(t, usageCreated)
+	assert.Equal(t, "testProject", usage.Project)
+	assert.EqualValues(t, 3, usage.ArtifactCount)
+	assert.EqualValues(t, 1024, usage.DataBytes)
+}
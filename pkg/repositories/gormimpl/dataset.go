@@ -2,6 +2,7 @@ package gormimpl
 
 import (
 	"context"
+	"time"
 
 	idl_datacatalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
@@ -12,48 +13,77 @@ import (
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type dataSetRepo struct {
-	db               *gorm.DB
-	errorTransformer errors.ErrorTransformer
-	repoMetrics      gormMetrics
+	db                *gorm.DB
+	errorTransformer  errors.ErrorTransformer
+	statementTimeouts StatementTimeouts
+	repoMetrics       gormMetrics
 }
 
-func NewDatasetRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.DatasetRepo {
+func NewDatasetRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, statementTimeouts StatementTimeouts, scope promutils.Scope) interfaces.DatasetRepo {
 	return &dataSetRepo{
-		db:               db,
-		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		db:                db,
+		errorTransformer:  errorTransformer,
+		statementTimeouts: statementTimeouts,
+		repoMetrics:       newGormMetrics(scope),
 	}
 }
 
 // Create a Dataset model
 func (h *dataSetRepo) Create(ctx context.Context, in models.Dataset) error {
+	ctx, span := startSpan(ctx, "dataSetRepo.Create")
+	defer span.End()
+
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
 	defer timer.Stop()
 
-	result := h.db.Create(&in)
-	if result.Error != nil {
-		return h.errorTransformer.ToDataCatalogError(result.Error)
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Create(&in).Error
+	})
+	if err != nil {
+		return h.errorTransformer.ToDataCatalogError(err)
 	}
 	return nil
 }
 
 // Get Dataset model
 func (h *dataSetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Dataset, error) {
+	ctx, span := startSpan(ctx, "dataSetRepo.Get")
+	defer span.End()
+
+	return h.get(ctx, in, false)
+}
+
+// GetForUpdate gets the Dataset model and locks it until the transaction of the context ends, so that no artifacts can
+// be created for the dataset while it is updated
+func (h *dataSetRepo) GetForUpdate(ctx context.Context, in models.DatasetKey) (models.Dataset, error) {
+	ctx, span := startSpan(ctx, "dataSetRepo.GetForUpdate")
+	defer span.End()
+
+	return h.get(ctx, in, true)
+}
+
+func (h *dataSetRepo) get(ctx context.Context, in models.DatasetKey, forUpdate bool) (models.Dataset, error) {
 	timer := h.repoMetrics.GetDuration.Start(ctx)
 	defer timer.Stop()
 
 	var ds models.Dataset
-	result := h.db.Preload("PartitionKeys", func(db *gorm.DB) *gorm.DB {
-		return db.Order("partition_keys.created_at ASC") // preserve the order in which the partitions were created
-	}).First(&ds, &models.Dataset{DatasetKey: in})
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Get, func(db *gorm.DB) error {
+		if forUpdate {
+			db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		return db.Preload("PartitionKeys", func(db *gorm.DB) *gorm.DB {
+			return db.Order("partition_keys.created_at ASC") // preserve the order in which the partitions were created
+		}).First(&ds, &models.Dataset{DatasetKey: in}).Error
+	})
 
-	if result.Error != nil {
-		logger.Debugf(ctx, "Unable to find Dataset: [%+v], err: %v", in, result.Error)
+	if err != nil {
+		logger.Debugf(ctx, "Unable to find Dataset: [%+v], err: %v", in, err)
 
-		if result.Error.Error() == gorm.ErrRecordNotFound.Error() {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
 			return models.Dataset{}, errors.GetMissingEntityError("Dataset", &idl_datacatalog.DatasetID{
 				Project: in.Project,
 				Domain:  in.Domain,
@@ -61,28 +91,197 @@ func (h *dataSetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Dat
 				Version: in.Version,
 			})
 		}
-		return models.Dataset{}, h.errorTransformer.ToDataCatalogError(result.Error)
+		return models.Dataset{}, h.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return ds, nil
 }
 
 func (h *dataSetRepo) List(ctx context.Context, in models.ListModelsInput) ([]models.Dataset, error) {
+	ctx, span := startSpan(ctx, "dataSetRepo.List")
+	defer span.End()
+
 	timer := h.repoMetrics.ListDuration.Start(ctx)
 	defer timer.Stop()
 
-	// apply filters and joins
-	tx, err := applyListModelsInput(h.db, common.Dataset, in)
+	datasets := make([]models.Dataset, 0)
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.List, func(db *gorm.DB) error {
+		// apply filters and joins
+		tx, err := applyListModelsInput(db, common.Dataset, in)
+		if err != nil {
+			return err
+		} else if tx.Error != nil {
+			return tx.Error
+		}
+
+		return tx.Preload("PartitionKeys").Find(&datasets).Error
+	})
 	if err != nil {
-		return nil, err
-	} else if tx.Error != nil {
-		return []models.Dataset{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+		return []models.Dataset{}, h.errorTransformer.ToDataCatalogError(err)
 	}
+	return datasets, nil
+}
 
-	datasets := make([]models.Dataset, 0)
-	tx = tx.Preload("PartitionKeys").Find(&datasets)
-	if tx.Error != nil {
-		return []models.Dataset{}, h.errorTransformer.ToDataCatalogError(tx.Error)
+// Update the metadata of a Dataset model and synchronize its partition keys with the given ones. Partition keys which
+// are no longer part of the model are deleted, new partition keys are created. The indexed metadata key/value pairs
+// are replaced if the model contains them.
+func (h *dataSetRepo) Update(ctx context.Context, in models.Dataset) error {
+	ctx, span := startSpan(ctx, "dataSetRepo.Update")
+	defer span.End()
+
+	timer := h.repoMetrics.UpdateDuration.Start(ctx)
+	defer timer.Stop()
+
+	// the dataset is updated within the transaction of the context, if any, e.g. to keep it locked while updating it
+	err := getDB(ctx, h.db).Transaction(func(tx *gorm.DB) error {
+		if err := setStatementTimeout(tx, h.statementTimeouts.Write); err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Dataset{DatasetKey: in.DatasetKey}).Updates(models.Dataset{SerializedMetadata: in.SerializedMetadata})
+		if res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return errors.GetMissingEntityError("Dataset", &idl_datacatalog.DatasetID{
+				Project: in.Project,
+				Domain:  in.Domain,
+				Name:    in.Name,
+				Version: in.Version,
+			})
+		}
+
+		partitionKeyNames := make([]string, len(in.PartitionKeys))
+		for i := range in.PartitionKeys {
+			partitionKeyNames[i] = in.PartitionKeys[i].Name
+			// ensure partition keys are associated with the correct dataset
+			in.PartitionKeys[i].DatasetUUID = in.UUID
+		}
+
+		// delete all removed partition keys, an empty NOT IN clause would not match any rows
+		deleteTx := tx.Where(&models.PartitionKey{DatasetUUID: in.UUID})
+		if len(partitionKeyNames) > 0 {
+			deleteTx = deleteTx.Where("name NOT IN ?", partitionKeyNames)
+		}
+		if err := deleteTx.Delete(&models.PartitionKey{}).Error; err != nil {
+			return err
+		}
+
+		if len(in.PartitionKeys) > 0 {
+			// create new partition keys, existing ones are left untouched to preserve their creation order
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(in.PartitionKeys).Error; err != nil {
+				return err
+			}
+		}
+
+		// a nil list of metadata pairs keeps the indexed metadata untouched, otherwise it is replaced as a whole
+		if in.MetadataPairs != nil {
+			if err := tx.Where(&models.DatasetMetadata{DatasetUUID: in.UUID}).Delete(&models.DatasetMetadata{}).Error; err != nil {
+				return err
+			}
+
+			for i := range in.MetadataPairs {
+				in.MetadataPairs[i].DatasetUUID = in.UUID
+			}
+			if len(in.MetadataPairs) > 0 {
+				if err := tx.Create(in.MetadataPairs).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return h.errorTransformer.ToDataCatalogError(err)
 	}
-	return datasets, nil
+
+	return nil
+}
+
+// List the distinct partition keys which are used by the artifacts of a dataset
+func (h *dataSetRepo) ListPartitionKeysInUse(ctx context.Context, in models.DatasetKey) ([]string, error) {
+	ctx, span := startSpan(ctx, "dataSetRepo.ListPartitionKeysInUse")
+	defer span.End()
+
+	timer := h.repoMetrics.ListDuration.Start(ctx)
+	defer timer.Stop()
+
+	partitionKeys := make([]string, 0)
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.List, func(db *gorm.DB) error {
+		return db.Model(&models.Partition{}).Where(&models.Partition{DatasetUUID: in.UUID}).Distinct().Pluck("key", &partitionKeys).Error
+	})
+	if err != nil {
+		return nil, h.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return partitionKeys, nil
+}
+
+// Compute the aggregated statistics of a dataset. The dataset key must contain the UUID of the dataset.
+func (h *dataSetRepo) GetStats(ctx context.Context, in models.DatasetKey) (models.DatasetStats, error) {
+	ctx, span := startSpan(ctx, "dataSetRepo.GetStats")
+	defer span.End()
+
+	timer := h.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	var artifactStats struct {
+		ArtifactCount   int64
+		OldestCreatedAt *time.Time
+		NewestCreatedAt *time.Time
+	}
+	var tagCount int64
+	var storedBytes int64
+	var partitionStats []struct {
+		Key        string
+		ValueCount int64
+	}
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.List, func(db *gorm.DB) error {
+		result := db.Model(&models.Artifact{}).
+			Select("COUNT(*) AS artifact_count, MIN(created_at) AS oldest_created_at, MAX(created_at) AS newest_created_at").
+			Where(&models.Artifact{DatasetUUID: in.UUID}).
+			Scan(&artifactStats)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = db.Model(&models.Tag{}).Where(&models.Tag{DatasetUUID: in.UUID}).Count(&tagCount)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = db.Model(&models.ArtifactData{}).Select("COALESCE(SUM(size), 0)").Where(&models.ArtifactData{
+			ArtifactKey: models.ArtifactKey{
+				DatasetProject: in.Project,
+				DatasetName:    in.Name,
+				DatasetDomain:  in.Domain,
+				DatasetVersion: in.Version,
+			},
+		}).Scan(&storedBytes)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return db.Model(&models.Partition{}).
+			Select("key, COUNT(DISTINCT value) AS value_count").
+			Where(&models.Partition{DatasetUUID: in.UUID}).
+			Group("key").
+			Scan(&partitionStats).Error
+	})
+	if err != nil {
+		return models.DatasetStats{}, h.errorTransformer.ToDataCatalogError(err)
+	}
+
+	partitionValueCounts := make(map[string]int64, len(partitionStats))
+	for _, partitionStat := range partitionStats {
+		partitionValueCounts[partitionStat.Key] = partitionStat.ValueCount
+	}
+
+	return models.DatasetStats{
+		ArtifactCount:           artifactStats.ArtifactCount,
+		TagCount:                tagCount,
+		PartitionValueCounts:    partitionValueCounts,
+		OldestArtifactCreatedAt: artifactStats.OldestCreatedAt,
+		NewestArtifactCreatedAt: artifactStats.NewestCreatedAt,
+		StoredBytes:             storedBytes,
+	}, nil
 }
@@ -102,7 +102,7 @@ func TestCreateDatasetNoPartitions(t *testing.T) {
 
 	dataset.PartitionKeys = nil
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), dataset)
 	assert.NoError(t, err)
 	assert.True(t, datasetCreated)
@@ -138,7 +138,7 @@ func TestCreateDataset(t *testing.T) {
 		},
 	)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), getTestDataset())
 	assert.NoError(t, err)
 	assert.True(t, datasetCreated)
@@ -171,7 +171,7 @@ func TestGetDataset(t *testing.T) {
 	expectedPartitionKeyResponse = append(expectedPartitionKeyResponse, samplePartitionKey, samplePartitionKey)
 
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1 ORDER BY partition_keys.created_at ASC`).WithReply(expectedPartitionKeyResponse)
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	actualDataset, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.NoError(t, err)
 	assert.Equal(t, dataset.Project, actualDataset.Project)
@@ -182,6 +182,24 @@ func TestGetDataset(t *testing.T) {
 	assert.Len(t, actualDataset.PartitionKeys, 2)
 }
 
+func TestGetDatasetForUpdate(t *testing.T) {
+	dataset := getTestDataset()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	// the dataset is locked until the transaction ends
+	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets" WHERE "datasets"."project" = $1 AND "datasets"."name" = $2 AND "datasets"."domain" = $3 AND "datasets"."version" = $4 AND "datasets"."uuid" = $5 ORDER BY "datasets"."created_at" LIMIT 1 FOR UPDATE`).WithReply(getDBDatasetResponse(dataset))
+	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1 ORDER BY partition_keys.created_at ASC`).WithReply(getDBPartitionKeysResponse([]models.Dataset{dataset}))
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	actualDataset, err := datasetRepo.GetForUpdate(context.Background(), dataset.DatasetKey)
+	assert.NoError(t, err)
+	assert.Equal(t, dataset.Project, actualDataset.Project)
+	assert.Equal(t, getDatasetUUID(), actualDataset.UUID)
+	assert.Len(t, actualDataset.PartitionKeys, 1)
+}
+
 func TestGetDatasetWithUUID(t *testing.T) {
 	dataset := models.Dataset{
 		DatasetKey: models.DatasetKey{
@@ -203,7 +221,7 @@ func TestGetDatasetWithUUID(t *testing.T) {
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets" WHERE "datasets"."uuid" = $1 ORDER BY "datasets"."created_at" LIMIT 1%!(EXTRA string=test-uuid)`).WithReply(expectedResponse)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	actualDataset, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.NoError(t, err)
 	assert.Equal(t, dataset.Project, actualDataset.Project)
@@ -226,7 +244,7 @@ func TestGetDatasetNotFound(t *testing.T) {
 	// Only match on queries that append expected filters
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "datasets"  WHERE "datasets"."deleted_at" IS NULL AND (("datasets"."project" = testProject) AND ("datasets"."name" = testName) AND ("datasets"."domain" = testDomain) AND ("datasets"."version" = testVersion)) ORDER BY "datasets"."id" ASC LIMIT 1`).WithReply(nil)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	_, err := datasetRepo.Get(context.Background(), dataset.DatasetKey)
 	assert.Error(t, err)
 	notFoundErr, ok := err.(datacatalog_error.DataCatalogError)
@@ -244,7 +262,7 @@ func TestCreateDatasetAlreadyExists(t *testing.T) {
 		getAlreadyExistsErr(),
 	)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := datasetRepo.Create(context.Background(), getTestDataset())
 	assert.Error(t, err)
 	dcErr, ok := err.(datacatalog_error.DataCatalogError)
@@ -265,7 +283,7 @@ func TestListDatasets(t *testing.T) {
 
 	expectedPartitionKeyResponse := getDBPartitionKeysResponse([]models.Dataset{dataset})
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1`).WithReply(expectedPartitionKeyResponse)
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		Limit: 10,
 	}
@@ -294,7 +312,7 @@ func TestListDatasetWithFilter(t *testing.T) {
 	expectedPartitionKeyResponse := getDBPartitionKeysResponse([]models.Dataset{dataset})
 	GlobalMock.NewMock().WithQuery(`SELECT * FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1%!(EXTRA string=test-uuid)`).WithReply(expectedPartitionKeyResponse)
 
-	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	listInput := models.ListModelsInput{
 		ModelFilters: []models.ModelFilter{
 			{
@@ -323,3 +341,209 @@ func TestListDatasetWithFilter(t *testing.T) {
 	assert.Len(t, datasets[0].PartitionKeys, 1)
 	assert.Equal(t, datasets[0].PartitionKeys[0].Name, "key1")
 }
+
+func TestUpdateDataset(t *testing.T) {
+	dataset := getTestDataset()
+	dataset.PartitionKeys = []models.PartitionKey{{Name: "key2"}, {Name: "key3"}}
+	datasetUpdated := false
+	partitionKeysDeleted := false
+	partitionKeysCreated := false
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "updated_at"=$1,"serialized_metadata"=$2 WHERE "project" = $3 AND "name" = $4 AND "domain" = $5 AND "version" = $6`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.EqualValues(t, dataset.SerializedMetadata, values[1].Value)
+			datasetUpdated = true
+		},
+	).WithRowsNum(1)
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1 AND name NOT IN ($2,$3)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.EqualValues(t, getDatasetUUID(), values[0].Value)
+			assert.EqualValues(t, "key2", values[1].Value)
+			assert.EqualValues(t, "key3", values[2].Value)
+			partitionKeysDeleted = true
+		},
+	)
+
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "partition_keys" ("created_at","updated_at","deleted_at","dataset_uuid","name") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10) ON CONFLICT DO NOTHING`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.EqualValues(t, getDatasetUUID(), values[3].Value)
+			assert.EqualValues(t, "key2", values[4].Value)
+			assert.EqualValues(t, getDatasetUUID(), values[8].Value)
+			assert.EqualValues(t, "key3", values[9].Value)
+			partitionKeysCreated = true
+		},
+	)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	err := datasetRepo.Update(context.Background(), dataset)
+	assert.NoError(t, err)
+	assert.True(t, datasetUpdated)
+	assert.True(t, partitionKeysDeleted)
+	assert.True(t, partitionKeysCreated)
+}
+
+func TestUpdateDatasetRemoveAllPartitionKeys(t *testing.T) {
+	dataset := getTestDataset()
+	dataset.PartitionKeys = nil
+	partitionKeysDeleted := false
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "updated_at"=$1,"serialized_metadata"=$2 WHERE "project" = $3 AND "name" = $4 AND "domain" = $5 AND "version" = $6`).WithRowsNum(1)
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "partition_keys" WHERE "partition_keys"."dataset_uuid" = $1`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			partitionKeysDeleted = true
+		},
+	)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	err := datasetRepo.Update(context.Background(), dataset)
+	assert.NoError(t, err)
+	assert.True(t, partitionKeysDeleted)
+}
+
+func TestUpdateDatasetMetadataPairs(t *testing.T) {
+	dataset := getTestDataset()
+	dataset.PartitionKeys = []models.PartitionKey{{Name: "key1"}}
+	dataset.MetadataPairs = []models.DatasetMetadata{{Key: "owner", Value: "team-a"}}
+	metadataDeleted := false
+	metadataCreated := false
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "updated_at"=$1,"serialized_metadata"=$2 WHERE "project" = $3 AND "name" = $4 AND "domain" = $5 AND "version" = $6`).WithRowsNum(1)
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "dataset_metadata" WHERE "dataset_metadata"."dataset_uuid" = $1`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.EqualValues(t, getDatasetUUID(), values[0].Value)
+			metadataDeleted = true
+		},
+	)
+
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "dataset_metadata" ("created_at","updated_at","deleted_at","dataset_uuid","key","value") VALUES ($1,$2,$3,$4,$5,$6)`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			assert.EqualValues(t, getDatasetUUID(), values[3].Value)
+			assert.EqualValues(t, "owner", values[4].Value)
+			assert.EqualValues(t, "team-a", values[5].Value)
+			metadataCreated = true
+		},
+	)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	err := datasetRepo.Update(context.Background(), dataset)
+	assert.NoError(t, err)
+	assert.True(t, metadataDeleted)
+	assert.True(t, metadataCreated)
+}
+
+func TestListDatasetWithMetadataFilter(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	dataset := getTestDataset()
+	dataset.UUID = getDatasetUUID()
+	expectedDatasetDBResponse := getDBDatasetResponse(dataset)
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT "datasets"."created_at","datasets"."updated_at","datasets"."deleted_at","datasets"."project","datasets"."name","datasets"."domain","datasets"."version","datasets"."uuid","datasets"."serialized_metadata" FROM "datasets" JOIN dataset_metadata dataset_metadata0 ON datasets.uuid = dataset_metadata0.dataset_uuid WHERE dataset_metadata0.key = $1 AND dataset_metadata0.value = $2 LIMIT 10`).WithReply(expectedDatasetDBResponse)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	listInput := models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{
+				Entity:        common.DatasetMetadata,
+				JoinCondition: NewGormJoinCondition(common.Dataset, common.DatasetMetadata),
+				ValueFilters: []models.ModelValueFilter{
+					NewGormValueFilter(common.Equal, "key", "owner"),
+					NewGormValueFilter(common.Equal, "value", "team-a"),
+				},
+			},
+		},
+		Limit: 10,
+	}
+	datasets, err := datasetRepo.List(context.Background(), listInput)
+	assert.NoError(t, err)
+	assert.Len(t, datasets, 1)
+	assert.Equal(t, dataset.Name, datasets[0].Name)
+}
+
+func TestUpdateDatasetNotFound(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`UPDATE "datasets" SET "updated_at"=$1,"serialized_metadata"=$2 WHERE "project" = $3 AND "name" = $4 AND "domain" = $5 AND "version" = $6`).WithRowsNum(0)
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	err := datasetRepo.Update(context.Background(), getTestDataset())
+	assert.Error(t, err)
+	dcErr, ok := err.(datacatalog_error.DataCatalogError)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, dcErr.Code())
+}
+
+func TestListPartitionKeysInUse(t *testing.T) {
+	dataset := getTestDataset()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT DISTINCT "key" FROM "partitions" WHERE "partitions"."dataset_uuid" = $1%!(EXTRA string=test-uuid)`).WithReply(
+		[]map[string]interface{}{{"key": "key1"}, {"key": "key2"}})
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	partitionKeys, err := datasetRepo.ListPartitionKeysInUse(context.Background(), dataset.DatasetKey)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, partitionKeys)
+}
+
+func TestGetDatasetStats(t *testing.T) {
+	dataset := getTestDataset()
+	oldest := time.Unix(100, 0)
+	newest := time.Unix(200, 0)
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT COUNT(*) AS artifact_count, MIN(created_at) AS oldest_created_at, MAX(created_at) AS newest_created_at FROM "artifacts" WHERE "artifacts"."dataset_uuid" = $1`).WithReply(
+		[]map[string]interface{}{{"artifact_count": 3, "oldest_created_at": oldest, "newest_created_at": newest}})
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT count(*) FROM "tags" WHERE "tags"."dataset_uuid" = $1`).WithReply(
+		[]map[string]interface{}{{"count": 2}})
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT COALESCE(SUM(size), 0) FROM "artifact_data" WHERE "artifact_data"."dataset_project" = $1 AND "artifact_data"."dataset_name" = $2 AND "artifact_data"."dataset_domain" = $3 AND "artifact_data"."dataset_version" = $4`).WithReply(
+		[]map[string]interface{}{{"coalesce": 1024}})
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT key, COUNT(DISTINCT value) AS value_count FROM "partitions" WHERE "partitions"."dataset_uuid" = $1 GROUP BY "key"`).WithReply(
+		[]map[string]interface{}{{"key": "key1", "value_count": 3}, {"key": "key2", "value_count": 1}})
+
+	datasetRepo := NewDatasetRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+	stats, err := datasetRepo.GetStats(context.Background(), dataset.DatasetKey)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stats.ArtifactCount)
+	assert.Equal(t, int64(2), stats.TagCount)
+	assert.Equal(t, map[string]int64{"key1": 3, "key2": 1}, stats.PartitionValueCounts)
+	assert.Equal(t, oldest.Unix(), stats.OldestArtifactCreatedAt.Unix())
+	assert.Equal(t, newest.Unix(), stats.NewestArtifactCreatedAt.Unix())
+	assert.Equal(t, int64(1024), stats.StoredBytes)
+}
@@ -10,7 +10,9 @@ import (
 
 // String formats for various GORM expression queries
 const (
-	equalQuery = "%s.%s = ?"
+	equalQuery              = "%s.%s = ?"
+	greaterThanOrEqualQuery = "%s.%s >= ?"
+	lessThanQuery           = "%s.%s < ?"
 )
 
 type gormValueFilterImpl struct {
@@ -27,6 +29,16 @@ func (g *gormValueFilterImpl) GetDBQueryExpression(tableName string) (models.DBQ
 			Query: fmt.Sprintf(equalQuery, tableName, g.field),
 			Args:  g.value,
 		}, nil
+	case common.GreaterThanOrEqual:
+		return models.DBQueryExpr{
+			Query: fmt.Sprintf(greaterThanOrEqualQuery, tableName, g.field),
+			Args:  g.value,
+		}, nil
+	case common.LessThan:
+		return models.DBQueryExpr{
+			Query: fmt.Sprintf(lessThanQuery, tableName, g.field),
+			Args:  g.value,
+		}, nil
 	}
 	return models.DBQueryExpr{}, errors.GetUnsupportedFilterExpressionErr(g.comparisonOperator)
 }
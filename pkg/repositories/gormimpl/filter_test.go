@@ -20,3 +20,17 @@ func TestGormValueFilterInvalidOperator(t *testing.T) {
 	_, err := filter.GetDBQueryExpression("partitions")
 	assert.Error(t, err)
 }
+
+func TestGormValueFilterRange(t *testing.T) {
+	filter := NewGormValueFilter(common.GreaterThanOrEqual, "created_at", "start")
+	expression, err := filter.GetDBQueryExpression("artifacts")
+	assert.NoError(t, err)
+	assert.Equal(t, "artifacts.created_at >= ?", expression.Query)
+	assert.Equal(t, "start", expression.Args)
+
+	filter = NewGormValueFilter(common.LessThan, "created_at", "end")
+	expression, err = filter.GetDBQueryExpression("artifacts")
+	assert.NoError(t, err)
+	assert.Equal(t, "artifacts.created_at < ?", expression.Query)
+	assert.Equal(t, "end", expression.Args)
+}
@@ -23,6 +23,11 @@ var joinFieldNames = map[common.Entity]map[common.Entity]JoinOnMap{
 	common.Artifact: {
 		common.Partition: JoinOnMap{"artifact_id": "artifact_id"},
 		common.Tag:       JoinOnMap{"artifact_id": "artifact_id"},
+
+		common.ArtifactMetadata: JoinOnMap{"artifact_id": "artifact_id"},
+	},
+	common.Dataset: {
+		common.DatasetMetadata: JoinOnMap{"uuid": "dataset_uuid"},
 	},
 }
 
@@ -21,6 +21,11 @@ var entityToModel = map[common.Entity]interface{}{
 	common.Dataset:   models.Dataset{},
 	common.Partition: models.Partition{},
 	common.Tag:       models.Tag{},
+
+	common.Reservation: models.Reservation{},
+
+	common.ArtifactMetadata: models.ArtifactMetadata{},
+	common.DatasetMetadata:  models.DatasetMetadata{},
 }
 
 func getTableName(tx *gorm.DB, model interface{}) (string, error) {
@@ -2,7 +2,9 @@ package gormimpl
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/flyteorg/datacatalog/pkg/common"
 	datacatalog_error "github.com/flyteorg/datacatalog/pkg/errors"
 	"google.golang.org/grpc/codes"
 
@@ -18,31 +20,48 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// The fields identifying the reservations of a key in all its slots. Conditions on structs ignore zero values unless
+// their fields are named, which would match the reservations of all datasets and tags.
+var reservationKeyFields = []interface{}{"DatasetProject", "DatasetName", "DatasetDomain", "DatasetVersion", "TagName"}
+
+// FencingTokenSequence is the Postgres sequence the fencing tokens of reservations are taken from
+const FencingTokenSequence = "reservation_fencing_tokens"
+
 type reservationRepo struct {
-	db               *gorm.DB
-	repoMetrics      gormMetrics
-	errorTransformer errors2.ErrorTransformer
+	db                *gorm.DB
+	repoMetrics       gormMetrics
+	errorTransformer  errors2.ErrorTransformer
+	statementTimeouts StatementTimeouts
 }
 
 // NewReservationRepo creates a reservationRepo
-func NewReservationRepo(db *gorm.DB, errorTransformer errors2.ErrorTransformer, scope promutils.Scope) interfaces.ReservationRepo {
+func NewReservationRepo(db *gorm.DB, errorTransformer errors2.ErrorTransformer, statementTimeouts StatementTimeouts, scope promutils.Scope) interfaces.ReservationRepo {
 	return &reservationRepo{
-		db:               db,
-		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		db:                db,
+		errorTransformer:  errorTransformer,
+		statementTimeouts: statementTimeouts,
+		repoMetrics:       newGormMetrics(scope),
 	}
 }
 
 func (r *reservationRepo) Create(ctx context.Context, reservation models.Reservation, now time.Time) error {
+	ctx, span := startSpan(ctx, "reservationRepo.Create")
+	defer span.End()
+
 	timer := r.repoMetrics.CreateDuration.Start(ctx)
 	defer timer.Stop()
 
-	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reservation)
-	if result.Error != nil {
-		return r.errorTransformer.ToDataCatalogError(result.Error)
+	var rowsAffected int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reservation)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
 	}
 
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return datacatalog_error.NewDataCatalogError(codes.FailedPrecondition, errors2.AlreadyExists)
 	}
 
@@ -50,20 +69,28 @@ func (r *reservationRepo) Create(ctx context.Context, reservation models.Reserva
 }
 
 func (r *reservationRepo) Delete(ctx context.Context, reservationKey models.ReservationKey, ownerID string) error {
+	ctx, span := startSpan(ctx, "reservationRepo.Delete")
+	defer span.End()
+
 	timer := r.repoMetrics.DeleteDuration.Start(ctx)
 	defer timer.Stop()
 
 	var reservation models.Reservation
 
-	result := r.db.Where(&models.Reservation{
-		ReservationKey: reservationKey,
-		OwnerID:        ownerID,
-	}).Delete(&reservation)
-	if result.Error != nil {
-		return r.errorTransformer.ToDataCatalogError(result.Error)
+	var rowsAffected int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		result := db.Where(&models.Reservation{
+			ReservationKey: reservationKey,
+			OwnerID:        ownerID,
+		}, append(reservationKeyFields, "OwnerID")...).Delete(&reservation)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
 	}
 
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return errors2.GetMissingEntityError("Reservation",
 			&datacatalog.ReservationID{
 				DatasetId: &datacatalog.DatasetID{
@@ -80,36 +107,270 @@ func (r *reservationRepo) Delete(ctx context.Context, reservationKey models.Rese
 }
 
 func (r *reservationRepo) Get(ctx context.Context, reservationKey models.ReservationKey) (models.Reservation, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.Get")
+	defer span.End()
+
 	timer := r.repoMetrics.GetDuration.Start(ctx)
 	defer timer.Stop()
 
 	var reservation models.Reservation
 
-	result := r.db.Where(&models.Reservation{
-		ReservationKey: reservationKey,
-	}).Take(&reservation)
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Where(&models.Reservation{
+			ReservationKey: reservationKey,
+		}, append(reservationKeyFields, "Slot")...).Take(&reservation).Error
+	})
 
-	if result.Error != nil {
-		return reservation, r.errorTransformer.ToDataCatalogError(result.Error)
+	if err != nil {
+		return reservation, r.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return reservation, nil
 }
 
+func (r *reservationRepo) GetSlots(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.GetSlots")
+	defer span.End()
+
+	timer := r.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	reservations := make([]models.Reservation, 0)
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Where(&models.Reservation{
+			ReservationKey: reservationKey,
+		}, reservationKeyFields...).Order("slot").Find(&reservations).Error
+	})
+	if err != nil {
+		return []models.Reservation{}, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return reservations, nil
+}
+
+func (r *reservationRepo) GetSlotsForUpdate(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.GetSlotsForUpdate")
+	defer span.End()
+
+	timer := r.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	reservations := make([]models.Reservation, 0)
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&models.Reservation{
+			ReservationKey: reservationKey,
+		}, reservationKeyFields...).Order("slot").Find(&reservations).Error
+	})
+	if err != nil {
+		return []models.Reservation{}, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return reservations, nil
+}
+
+func (r *reservationRepo) NextFencingToken(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.NextFencingToken")
+	defer span.End()
+
+	timer := r.repoMetrics.GetDuration.Start(ctx)
+	defer timer.Stop()
+
+	var token int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Raw(fmt.Sprintf("SELECT nextval('%s')", FencingTokenSequence)).Scan(&token).Error
+	})
+	if err != nil {
+		return 0, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return token, nil
+}
+
 func (r *reservationRepo) Update(ctx context.Context, reservation models.Reservation, now time.Time) error {
+	ctx, span := startSpan(ctx, "reservationRepo.Update")
+	defer span.End()
+
 	timer := r.repoMetrics.UpdateDuration.Start(ctx)
 	defer timer.Stop()
 
-	result := r.db.Model(&models.Reservation{
-		ReservationKey: reservation.ReservationKey,
-	}).Where("expires_at<=? OR owner_id=?", now, reservation.OwnerID).Updates(reservation)
-	if result.Error != nil {
-		return r.errorTransformer.ToDataCatalogError(result.Error)
+	var rowsAffected int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		result := db.Model(&models.Reservation{}).Where(&models.Reservation{
+			ReservationKey: reservation.ReservationKey,
+		}, append(reservationKeyFields, "Slot")...).Where("expires_at<=? OR owner_id=?", now, reservation.OwnerID).Updates(reservation)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
 	}
 
-	if result.RowsAffected == 0 {
+	if rowsAffected == 0 {
 		return datacatalog_error.NewDataCatalogError(codes.FailedPrecondition, errors2.AlreadyExists)
 	}
 
 	return nil
 }
+
+func (r *reservationRepo) List(ctx context.Context, in models.ListModelsInput) ([]models.Reservation, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.List")
+	defer span.End()
+
+	timer := r.repoMetrics.ListDuration.Start(ctx)
+	defer timer.Stop()
+
+	reservations := make([]models.Reservation, 0)
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.List, func(db *gorm.DB) error {
+		// apply filters and pagination
+		tx, err := applyListModelsInput(db, common.Reservation, in)
+		if err != nil {
+			return err
+		} else if tx.Error != nil {
+			return tx.Error
+		}
+
+		return tx.Find(&reservations).Error
+	})
+	if err != nil {
+		return []models.Reservation{}, r.errorTransformer.ToDataCatalogError(err)
+	}
+	return reservations, nil
+}
+
+func (r *reservationRepo) DeleteExpired(ctx context.Context, expiredBefore time.Time, limit int) (int64, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.DeleteExpired")
+	defer span.End()
+
+	timer := r.repoMetrics.DeleteDuration.Start(ctx)
+	defer timer.Stop()
+
+	var rowsAffected int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		// Postgres does not support limiting deletes, so the expired reservations are selected by their primary key first
+		expiredKeys := db.Model(&models.Reservation{}).
+			Select("dataset_project", "dataset_name", "dataset_domain", "dataset_version", "tag_name", "slot").
+			Where("expires_at < ?", expiredBefore).
+			Limit(limit)
+
+		// the expiration is checked again as the reservation may have been taken over in the meantime
+		result := db.Where("(dataset_project, dataset_name, dataset_domain, dataset_version, tag_name, slot) IN (?)", expiredKeys).
+			Where("expires_at < ?", expiredBefore).
+			Delete(&models.Reservation{})
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return rowsAffected, nil
+}
+
+func (r *reservationRepo) CreateForceRelease(ctx context.Context, forceRelease models.ReservationForceRelease) error {
+	ctx, span := startSpan(ctx, "reservationRepo.CreateForceRelease")
+	defer span.End()
+
+	timer := r.repoMetrics.CreateDuration.Start(ctx)
+	defer timer.Stop()
+
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Create(&forceRelease).Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return nil
+}
+
+func (r *reservationRepo) Enqueue(ctx context.Context, waiter models.ReservationWaiter) error {
+	ctx, span := startSpan(ctx, "reservationRepo.Enqueue")
+	defer span.End()
+
+	timer := r.repoMetrics.CreateDuration.Start(ctx)
+	defer timer.Stop()
+
+	// queued owners keep their place, only their expiration is extended
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "dataset_project"}, {Name: "dataset_name"}, {Name: "dataset_domain"}, {Name: "dataset_version"},
+				{Name: "tag_name"}, {Name: "slot"}, {Name: "owner_id"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "expires_at"}),
+		}).Create(&waiter).Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return nil
+}
+
+func (r *reservationRepo) ListWaiters(ctx context.Context, reservationKey models.ReservationKey, now time.Time) ([]models.ReservationWaiter, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.ListWaiters")
+	defer span.End()
+
+	timer := r.repoMetrics.ListDuration.Start(ctx)
+	defer timer.Stop()
+
+	waiters := make([]models.ReservationWaiter, 0)
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.List, func(db *gorm.DB) error {
+		return db.Where(&models.ReservationWaiter{
+			ReservationKey: reservationKey,
+		}, reservationKeyFields...).Where("expires_at > ?", now).Order("enqueued_at, owner_id").Find(&waiters).Error
+	})
+	if err != nil {
+		return []models.ReservationWaiter{}, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return waiters, nil
+}
+
+func (r *reservationRepo) Dequeue(ctx context.Context, reservationKey models.ReservationKey, ownerID string) error {
+	ctx, span := startSpan(ctx, "reservationRepo.Dequeue")
+	defer span.End()
+
+	timer := r.repoMetrics.DeleteDuration.Start(ctx)
+	defer timer.Stop()
+
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Where(&models.ReservationWaiter{
+			ReservationKey: reservationKey,
+			OwnerID:        ownerID,
+		}, append(reservationKeyFields, "OwnerID")...).Delete(&models.ReservationWaiter{}).Error
+	})
+	if err != nil {
+		return r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return nil
+}
+
+func (r *reservationRepo) DeleteExpiredWaiters(ctx context.Context, expiredBefore time.Time, limit int) (int64, error) {
+	ctx, span := startSpan(ctx, "reservationRepo.DeleteExpiredWaiters")
+	defer span.End()
+
+	timer := r.repoMetrics.DeleteDuration.Start(ctx)
+	defer timer.Stop()
+
+	var rowsAffected int64
+	err := withStatementTimeout(ctx, r.db, r.statementTimeouts.Write, func(db *gorm.DB) error {
+		expiredKeys := db.Model(&models.ReservationWaiter{}).
+			Select("dataset_project", "dataset_name", "dataset_domain", "dataset_version", "tag_name", "slot", "owner_id").
+			Where("expires_at < ?", expiredBefore).
+			Limit(limit)
+
+		// the expiration is checked again as the owner may have requested the reservation in the meantime
+		result := db.Where("(dataset_project, dataset_name, dataset_domain, dataset_version, tag_name, slot, owner_id) IN (?)", expiredKeys).
+			Where("expires_at < ?", expiredBefore).
+			Delete(&models.ReservationWaiter{})
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, r.errorTransformer.ToDataCatalogError(err)
+	}
+
+	return rowsAffected, nil
+}
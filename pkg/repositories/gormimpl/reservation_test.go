@@ -3,13 +3,16 @@ package gormimpl
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"testing"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/flyteorg/datacatalog/pkg/common"
 	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
 	apiErrors "github.com/flyteorg/datacatalog/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -27,7 +30,7 @@ func TestCreate(t *testing.T) {
 	expectedReservation := GetReservation()
 
 	GlobalMock.NewMock().WithQuery(
-		`INSERT INTO "reservations" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","owner_id","expires_at","serialized_metadata") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) ON CONFLICT DO NOTHING`,
+		`INSERT INTO "reservations" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot","owner_id","expires_at","serialized_metadata","fencing_token","acquired_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ON CONFLICT DO NOTHING`,
 	).WithRowsNum(1)
 
 	reservationRepo := getReservationRepo(t)
@@ -74,7 +77,7 @@ func TestGet(t *testing.T) {
 	GlobalMock.Logging = true
 
 	GlobalMock.NewMock().WithQuery(
-		`SELECT * FROM "reservations" WHERE "reservations"."dataset_project" = $1 AND "reservations"."dataset_name" = $2 AND "reservations"."dataset_domain" = $3 AND "reservations"."dataset_version" = $4 AND "reservations"."tag_name" = $5 LIMIT 1%!!(string=testTag)!(string=testVersion)!(string=testDomain)!(string=testDataset)(EXTRA string=testProject)`,
+		`SELECT * FROM "reservations" WHERE "reservations"."dataset_project" = $1 AND "reservations"."dataset_name" = $2 AND "reservations"."dataset_domain" = $3 AND "reservations"."dataset_version" = $4 AND "reservations"."tag_name" = $5 AND "reservations"."slot" = $6 LIMIT 1%!!(int64=0)!(string=testTag)!(string=testVersion)!(string=testDomain)!(string=testDataset)(EXTRA string=testProject)`,
 	).WithReply(getDBResponse(expectedReservation))
 
 	reservationRepo := getReservationRepo(t)
@@ -105,13 +108,66 @@ func TestGetNotFound(t *testing.T) {
 
 }
 
+func TestGetSlots(t *testing.T) {
+	expectedReservation := GetReservation()
+	slotReservation := GetReservation()
+	slotReservation.Slot = 1
+	slotReservation.OwnerID = "robin"
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "reservations" WHERE "reservations"."dataset_project" = $1 AND "reservations"."dataset_name" = $2 AND "reservations"."dataset_domain" = $3 AND "reservations"."dataset_version" = $4 AND "reservations"."tag_name" = $5 ORDER BY slot`,
+	).WithReply(append(getDBResponse(expectedReservation), getDBResponse(slotReservation)...))
+
+	reservationRepo := getReservationRepo(t)
+	reservations, err := reservationRepo.GetSlots(context.Background(), slotReservation.ReservationKey)
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 2)
+	assert.Equal(t, 0, reservations[0].Slot)
+	assert.Equal(t, 1, reservations[1].Slot)
+	assert.Equal(t, "robin", reservations[1].OwnerID)
+}
+
+func TestGetSlotsForUpdate(t *testing.T) {
+	expectedReservation := GetReservation()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "reservations" WHERE "reservations"."dataset_project" = $1 AND "reservations"."dataset_name" = $2 AND "reservations"."dataset_domain" = $3 AND "reservations"."dataset_version" = $4 AND "reservations"."tag_name" = $5 ORDER BY slot FOR UPDATE`,
+	).WithReply(getDBResponse(expectedReservation))
+
+	reservationRepo := getReservationRepo(t)
+	reservations, err := reservationRepo.GetSlotsForUpdate(context.Background(), expectedReservation.ReservationKey)
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 1)
+	assert.Equal(t, expectedReservation.OwnerID, reservations[0].OwnerID)
+}
+
+func TestNextFencingToken(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT nextval('reservation_fencing_tokens')`,
+	).WithReply([]map[string]interface{}{{"nextval": int64(42)}})
+
+	reservationRepo := getReservationRepo(t)
+	token, err := reservationRepo.NextFencingToken(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), token)
+}
+
 func TestUpdate(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
 	expectedReservation := GetReservation()
 
 	GlobalMock.NewMock().WithQuery(
-		`UPDATE "reservations" SET "updated_at"=$1,"dataset_project"=$2,"dataset_name"=$3,"dataset_domain"=$4,"dataset_version"=$5,"tag_name"=$6,"owner_id"=$7,"expires_at"=$8 WHERE (expires_at<=$9 OR owner_id=$10) AND "dataset_project" = $11 AND "dataset_name" = $12 AND "dataset_domain" = $13 AND "dataset_version" = $14 AND "tag_name" = $15`,
+		`UPDATE "reservations" SET "updated_at"=$1,"dataset_project"=$2,"dataset_name"=$3,"dataset_domain"=$4,"dataset_version"=$5,"tag_name"=$6,"owner_id"=$7,"expires_at"=$8,"fencing_token"=$9 WHERE "reservations"."dataset_project" = $10 AND "reservations"."dataset_name" = $11 AND "reservations"."dataset_domain" = $12 AND "reservations"."dataset_version" = $13 AND "reservations"."tag_name" = $14 AND "reservations"."slot" = $15 AND (expires_at<=$16 OR owner_id=$17)`,
 	).WithRowsNum(1)
 
 	reservationRepo := getReservationRepo(t)
@@ -126,7 +182,7 @@ func TestUpdateFailure(t *testing.T) {
 	expectedReservation := GetReservation()
 
 	GlobalMock.NewMock().WithQuery(
-		`UPDATE "reservations" SET "updated_at"=$1,"dataset_project"=$2,"dataset_name"=$3,"dataset_domain"=$4,"dataset_version"=$5,"tag_name"=$6,"owner_id"=$7,"expires_at"=$8 WHERE (expires_at<=$9 OR owner_id=$10) AND "dataset_project" = $11 AND "dataset_name" = $12 AND "dataset_domain" = $13 AND "dataset_version" = $14 AND "tag_name" = $15`,
+		`UPDATE "reservations" SET "updated_at"=$1,"dataset_project"=$2,"dataset_name"=$3,"dataset_domain"=$4,"dataset_version"=$5,"tag_name"=$6,"owner_id"=$7,"expires_at"=$8,"fencing_token"=$9 WHERE "reservations"."dataset_project" = $10 AND "reservations"."dataset_name" = $11 AND "reservations"."dataset_domain" = $12 AND "reservations"."dataset_version" = $13 AND "reservations"."tag_name" = $14 AND "reservations"."slot" = $15 AND (expires_at<=$16 OR owner_id=$17)`,
 	).WithRowsNum(0)
 
 	reservationRepo := getReservationRepo(t)
@@ -146,7 +202,7 @@ func getReservationRepo(t *testing.T) interfaces.ReservationRepo {
 		t.Fatalf("Failed to open mock db with err %v", err)
 	}
 
-	return NewReservationRepo(db, errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	return NewReservationRepo(db, errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 }
 
 func getDBResponse(reservation models.Reservation) []map[string]interface{} {
@@ -157,6 +213,7 @@ func getDBResponse(reservation models.Reservation) []map[string]interface{} {
 			"dataset_domain":  reservation.DatasetDomain,
 			"dataset_version": reservation.DatasetVersion,
 			"tag_name":        reservation.TagName,
+			"slot":            reservation.Slot,
 			"owner_id":        reservation.OwnerID,
 			"expires_at":      reservation.ExpiresAt,
 		},
@@ -178,6 +235,161 @@ func GetReservation() models.Reservation {
 		ReservationKey: GetReservationKey(),
 		OwnerID:        "batman",
 		ExpiresAt:      time.Unix(1, 1),
+		FencingToken:   1,
 	}
 	return reservation
 }
+
+func TestListReservations(t *testing.T) {
+	expectedReservation := GetReservation()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "reservations" WHERE reservations.dataset_project = $1 AND reservations.owner_id = $2 AND reservations.expires_at < $3 ORDER BY reservations.created_at desc LIMIT 10`,
+	).WithReply(getDBResponse(expectedReservation))
+
+	reservationRepo := getReservationRepo(t)
+	reservations, err := reservationRepo.List(context.Background(), models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{
+				Entity: common.Reservation,
+				ValueFilters: []models.ModelValueFilter{
+					NewGormValueFilter(common.Equal, "dataset_project", "testProject"),
+					NewGormValueFilter(common.Equal, "owner_id", "batman"),
+					NewGormValueFilter(common.LessThan, "expires_at", time.Unix(2, 0)),
+				},
+			},
+		},
+		Limit:         10,
+		SortParameter: NewGormSortParameter(datacatalog.PaginationOptions_CREATION_TIME, datacatalog.PaginationOptions_DESCENDING),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 1)
+	assert.Equal(t, expectedReservation.OwnerID, reservations[0].OwnerID)
+	assert.Equal(t, expectedReservation.TagName, reservations[0].TagName)
+}
+
+func TestDeleteExpiredReservations(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "reservations" WHERE (dataset_project, dataset_name, dataset_domain, dataset_version, tag_name, slot) IN (SELECT "dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot" FROM "reservations" WHERE expires_at < $1 LIMIT 100) AND expires_at < $2`,
+	).WithRowsNum(2)
+
+	reservationRepo := getReservationRepo(t)
+	deleted, err := reservationRepo.DeleteExpired(context.Background(), time.Now(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
+
+func getReservationWaiter() models.ReservationWaiter {
+	return models.ReservationWaiter{
+		ReservationKey: GetReservationKey(),
+		OwnerID:        "robin",
+		EnqueuedAt:     time.Unix(1, 0),
+		ExpiresAt:      time.Unix(2, 0),
+	}
+}
+
+func TestCreateForceRelease(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	created := false
+
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "reservation_force_releases" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot","released_at","released_by","reason","owner_id","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`,
+	).WithCallback(func(s string, values []driver.NamedValue) {
+		created = true
+	}).WithRowsNum(1)
+
+	reservation := GetReservation()
+	reservationRepo := getReservationRepo(t)
+	err := reservationRepo.CreateForceRelease(context.Background(), models.ReservationForceRelease{
+		ReservationKey: reservation.ReservationKey,
+		ReleasedAt:     time.Now(),
+		ReleasedBy:     "admin",
+		OwnerID:        reservation.OwnerID,
+		ExpiresAt:      reservation.ExpiresAt,
+	})
+	assert.NoError(t, err)
+	assert.True(t, created)
+}
+
+func TestEnqueue(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	enqueued := false
+
+	GlobalMock.NewMock().WithQuery(
+		`INSERT INTO "reservation_waiters" ("created_at","updated_at","deleted_at","dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot","owner_id","enqueued_at","expires_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) ON CONFLICT ("dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot","owner_id") DO UPDATE SET "updated_at"="excluded"."updated_at","expires_at"="excluded"."expires_at"`,
+	).WithCallback(func(s string, values []driver.NamedValue) {
+		enqueued = true
+	}).WithRowsNum(1)
+
+	reservationRepo := getReservationRepo(t)
+	err := reservationRepo.Enqueue(context.Background(), getReservationWaiter())
+	assert.NoError(t, err)
+	assert.True(t, enqueued)
+}
+
+func TestListWaiters(t *testing.T) {
+	waiter := getReservationWaiter()
+
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`SELECT * FROM "reservation_waiters" WHERE "reservation_waiters"."dataset_project" = $1 AND "reservation_waiters"."dataset_name" = $2 AND "reservation_waiters"."dataset_domain" = $3 AND "reservation_waiters"."dataset_version" = $4 AND "reservation_waiters"."tag_name" = $5 AND expires_at > $6 ORDER BY enqueued_at, owner_id`,
+	).WithReply([]map[string]interface{}{
+		{
+			"dataset_project": waiter.DatasetProject,
+			"dataset_name":    waiter.DatasetName,
+			"dataset_domain":  waiter.DatasetDomain,
+			"dataset_version": waiter.DatasetVersion,
+			"tag_name":        waiter.TagName,
+			"owner_id":        waiter.OwnerID,
+			"enqueued_at":     waiter.EnqueuedAt,
+			"expires_at":      waiter.ExpiresAt,
+		},
+	})
+
+	reservationRepo := getReservationRepo(t)
+	waiters, err := reservationRepo.ListWaiters(context.Background(), waiter.ReservationKey, time.Unix(1, 0))
+	assert.NoError(t, err)
+	assert.Len(t, waiters, 1)
+	assert.Equal(t, waiter.OwnerID, waiters[0].OwnerID)
+}
+
+func TestDequeue(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+	dequeued := false
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "reservation_waiters" WHERE "reservation_waiters"."dataset_project" = $1 AND "reservation_waiters"."dataset_name" = $2 AND "reservation_waiters"."dataset_domain" = $3 AND "reservation_waiters"."dataset_version" = $4 AND "reservation_waiters"."tag_name" = $5 AND "reservation_waiters"."owner_id" = $6`,
+	).WithCallback(func(s string, values []driver.NamedValue) {
+		dequeued = true
+	}).WithRowsNum(0)
+
+	reservationRepo := getReservationRepo(t)
+	err := reservationRepo.Dequeue(context.Background(), GetReservationKey(), "robin")
+	assert.NoError(t, err)
+	assert.True(t, dequeued)
+}
+
+func TestDeleteExpiredWaiters(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	GlobalMock.NewMock().WithQuery(
+		`DELETE FROM "reservation_waiters" WHERE (dataset_project, dataset_name, dataset_domain, dataset_version, tag_name, slot, owner_id) IN (SELECT "dataset_project","dataset_name","dataset_domain","dataset_version","tag_name","slot","owner_id" FROM "reservation_waiters" WHERE expires_at < $1 LIMIT 100) AND expires_at < $2`,
+	).WithRowsNum(3)
+
+	reservationRepo := getReservationRepo(t)
+	deleted, err := reservationRepo.DeleteExpiredWaiters(context.Background(), time.Now(), 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
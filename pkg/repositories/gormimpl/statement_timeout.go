@@ -0,0 +1,46 @@
+package gormimpl
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatementTimeouts bound how long Postgres executes the statements of every class of queries within transactions,
+// overriding the statement timeout of the connection. Setting them costs a round trip, so they are not set for queries
+// outside of transactions, which are bound by the statement timeout of the connection only.
+type StatementTimeouts struct {
+	// Queries getting a single entity, e.g. a tag with its artifact
+	Get time.Duration
+	// Queries listing entities or aggregating them
+	List time.Duration
+	// Queries creating, updating or deleting entities
+	Write time.Duration
+}
+
+// Set the statement timeout of the remaining queries of the transaction. set_config is equivalent to SET LOCAL, but
+// takes the timeout as a bound parameter.
+func setStatementTimeout(tx *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+	var setting string
+	return tx.Raw("SELECT set_config('statement_timeout', ?, true)", strconv.FormatInt(timeout.Milliseconds(), 10)).
+		Scan(&setting).Error
+}
+
+// Run the queries of fn with the statement timeout if the context is within a transaction. Otherwise no transaction
+// is started for the timeout, the queries are bound by the statement timeout of the connection.
+func withStatementTimeout(ctx context.Context, db *gorm.DB, timeout time.Duration, fn func(db *gorm.DB) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*gorm.DB); !ok {
+		return fn(getDB(ctx, db))
+	}
+
+	tx := getDB(ctx, db)
+	if err := setStatementTimeout(tx, timeout); err != nil {
+		return err
+	}
+	return fn(tx)
+}
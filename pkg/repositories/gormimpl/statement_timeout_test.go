@@ -0,0 +1,56 @@
+package gormimpl
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/datacatalog/pkg/repositories/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/utils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementTimeout(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	var statementTimeout string
+	GlobalMock.NewMock().WithQuery(`set_config('statement_timeout'`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			statementTimeout = values[0].Value.(string)
+		},
+	)
+
+	db := utils.GetDbForTest(t)
+	tagRepo := NewTagRepo(db, errors.NewPostgresErrorTransformer(),
+		StatementTimeouts{Get: time.Second, Write: 2 * time.Second}, promutils.NewTestScope())
+
+	t.Run("Set for the query class within transactions", func(t *testing.T) {
+		statementTimeout = ""
+		err := Transaction(context.Background(), db, func(ctx context.Context) error {
+			return tagRepo.Create(ctx, getTestTag())
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "2000", statementTimeout)
+	})
+
+	t.Run("Not set outside of transactions", func(t *testing.T) {
+		statementTimeout = ""
+		err := tagRepo.Create(context.Background(), getTestTag())
+		assert.NoError(t, err)
+		assert.Empty(t, statementTimeout)
+	})
+
+	t.Run("Not set if zero", func(t *testing.T) {
+		statementTimeout = ""
+		tagRepo := NewTagRepo(db, errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+		err := Transaction(context.Background(), db, func(ctx context.Context) error {
+			return tagRepo.Create(ctx, getTestTag())
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, statementTimeout)
+	})
+}
@@ -13,54 +13,65 @@ import (
 )
 
 type tagRepo struct {
-	db               *gorm.DB
-	errorTransformer errors.ErrorTransformer
-	repoMetrics      gormMetrics
+	db                *gorm.DB
+	errorTransformer  errors.ErrorTransformer
+	statementTimeouts StatementTimeouts
+	repoMetrics       gormMetrics
 }
 
-func NewTagRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.TagRepo {
+func NewTagRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, statementTimeouts StatementTimeouts, scope promutils.Scope) interfaces.TagRepo {
 	return &tagRepo{
-		db:               db,
-		errorTransformer: errorTransformer,
-		repoMetrics:      newGormMetrics(scope),
+		db:                db,
+		errorTransformer:  errorTransformer,
+		statementTimeouts: statementTimeouts,
+		repoMetrics:       newGormMetrics(scope),
 	}
 }
 
 func (h *tagRepo) Create(ctx context.Context, tag models.Tag) error {
+	ctx, span := startSpan(ctx, "tagRepo.Create")
+	defer span.End()
+
 	timer := h.repoMetrics.CreateDuration.Start(ctx)
 	defer timer.Stop()
 
-	db := h.db.Create(&tag)
-
-	if db.Error != nil {
-		return h.errorTransformer.ToDataCatalogError(db.Error)
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Write, func(db *gorm.DB) error {
+		return db.Create(&tag).Error
+	})
+	if err != nil {
+		return h.errorTransformer.ToDataCatalogError(err)
 	}
 	return nil
 }
 
 func (h *tagRepo) Get(ctx context.Context, in models.TagKey) (models.Tag, error) {
+	ctx, span := startSpan(ctx, "tagRepo.Get")
+	defer span.End()
+
 	timer := h.repoMetrics.GetDuration.Start(ctx)
 	defer timer.Stop()
 
 	var tag models.Tag
-	result := h.db.Preload("Artifact").
-		Preload("Artifact.ArtifactData").
-		Preload("Artifact.Partitions", func(db *gorm.DB) *gorm.DB {
-			return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
-		}).
-		Preload("Artifact.Tags").
-		Order("tags.created_at DESC").
-		First(&tag, &models.Tag{
-			TagKey: in,
-		})
+	err := withStatementTimeout(ctx, h.db, h.statementTimeouts.Get, func(db *gorm.DB) error {
+		return db.Preload("Artifact").
+			Preload("Artifact.ArtifactData").
+			Preload("Artifact.Partitions", func(db *gorm.DB) *gorm.DB {
+				return db.Order("partitions.created_at ASC") // preserve the order in which the partitions were created
+			}).
+			Preload("Artifact.Tags").
+			Order("tags.created_at DESC").
+			First(&tag, &models.Tag{
+				TagKey: in,
+			}).Error
+	})
 
-	if result.Error != nil {
-		if result.Error.Error() == gorm.ErrRecordNotFound.Error() {
+	if err != nil {
+		if err.Error() == gorm.ErrRecordNotFound.Error() {
 			return models.Tag{}, errors.GetMissingEntityError("Tag", &idl_datacatalog.Tag{
 				Name: tag.TagName,
 			})
 		}
-		return models.Tag{}, h.errorTransformer.ToDataCatalogError(result.Error)
+		return models.Tag{}, h.errorTransformer.ToDataCatalogError(err)
 	}
 
 	return tag, nil
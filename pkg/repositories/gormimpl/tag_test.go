@@ -76,7 +76,7 @@ func TestCreateTag(t *testing.T) {
 		},
 	)
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := tagRepo.Create(context.Background(), getTestTag())
 	assert.NoError(t, err)
 	assert.True(t, tagCreated)
@@ -107,7 +107,7 @@ func TestGetTag(t *testing.T) {
 		TagName:        "test-tag",
 	}
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	response, err := tagRepo.Get(context.Background(), getInput)
 	assert.NoError(t, err)
 	assert.Equal(t, artifact.ArtifactID, response.ArtifactID)
@@ -136,7 +136,7 @@ func TestTagNotFound(t *testing.T) {
 		TagName:        "test-tag",
 	}
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	_, err := tagRepo.Get(context.Background(), getInput)
 	assert.Error(t, err)
 	assert.Equal(t, "missing entity of type Tag with identifier ", err.Error())
@@ -152,7 +152,7 @@ func TestTagAlreadyExists(t *testing.T) {
 		getAlreadyExistsErr(),
 	)
 
-	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), promutils.NewTestScope())
+	tagRepo := NewTagRepo(utils.GetDbForTest(t), errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
 	err := tagRepo.Create(context.Background(), getTestTag())
 	assert.Error(t, err)
 	dcErr, ok := err.(datacatalog_error.DataCatalogError)
@@ -0,0 +1,26 @@
+package gormimpl
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// Transaction runs fn within a database transaction, which is committed if fn returns nil and rolled back otherwise.
+// The repositories run the queries of the context passed to fn within the transaction.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, transactionKey{}, tx))
+	})
+}
+
+// Get the transaction of the context, or the database if the context is not within a transaction. Queries are
+// cancelled once the context is done.
+func getDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
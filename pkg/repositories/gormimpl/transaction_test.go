@@ -0,0 +1,52 @@
+package gormimpl
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	mocket "github.com/Selvatico/go-mocket"
+	"github.com/flyteorg/datacatalog/pkg/repositories/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/utils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestTransaction(t *testing.T) {
+	GlobalMock := mocket.Catcher.Reset()
+	GlobalMock.Logging = true
+
+	tagCreated := false
+	GlobalMock.NewMock().WithQuery(`INSERT INTO "tags"`).WithCallback(
+		func(s string, values []driver.NamedValue) {
+			tagCreated = true
+		},
+	)
+
+	db := utils.GetDbForTest(t)
+	tagRepo := NewTagRepo(db, errors.NewPostgresErrorTransformer(), StatementTimeouts{}, promutils.NewTestScope())
+
+	t.Run("Commit", func(t *testing.T) {
+		err := Transaction(context.Background(), db, func(ctx context.Context) error {
+			assert.NotEqual(t, db, getDB(ctx, db))
+			return tagRepo.Create(ctx, getTestTag())
+		})
+		assert.NoError(t, err)
+		assert.True(t, tagCreated)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		err := Transaction(context.Background(), db, func(ctx context.Context) error {
+			return gorm.ErrInvalidData
+		})
+		assert.Equal(t, gorm.ErrInvalidData, err)
+	})
+
+	t.Run("Outside of transaction", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), transactionKey{}, nil)
+		outside := getDB(ctx, db)
+		assert.Equal(t, db.Statement.ConnPool, outside.Statement.ConnPool)
+		assert.Equal(t, ctx, outside.Statement.Context)
+	})
+}
@@ -10,12 +10,22 @@ import (
 	"github.com/flyteorg/flytestdlib/database"
 
 	"github.com/flyteorg/datacatalog/pkg/repositories/config"
+	"github.com/flyteorg/datacatalog/pkg/repositories/gormimpl"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/datacatalog/pkg/repositories/transformers"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// The number of artifact data whose sizes are looked up at a time
+const artifactDataSizeBatchSize = 1000
+
+// The number of artifacts or datasets whose metadata pairs are created at a time
+const metadataPairsBatchSize = 1000
+
 type DBHandle struct {
 	db *gorm.DB
 }
@@ -73,6 +83,11 @@ func (h *DBHandle) CreateDB(dbName string) error {
 	return nil
 }
 
+// Ping checks that a connection to the database can be established
+func (h *DBHandle) Ping(ctx context.Context) error {
+	return pingDB(ctx, h.db)
+}
+
 func (h *DBHandle) Migrate(ctx context.Context) error {
 	if err := h.db.AutoMigrate(&models.Dataset{}); err != nil {
 		return err
@@ -102,5 +117,237 @@ func (h *DBHandle) Migrate(ctx context.Context) error {
 		return err
 	}
 
+	if err := h.migrateReservationSlots(); err != nil {
+		return err
+	}
+
+	if err := h.migrateFencingTokenSequence(); err != nil {
+		return err
+	}
+
+	if err := h.db.AutoMigrate(&models.ReservationWaiter{}); err != nil {
+		return err
+	}
+
+	if err := h.db.AutoMigrate(&models.ReservationForceRelease{}); err != nil {
+		return err
+	}
+
+	if err := h.db.AutoMigrate(&models.ArtifactMetadata{}); err != nil {
+		return err
+	}
+
+	if err := h.db.AutoMigrate(&models.DatasetMetadata{}); err != nil {
+		return err
+	}
+
+	if err := h.migrateArtifactMetadataPairs(ctx); err != nil {
+		return err
+	}
+
+	if err := h.migrateDatasetMetadataPairs(ctx); err != nil {
+		return err
+	}
+
+	if err := h.db.AutoMigrate(&models.ProjectUsage{}); err != nil {
+		return err
+	}
+
+	if err := h.countProjectUsage(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reservations were keyed by dataset and tag before they could be held in multiple slots. AutoMigrate adds the slot
+// column to existing tables but does not change their primary key, so it is extended here. New tables and SQLite
+// databases are created with the slot in the primary key already.
+func (h *DBHandle) migrateReservationSlots() error {
+	if h.db.Name() != config.Postgres {
+		return nil
+	}
+
+	type PrimaryKeyResult struct {
+		Exists bool
+	}
+	var slotInPrimaryKey PrimaryKeyResult
+	result := h.db.Raw(`SELECT EXISTS(SELECT 1 FROM pg_index JOIN pg_attribute ON pg_attribute.attrelid = pg_index.indrelid ` +
+		`AND pg_attribute.attnum = ANY(pg_index.indkey) WHERE pg_index.indrelid = 'reservations'::regclass ` +
+		`AND pg_index.indisprimary AND pg_attribute.attname = 'slot')`).Scan(&slotInPrimaryKey)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if slotInPrimaryKey.Exists {
+		return nil
+	}
+
+	logger.Infof(context.TODO(), "Adding the slot to the primary key of the reservations table")
+	return h.db.Exec("ALTER TABLE reservations DROP CONSTRAINT reservations_pkey, " +
+		"ADD PRIMARY KEY (dataset_project, dataset_name, dataset_domain, dataset_version, tag_name, slot)").Error
+}
+
+// Fencing tokens are taken from a sequence, so that they increase across replicas regardless of their clocks. The
+// sequence is moved past the tokens of existing reservations, which were derived from the time they were acquired at.
+func (h *DBHandle) migrateFencingTokenSequence() error {
+	if h.db.Name() != config.Postgres {
+		return nil
+	}
+
+	if err := h.db.Exec(fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s", gormimpl.FencingTokenSequence)).Error; err != nil {
+		return err
+	}
+
+	// the sequence is never moved back, so that migrating again does not hand out tokens twice
+	return h.db.Exec(fmt.Sprintf("SELECT setval('%[1]s', GREATEST((SELECT last_value FROM %[1]s), "+
+		"(SELECT COALESCE(MAX(fencing_token), 0) FROM reservations)))", gormimpl.FencingTokenSequence)).Error
+}
+
+// Metadata pairs are stored next to the serialized metadata of artifacts, so that artifacts can be filtered by their
+// metadata. The pairs of artifacts stored before are created from their serialized metadata. Metadata which fails to
+// be deserialized is skipped by the following batches, which is why they are offset by the number of artifacts
+// skipped so far. Artifacts without metadata serialize it to no bytes, so they are not selected.
+func (h *DBHandle) migrateArtifactMetadataPairs(ctx context.Context) error {
+	migrated, skipped := 0, 0
+	for {
+		var batch []models.Artifact
+		err := h.db.Where("length(serialized_metadata) > 0").
+			Where("NOT EXISTS (SELECT 1 FROM artifact_metadata WHERE artifact_metadata.dataset_uuid = artifacts.dataset_uuid " +
+				"AND artifact_metadata.artifact_id = artifacts.artifact_id)").
+			Order("dataset_project, dataset_name, dataset_domain, dataset_version, artifact_id").
+			Offset(skipped).Limit(metadataPairsBatchSize).Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, artifact := range batch {
+			metadataPairs, err := transformers.DeserializeArtifactMetadataModels(artifact)
+			if err != nil || len(metadataPairs) == 0 {
+				logger.Warnf(ctx, "Failed to deserialize the metadata of artifact %+v, err: %v", artifact.ArtifactKey, err)
+				skipped++
+				continue
+			}
+
+			if err := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&metadataPairs).Error; err != nil {
+				return err
+			}
+			migrated++
+		}
+	}
+
+	if migrated > 0 || skipped > 0 {
+		logger.Infof(ctx, "Created the metadata pairs of %d artifacts, skipped %d", migrated, skipped)
+	}
+	return nil
+}
+
+// Like migrateArtifactMetadataPairs, the metadata pairs of datasets stored before are created from their serialized
+// metadata
+func (h *DBHandle) migrateDatasetMetadataPairs(ctx context.Context) error {
+	migrated, skipped := 0, 0
+	for {
+		var batch []models.Dataset
+		err := h.db.Where("length(serialized_metadata) > 0").
+			Where("NOT EXISTS (SELECT 1 FROM dataset_metadata WHERE dataset_metadata.dataset_uuid = datasets.uuid)").
+			Order("project, name, domain, version").
+			Offset(skipped).Limit(metadataPairsBatchSize).Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, dataset := range batch {
+			metadataPairs, err := transformers.DeserializeDatasetMetadataModels(dataset)
+			if err != nil || len(metadataPairs) == 0 {
+				logger.Warnf(ctx, "Failed to deserialize the metadata of dataset %+v, err: %v", dataset.DatasetKey, err)
+				skipped++
+				continue
+			}
+
+			if err := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&metadataPairs).Error; err != nil {
+				return err
+			}
+			migrated++
+		}
+	}
+
+	if migrated > 0 || skipped > 0 {
+		logger.Infof(ctx, "Created the metadata pairs of %d datasets, skipped %d", migrated, skipped)
+	}
+	return nil
+}
+
+// The usage of projects is counted up when artifacts are created, it is recounted from their artifacts so that
+// artifacts created before the usage was counted are included. Migrations run before the service is rolled out, so no
+// artifacts are created while recounting.
+func (h *DBHandle) countProjectUsage() error {
+	return h.db.Exec(`INSERT INTO project_usages (created_at, updated_at, project, artifact_count, data_bytes) ` +
+		`SELECT CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, dataset_project, COUNT(*), (SELECT COALESCE(SUM(size), 0) ` +
+		`FROM artifact_data WHERE artifact_data.dataset_project = artifacts.dataset_project) ` +
+		`FROM artifacts GROUP BY dataset_project ON CONFLICT (project) DO UPDATE SET ` +
+		`updated_at = excluded.updated_at, artifact_count = excluded.artifact_count, data_bytes = excluded.data_bytes`).Error
+}
+
+// BackfillArtifactDataSizes looks up the sizes of the artifact data stored before sizes were recorded in the storage
+// and recounts the usage of the projects afterwards. Data whose size can't be looked up keeps a zero size and is
+// skipped by the following batches, which is why they are offset by the number of data skipped so far. Fails after
+// all data was looked up if the storage failed to look up any of it.
+func (h *DBHandle) BackfillArtifactDataSizes(ctx context.Context, store *storage.DataStore) error {
+	backfilled, missing, failed := 0, 0, 0
+	for {
+		var batch []models.ArtifactData
+		err := h.db.Where("size = 0").
+			Order("dataset_project, dataset_name, dataset_domain, dataset_version, artifact_id, name").
+			Offset(missing + failed).Limit(artifactDataSizeBatchSize).Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, artifactData := range batch {
+			metadata, err := store.Head(ctx, storage.DataReference(artifactData.Location))
+			if err != nil {
+				logger.Errorf(ctx, "Failed to look up the size of artifact data %s at %s, err: %v", artifactData.Name,
+					artifactData.Location, err)
+				failed++
+				continue
+			}
+
+			if !metadata.Exists() || metadata.Size() == 0 {
+				logger.Warnf(ctx, "No data of artifact data %s is stored at %s", artifactData.Name, artifactData.Location)
+				missing++
+				continue
+			}
+
+			err = h.db.Model(&models.ArtifactData{}).
+				Where(&models.ArtifactData{ArtifactKey: artifactData.ArtifactKey, Name: artifactData.Name}).
+				Update("size", metadata.Size()).Error
+			if err != nil {
+				return err
+			}
+			backfilled++
+		}
+	}
+
+	logger.Infof(ctx, "Backfilled the sizes of %d artifact data, %d are missing from the storage and %d failed",
+		backfilled, missing, failed)
+	if err := h.countProjectUsage(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to look up the sizes of %d artifact data", failed)
+	}
 	return nil
 }
@@ -1,13 +1,21 @@
 package repositories
 
 import (
+	"bytes"
 	"context"
 	"path"
 	"testing"
 
 	mocket "github.com/Selvatico/go-mocket"
 	"github.com/flyteorg/datacatalog/pkg/repositories/config"
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/database"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"database/sql/driver"
@@ -15,6 +23,10 @@ import (
 	"github.com/flyteorg/datacatalog/pkg/repositories/utils"
 )
 
+func init() {
+	labeled.SetMetricKeys(contextutils.AppNameKey)
+}
+
 func TestCreateDB(t *testing.T) {
 	GlobalMock := mocket.Catcher.Reset()
 	GlobalMock.Logging = true
@@ -95,3 +107,109 @@ func TestNewDBHandle(t *testing.T) {
 		assert.Equal(t, config.Sqlite, dbHandle.db.Name())
 	})
 }
+
+func TestPing(t *testing.T) {
+	mocket.Catcher.Reset()
+	dbHandle := &DBHandle{
+		db: utils.GetDbForTest(t),
+	}
+	assert.NoError(t, dbHandle.Ping(context.Background()))
+}
+
+func TestProjectUsage(t *testing.T) {
+	ctx := context.Background()
+	dbFile := path.Join(t.TempDir(), "admin.db")
+	dbHandle, err := NewDBHandle(ctx, database.DbConfig{SQLite: database.SQLiteConfig{File: dbFile}}, migrateScope)
+	assert.NoError(t, err)
+	assert.NoError(t, dbHandle.Migrate(ctx))
+
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+	legacyLocation := storage.DataReference("s3://bucket/legacy")
+	assert.NoError(t, store.WriteRaw(ctx, legacyLocation, 42, storage.Options{}, bytes.NewReader(make([]byte, 42))))
+
+	artifactKey := func(project, artifactID string) models.ArtifactKey {
+		return models.ArtifactKey{DatasetProject: project, DatasetName: "name", DatasetDomain: "domain",
+			DatasetVersion: "version", ArtifactID: artifactID}
+	}
+	assert.NoError(t, dbHandle.db.Create(&[]models.Artifact{
+		{ArtifactKey: artifactKey("project-a", "1")},
+		{ArtifactKey: artifactKey("project-a", "2")},
+		{ArtifactKey: artifactKey("project-b", "3")},
+	}).Error)
+	assert.NoError(t, dbHandle.db.Create(&[]models.ArtifactData{
+		// stored before sizes were recorded
+		{ArtifactKey: artifactKey("project-a", "1"), Name: "legacy", Location: legacyLocation.String()},
+		{ArtifactKey: artifactKey("project-a", "1"), Name: "missing", Location: "s3://bucket/missing"},
+		{ArtifactKey: artifactKey("project-a", "2"), Name: "data", Location: "s3://bucket/data", Size: 100},
+	}).Error)
+	// the usage counted so far is recounted when migrating
+	assert.NoError(t, dbHandle.db.Create(&models.ProjectUsage{Project: "project-a", ArtifactCount: 1}).Error)
+	assert.NoError(t, dbHandle.Migrate(ctx))
+
+	var usages []models.ProjectUsage
+	assert.NoError(t, dbHandle.db.Order("project").Find(&usages).Error)
+	assert.Len(t, usages, 2)
+	assert.Equal(t, "project-a", usages[0].Project)
+	assert.EqualValues(t, 2, usages[0].ArtifactCount)
+	assert.EqualValues(t, 100, usages[0].DataBytes)
+	assert.Equal(t, "project-b", usages[1].Project)
+	assert.EqualValues(t, 1, usages[1].ArtifactCount)
+	assert.EqualValues(t, 0, usages[1].DataBytes)
+
+	// the sizes of legacy data are backfilled separately
+	assert.NoError(t, dbHandle.BackfillArtifactDataSizes(ctx, store))
+
+	var legacyData models.ArtifactData
+	assert.NoError(t, dbHandle.db.Where("name = ?", "legacy").Take(&legacyData).Error)
+	assert.EqualValues(t, 42, legacyData.Size)
+
+	var usage models.ProjectUsage
+	assert.NoError(t, dbHandle.db.Where("project = ?", "project-a").Take(&usage).Error)
+	assert.EqualValues(t, 142, usage.DataBytes)
+}
+
+func TestMigrateMetadataPairs(t *testing.T) {
+	ctx := context.Background()
+	dbFile := path.Join(t.TempDir(), "admin.db")
+	dbHandle, err := NewDBHandle(ctx, database.DbConfig{SQLite: database.SQLiteConfig{File: dbFile}}, migrateScope)
+	assert.NoError(t, err)
+	assert.NoError(t, dbHandle.Migrate(ctx))
+
+	serializedMetadata, err := proto.Marshal(&datacatalog.Metadata{KeyMap: map[string]string{"execution_id": "X"}})
+	assert.NoError(t, err)
+
+	// stored before their metadata pairs were created
+	datasetKey := models.DatasetKey{Project: "project", Name: "name", Domain: "domain", Version: "version",
+		UUID: "9a5b7c2e-4c1d-4f3a-8e6b-1d2c3b4a5f6e"}
+	assert.NoError(t, dbHandle.db.Create(&models.Dataset{DatasetKey: datasetKey, SerializedMetadata: serializedMetadata}).Error)
+	artifactKey := models.ArtifactKey{DatasetProject: "project", DatasetName: "name", DatasetDomain: "domain",
+		DatasetVersion: "version", ArtifactID: "artifact"}
+	assert.NoError(t, dbHandle.db.Create(&[]models.Artifact{
+		{ArtifactKey: artifactKey, DatasetUUID: datasetKey.UUID, SerializedMetadata: serializedMetadata},
+		{ArtifactKey: models.ArtifactKey{DatasetProject: "project", DatasetName: "name", DatasetDomain: "domain",
+			DatasetVersion: "version", ArtifactID: "undecodable"}, DatasetUUID: datasetKey.UUID, SerializedMetadata: []byte{0xff}},
+	}).Error)
+	assert.NoError(t, dbHandle.Migrate(ctx))
+
+	var datasetMetadata []models.DatasetMetadata
+	assert.NoError(t, dbHandle.db.Find(&datasetMetadata).Error)
+	assert.Len(t, datasetMetadata, 1)
+	assert.Equal(t, datasetKey.UUID, datasetMetadata[0].DatasetUUID)
+	assert.Equal(t, "execution_id", datasetMetadata[0].Key)
+	assert.Equal(t, "X", datasetMetadata[0].Value)
+
+	// the artifact is found through the same join as metadata filters, the preloads of listing artifacts need Postgres
+	var artifacts []models.Artifact
+	assert.NoError(t, dbHandle.db.Joins("JOIN artifact_metadata ON artifacts.dataset_uuid = artifact_metadata.dataset_uuid "+
+		"AND artifacts.artifact_id = artifact_metadata.artifact_id").
+		Where("artifact_metadata.key = ? AND artifact_metadata.value = ?", "execution_id", "X").Find(&artifacts).Error)
+	assert.Len(t, artifacts, 1)
+	assert.Equal(t, artifactKey, artifacts[0].ArtifactKey)
+
+	// migrating again leaves the created pairs as they are
+	assert.NoError(t, dbHandle.Migrate(ctx))
+	var artifactMetadata []models.ArtifactMetadata
+	assert.NoError(t, dbHandle.db.Find(&artifactMetadata).Error)
+	assert.Len(t, artifactMetadata, 1)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/flyteorg/datacatalog/pkg/runtime"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/storage"
 	"github.com/jackc/pgconn"
 )
 
@@ -78,6 +79,32 @@ func Migrate(ctx context.Context) error {
 	return nil
 }
 
+// BackfillArtifactDataSizes looks up the sizes of artifact data stored before sizes were recorded, so that the data
+// counts towards the quota of its project. The database must have been migrated before.
+func BackfillArtifactDataSizes(ctx context.Context) error {
+	configProvider := runtime.NewConfigurationProvider()
+	dbConfigValues := *configProvider.ApplicationConfiguration().GetDbConfig()
+	dbHandle, err := NewDBHandle(ctx, dbConfigValues, migrateScope)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to connect DB err %v", err)
+		return err
+	}
+
+	storeConfig := storage.GetConfig()
+	store, err := storage.NewDataStore(storeConfig, migrateScope.NewSubScope("storage"))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to create DataStore %v, err %v", storeConfig, err)
+		return err
+	}
+
+	if err := dbHandle.BackfillArtifactDataSizes(ctx, store); err != nil {
+		logger.Errorf(ctx, "Failed to backfill the sizes of artifact data. err: %v", err)
+		return err
+	}
+	logger.Infof(ctx, "Backfilled the sizes of artifact data successfully.")
+	return nil
+}
+
 func isPgErrorWithCode(err error, code string) bool {
 	pgErr := &pgconn.PgError{}
 	if !errors.As(err, &pgErr) {
@@ -13,4 +13,7 @@ type ArtifactRepo interface {
 	Get(ctx context.Context, in models.ArtifactKey) (models.Artifact, error)
 	List(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error)
 	Update(ctx context.Context, artifact models.Artifact) error
+	Search(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error)
+	GetProjectUsage(ctx context.Context, project string) (models.ProjectUsage, error)
+	GetProjectUsageForUpdate(ctx context.Context, project string) (models.ProjectUsage, error)
 }
@@ -1,8 +1,17 @@
 package interfaces
 
+import "context"
+
 type DataCatalogRepo interface {
 	DatasetRepo() DatasetRepo
 	ArtifactRepo() ArtifactRepo
 	TagRepo() TagRepo
 	ReservationRepo() ReservationRepo
+	// Transaction runs fn in a database transaction, committed if fn returns nil and rolled back otherwise. The
+	// repository calls made with the context passed to fn are part of the transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Ping checks that a connection to the database can be established
+	Ping(ctx context.Context) error
+	// Close closes the connections to the database, waiting for the queries in flight to complete
+	Close() error
 }
@@ -11,5 +11,9 @@ import (
 type DatasetRepo interface {
 	Create(ctx context.Context, in models.Dataset) error
 	Get(ctx context.Context, in models.DatasetKey) (models.Dataset, error)
+	GetForUpdate(ctx context.Context, in models.DatasetKey) (models.Dataset, error)
 	List(ctx context.Context, in models.ListModelsInput) ([]models.Dataset, error)
+	Update(ctx context.Context, in models.Dataset) error
+	ListPartitionKeysInUse(ctx context.Context, in models.DatasetKey) ([]string, error)
+	GetStats(ctx context.Context, in models.DatasetKey) (models.DatasetStats, error)
 }
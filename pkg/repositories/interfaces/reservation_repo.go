@@ -15,14 +15,46 @@ type ReservationRepo interface {
 	// Create a new reservation if the reservation does not already exist
 	Create(ctx context.Context, reservation models.Reservation, now time.Time) error
 
-	// Delete a reservation if it exists
+	// Delete the reservation held by the owner if it exists, regardless of the slot of the key it holds
 	Delete(ctx context.Context, reservation models.ReservationKey, ownerID string) error
 
 	// Get reservation
 	Get(ctx context.Context, reservationKey models.ReservationKey) (models.Reservation, error)
 
+	// Get the reservations held in all slots of the key, ordered by slot. The slot of the key is ignored.
+	GetSlots(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error)
+
+	// Get the reservations held in all slots of the key like GetSlots, locking them until the transaction of the
+	// context ends so that they are neither taken over nor released in the meantime
+	GetSlotsForUpdate(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error)
+
+	// Get a new fencing token, greater than all tokens handed out before by any replica regardless of its clock
+	NextFencingToken(ctx context.Context) (int64, error)
+
+	// Record the release of a reservation regardless of its owner
+	CreateForceRelease(ctx context.Context, forceRelease models.ReservationForceRelease) error
+
 	// Update an existing reservation. If called by the current owner, we update the
 	// expiresAt timestamp. If called by a new owner and the current reservation has
 	// expired, we attempt to take over the reservation.
 	Update(ctx context.Context, reservation models.Reservation, now time.Time) error
+
+	// List reservations matching the filters of the list input
+	List(ctx context.Context, in models.ListModelsInput) ([]models.Reservation, error)
+
+	// Delete up to limit reservations which expired before the given time, returning the number of deleted reservations
+	DeleteExpired(ctx context.Context, expiredBefore time.Time, limit int) (int64, error)
+
+	// Queue the owner of the waiter for the reservation. Owners which are queued already keep their place in the
+	// queue and only extend their expiration.
+	Enqueue(ctx context.Context, waiter models.ReservationWaiter) error
+
+	// List the waiters of all slots of the key which have not expired, in the order they were queued in
+	ListWaiters(ctx context.Context, reservationKey models.ReservationKey, now time.Time) ([]models.ReservationWaiter, error)
+
+	// Remove the owner from the queue of the key if it is queued
+	Dequeue(ctx context.Context, reservationKey models.ReservationKey, ownerID string) error
+
+	// Delete up to limit waiters which expired before the given time, returning the number of deleted waiters
+	DeleteExpiredWaiters(ctx context.Context, expiredBefore time.Time, limit int) (int64, error)
 }
@@ -86,6 +86,84 @@ func (_m *ArtifactRepo) Get(ctx context.Context, in models.ArtifactKey) (models.
 	return r0, r1
 }
 
+type ArtifactRepo_GetProjectUsage struct {
+	*mock.Call
+}
+
+func (_m ArtifactRepo_GetProjectUsage) Return(_a0 models.ProjectUsage, _a1 error) *ArtifactRepo_GetProjectUsage {
+	return &ArtifactRepo_GetProjectUsage{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ArtifactRepo) OnGetProjectUsage(ctx context.Context, project string) *ArtifactRepo_GetProjectUsage {
+	c_call := _m.On("GetProjectUsage", ctx, project)
+	return &ArtifactRepo_GetProjectUsage{Call: c_call}
+}
+
+func (_m *ArtifactRepo) OnGetProjectUsageMatch(matchers ...interface{}) *ArtifactRepo_GetProjectUsage {
+	c_call := _m.On("GetProjectUsage", matchers...)
+	return &ArtifactRepo_GetProjectUsage{Call: c_call}
+}
+
+// GetProjectUsage provides a mock function with given fields: ctx, project
+func (_m *ArtifactRepo) GetProjectUsage(ctx context.Context, project string) (models.ProjectUsage, error) {
+	ret := _m.Called(ctx, project)
+
+	var r0 models.ProjectUsage
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ProjectUsage); ok {
+		r0 = rf(ctx, project)
+	} else {
+		r0 = ret.Get(0).(models.ProjectUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ArtifactRepo_GetProjectUsageForUpdate struct {
+	*mock.Call
+}
+
+func (_m ArtifactRepo_GetProjectUsageForUpdate) Return(_a0 models.ProjectUsage, _a1 error) *ArtifactRepo_GetProjectUsageForUpdate {
+	return &ArtifactRepo_GetProjectUsageForUpdate{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ArtifactRepo) OnGetProjectUsageForUpdate(ctx context.Context, project string) *ArtifactRepo_GetProjectUsageForUpdate {
+	c_call := _m.On("GetProjectUsageForUpdate", ctx, project)
+	return &ArtifactRepo_GetProjectUsageForUpdate{Call: c_call}
+}
+
+func (_m *ArtifactRepo) OnGetProjectUsageForUpdateMatch(matchers ...interface{}) *ArtifactRepo_GetProjectUsageForUpdate {
+	c_call := _m.On("GetProjectUsageForUpdate", matchers...)
+	return &ArtifactRepo_GetProjectUsageForUpdate{Call: c_call}
+}
+
+// GetProjectUsageForUpdate provides a mock function with given fields: ctx, project
+func (_m *ArtifactRepo) GetProjectUsageForUpdate(ctx context.Context, project string) (models.ProjectUsage, error) {
+	ret := _m.Called(ctx, project)
+
+	var r0 models.ProjectUsage
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ProjectUsage); ok {
+		r0 = rf(ctx, project)
+	} else {
+		r0 = ret.Get(0).(models.ProjectUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ArtifactRepo_List struct {
 	*mock.Call
 }
@@ -127,6 +205,47 @@ func (_m *ArtifactRepo) List(ctx context.Context, datasetKey models.DatasetKey,
 	return r0, r1
 }
 
+type ArtifactRepo_Search struct {
+	*mock.Call
+}
+
+func (_m ArtifactRepo_Search) Return(_a0 []models.Artifact, _a1 error) *ArtifactRepo_Search {
+	return &ArtifactRepo_Search{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ArtifactRepo) OnSearch(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) *ArtifactRepo_Search {
+	c_call := _m.On("Search", ctx, datasetKey, in)
+	return &ArtifactRepo_Search{Call: c_call}
+}
+
+func (_m *ArtifactRepo) OnSearchMatch(matchers ...interface{}) *ArtifactRepo_Search {
+	c_call := _m.On("Search", matchers...)
+	return &ArtifactRepo_Search{Call: c_call}
+}
+
+// Search provides a mock function with given fields: ctx, datasetKey, in
+func (_m *ArtifactRepo) Search(ctx context.Context, datasetKey models.DatasetKey, in models.ListModelsInput) ([]models.Artifact, error) {
+	ret := _m.Called(ctx, datasetKey, in)
+
+	var r0 []models.Artifact
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey, models.ListModelsInput) []models.Artifact); ok {
+		r0 = rf(ctx, datasetKey, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artifact)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, datasetKey, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ArtifactRepo_Update struct {
 	*mock.Call
 }
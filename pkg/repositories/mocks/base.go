@@ -1,12 +1,18 @@
 package mocks
 
-import "github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+import (
+	"context"
+
+	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
+)
 
 type DataCatalogRepo struct {
 	MockDatasetRepo     *DatasetRepo
 	MockArtifactRepo    *ArtifactRepo
 	MockTagRepo         *TagRepo
 	MockReservationRepo *ReservationRepo
+	// PingErr is returned by Ping
+	PingErr error
 }
 
 func (m *DataCatalogRepo) DatasetRepo() interfaces.DatasetRepo {
@@ -24,3 +30,16 @@ func (m *DataCatalogRepo) TagRepo() interfaces.TagRepo {
 func (m *DataCatalogRepo) ReservationRepo() interfaces.ReservationRepo {
 	return m.MockReservationRepo
 }
+
+// Transaction runs fn without a transaction, the mocked repositories can't roll back
+func (m *DataCatalogRepo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *DataCatalogRepo) Ping(ctx context.Context) error {
+	return m.PingErr
+}
+
+func (m *DataCatalogRepo) Close() error {
+	return nil
+}
@@ -86,6 +86,84 @@ func (_m *DatasetRepo) Get(ctx context.Context, in models.DatasetKey) (models.Da
 	return r0, r1
 }
 
+type DatasetRepo_GetForUpdate struct {
+	*mock.Call
+}
+
+func (_m DatasetRepo_GetForUpdate) Return(_a0 models.Dataset, _a1 error) *DatasetRepo_GetForUpdate {
+	return &DatasetRepo_GetForUpdate{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *DatasetRepo) OnGetForUpdate(ctx context.Context, in models.DatasetKey) *DatasetRepo_GetForUpdate {
+	c_call := _m.On("GetForUpdate", ctx, in)
+	return &DatasetRepo_GetForUpdate{Call: c_call}
+}
+
+func (_m *DatasetRepo) OnGetForUpdateMatch(matchers ...interface{}) *DatasetRepo_GetForUpdate {
+	c_call := _m.On("GetForUpdate", matchers...)
+	return &DatasetRepo_GetForUpdate{Call: c_call}
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, in
+func (_m *DatasetRepo) GetForUpdate(ctx context.Context, in models.DatasetKey) (models.Dataset, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 models.Dataset
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey) models.Dataset); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(models.Dataset)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type DatasetRepo_GetStats struct {
+	*mock.Call
+}
+
+func (_m DatasetRepo_GetStats) Return(_a0 models.DatasetStats, _a1 error) *DatasetRepo_GetStats {
+	return &DatasetRepo_GetStats{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *DatasetRepo) OnGetStats(ctx context.Context, in models.DatasetKey) *DatasetRepo_GetStats {
+	c_call := _m.On("GetStats", ctx, in)
+	return &DatasetRepo_GetStats{Call: c_call}
+}
+
+func (_m *DatasetRepo) OnGetStatsMatch(matchers ...interface{}) *DatasetRepo_GetStats {
+	c_call := _m.On("GetStats", matchers...)
+	return &DatasetRepo_GetStats{Call: c_call}
+}
+
+// GetStats provides a mock function with given fields: ctx, in
+func (_m *DatasetRepo) GetStats(ctx context.Context, in models.DatasetKey) (models.DatasetStats, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 models.DatasetStats
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey) models.DatasetStats); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(models.DatasetStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type DatasetRepo_List struct {
 	*mock.Call
 }
@@ -126,3 +204,76 @@ func (_m *DatasetRepo) List(ctx context.Context, in models.ListModelsInput) ([]m
 
 	return r0, r1
 }
+
+type DatasetRepo_ListPartitionKeysInUse struct {
+	*mock.Call
+}
+
+func (_m DatasetRepo_ListPartitionKeysInUse) Return(_a0 []string, _a1 error) *DatasetRepo_ListPartitionKeysInUse {
+	return &DatasetRepo_ListPartitionKeysInUse{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *DatasetRepo) OnListPartitionKeysInUse(ctx context.Context, in models.DatasetKey) *DatasetRepo_ListPartitionKeysInUse {
+	c_call := _m.On("ListPartitionKeysInUse", ctx, in)
+	return &DatasetRepo_ListPartitionKeysInUse{Call: c_call}
+}
+
+func (_m *DatasetRepo) OnListPartitionKeysInUseMatch(matchers ...interface{}) *DatasetRepo_ListPartitionKeysInUse {
+	c_call := _m.On("ListPartitionKeysInUse", matchers...)
+	return &DatasetRepo_ListPartitionKeysInUse{Call: c_call}
+}
+
+// ListPartitionKeysInUse provides a mock function with given fields: ctx, in
+func (_m *DatasetRepo) ListPartitionKeysInUse(ctx context.Context, in models.DatasetKey) ([]string, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, models.DatasetKey) []string); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.DatasetKey) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type DatasetRepo_Update struct {
+	*mock.Call
+}
+
+func (_m DatasetRepo_Update) Return(_a0 error) *DatasetRepo_Update {
+	return &DatasetRepo_Update{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *DatasetRepo) OnUpdate(ctx context.Context, in models.Dataset) *DatasetRepo_Update {
+	c_call := _m.On("Update", ctx, in)
+	return &DatasetRepo_Update{Call: c_call}
+}
+
+func (_m *DatasetRepo) OnUpdateMatch(matchers ...interface{}) *DatasetRepo_Update {
+	c_call := _m.On("Update", matchers...)
+	return &DatasetRepo_Update{Call: c_call}
+}
+
+// Update provides a mock function with given fields: ctx, in
+func (_m *DatasetRepo) Update(ctx context.Context, in models.Dataset) error {
+	ret := _m.Called(ctx, in)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Dataset) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -49,6 +49,38 @@ func (_m *ReservationRepo) Create(ctx context.Context, reservation models.Reserv
 	return r0
 }
 
+type ReservationRepo_CreateForceRelease struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_CreateForceRelease) Return(_a0 error) *ReservationRepo_CreateForceRelease {
+	return &ReservationRepo_CreateForceRelease{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ReservationRepo) OnCreateForceRelease(ctx context.Context, forceRelease models.ReservationForceRelease) *ReservationRepo_CreateForceRelease {
+	c_call := _m.On("CreateForceRelease", ctx, forceRelease)
+	return &ReservationRepo_CreateForceRelease{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnCreateForceReleaseMatch(matchers ...interface{}) *ReservationRepo_CreateForceRelease {
+	c_call := _m.On("CreateForceRelease", matchers...)
+	return &ReservationRepo_CreateForceRelease{Call: c_call}
+}
+
+// CreateForceRelease provides a mock function with given fields: ctx, forceRelease
+func (_m *ReservationRepo) CreateForceRelease(ctx context.Context, forceRelease models.ReservationForceRelease) error {
+	ret := _m.Called(ctx, forceRelease)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationForceRelease) error); ok {
+		r0 = rf(ctx, forceRelease)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type ReservationRepo_Delete struct {
 	*mock.Call
 }
@@ -81,6 +113,148 @@ func (_m *ReservationRepo) Delete(ctx context.Context, reservation models.Reserv
 	return r0
 }
 
+type ReservationRepo_DeleteExpired struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_DeleteExpired) Return(_a0 int64, _a1 error) *ReservationRepo_DeleteExpired {
+	return &ReservationRepo_DeleteExpired{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnDeleteExpired(ctx context.Context, expiredBefore time.Time, limit int) *ReservationRepo_DeleteExpired {
+	c_call := _m.On("DeleteExpired", ctx, expiredBefore, limit)
+	return &ReservationRepo_DeleteExpired{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnDeleteExpiredMatch(matchers ...interface{}) *ReservationRepo_DeleteExpired {
+	c_call := _m.On("DeleteExpired", matchers...)
+	return &ReservationRepo_DeleteExpired{Call: c_call}
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, expiredBefore, limit
+func (_m *ReservationRepo) DeleteExpired(ctx context.Context, expiredBefore time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, expiredBefore, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, expiredBefore, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, expiredBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_DeleteExpiredWaiters struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_DeleteExpiredWaiters) Return(_a0 int64, _a1 error) *ReservationRepo_DeleteExpiredWaiters {
+	return &ReservationRepo_DeleteExpiredWaiters{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnDeleteExpiredWaiters(ctx context.Context, expiredBefore time.Time, limit int) *ReservationRepo_DeleteExpiredWaiters {
+	c_call := _m.On("DeleteExpiredWaiters", ctx, expiredBefore, limit)
+	return &ReservationRepo_DeleteExpiredWaiters{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnDeleteExpiredWaitersMatch(matchers ...interface{}) *ReservationRepo_DeleteExpiredWaiters {
+	c_call := _m.On("DeleteExpiredWaiters", matchers...)
+	return &ReservationRepo_DeleteExpiredWaiters{Call: c_call}
+}
+
+// DeleteExpiredWaiters provides a mock function with given fields: ctx, expiredBefore, limit
+func (_m *ReservationRepo) DeleteExpiredWaiters(ctx context.Context, expiredBefore time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, expiredBefore, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, expiredBefore, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, expiredBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_Dequeue struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_Dequeue) Return(_a0 error) *ReservationRepo_Dequeue {
+	return &ReservationRepo_Dequeue{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ReservationRepo) OnDequeue(ctx context.Context, reservationKey models.ReservationKey, ownerID string) *ReservationRepo_Dequeue {
+	c_call := _m.On("Dequeue", ctx, reservationKey, ownerID)
+	return &ReservationRepo_Dequeue{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnDequeueMatch(matchers ...interface{}) *ReservationRepo_Dequeue {
+	c_call := _m.On("Dequeue", matchers...)
+	return &ReservationRepo_Dequeue{Call: c_call}
+}
+
+// Dequeue provides a mock function with given fields: ctx, reservationKey, ownerID
+func (_m *ReservationRepo) Dequeue(ctx context.Context, reservationKey models.ReservationKey, ownerID string) error {
+	ret := _m.Called(ctx, reservationKey, ownerID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationKey, string) error); ok {
+		r0 = rf(ctx, reservationKey, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type ReservationRepo_Enqueue struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_Enqueue) Return(_a0 error) *ReservationRepo_Enqueue {
+	return &ReservationRepo_Enqueue{Call: _m.Call.Return(_a0)}
+}
+
+func (_m *ReservationRepo) OnEnqueue(ctx context.Context, waiter models.ReservationWaiter) *ReservationRepo_Enqueue {
+	c_call := _m.On("Enqueue", ctx, waiter)
+	return &ReservationRepo_Enqueue{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnEnqueueMatch(matchers ...interface{}) *ReservationRepo_Enqueue {
+	c_call := _m.On("Enqueue", matchers...)
+	return &ReservationRepo_Enqueue{Call: c_call}
+}
+
+// Enqueue provides a mock function with given fields: ctx, waiter
+func (_m *ReservationRepo) Enqueue(ctx context.Context, waiter models.ReservationWaiter) error {
+	ret := _m.Called(ctx, waiter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationWaiter) error); ok {
+		r0 = rf(ctx, waiter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type ReservationRepo_Get struct {
 	*mock.Call
 }
@@ -120,6 +294,209 @@ func (_m *ReservationRepo) Get(ctx context.Context, reservationKey models.Reserv
 	return r0, r1
 }
 
+type ReservationRepo_GetSlots struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_GetSlots) Return(_a0 []models.Reservation, _a1 error) *ReservationRepo_GetSlots {
+	return &ReservationRepo_GetSlots{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnGetSlots(ctx context.Context, reservationKey models.ReservationKey) *ReservationRepo_GetSlots {
+	c_call := _m.On("GetSlots", ctx, reservationKey)
+	return &ReservationRepo_GetSlots{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnGetSlotsMatch(matchers ...interface{}) *ReservationRepo_GetSlots {
+	c_call := _m.On("GetSlots", matchers...)
+	return &ReservationRepo_GetSlots{Call: c_call}
+}
+
+// GetSlots provides a mock function with given fields: ctx, reservationKey
+func (_m *ReservationRepo) GetSlots(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error) {
+	ret := _m.Called(ctx, reservationKey)
+
+	var r0 []models.Reservation
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationKey) []models.Reservation); ok {
+		r0 = rf(ctx, reservationKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reservation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReservationKey) error); ok {
+		r1 = rf(ctx, reservationKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_GetSlotsForUpdate struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_GetSlotsForUpdate) Return(_a0 []models.Reservation, _a1 error) *ReservationRepo_GetSlotsForUpdate {
+	return &ReservationRepo_GetSlotsForUpdate{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnGetSlotsForUpdate(ctx context.Context, reservationKey models.ReservationKey) *ReservationRepo_GetSlotsForUpdate {
+	c_call := _m.On("GetSlotsForUpdate", ctx, reservationKey)
+	return &ReservationRepo_GetSlotsForUpdate{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnGetSlotsForUpdateMatch(matchers ...interface{}) *ReservationRepo_GetSlotsForUpdate {
+	c_call := _m.On("GetSlotsForUpdate", matchers...)
+	return &ReservationRepo_GetSlotsForUpdate{Call: c_call}
+}
+
+// GetSlotsForUpdate provides a mock function with given fields: ctx, reservationKey
+func (_m *ReservationRepo) GetSlotsForUpdate(ctx context.Context, reservationKey models.ReservationKey) ([]models.Reservation, error) {
+	ret := _m.Called(ctx, reservationKey)
+
+	var r0 []models.Reservation
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationKey) []models.Reservation); ok {
+		r0 = rf(ctx, reservationKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reservation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReservationKey) error); ok {
+		r1 = rf(ctx, reservationKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_List struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_List) Return(_a0 []models.Reservation, _a1 error) *ReservationRepo_List {
+	return &ReservationRepo_List{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnList(ctx context.Context, in models.ListModelsInput) *ReservationRepo_List {
+	c_call := _m.On("List", ctx, in)
+	return &ReservationRepo_List{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnListMatch(matchers ...interface{}) *ReservationRepo_List {
+	c_call := _m.On("List", matchers...)
+	return &ReservationRepo_List{Call: c_call}
+}
+
+// List provides a mock function with given fields: ctx, in
+func (_m *ReservationRepo) List(ctx context.Context, in models.ListModelsInput) ([]models.Reservation, error) {
+	ret := _m.Called(ctx, in)
+
+	var r0 []models.Reservation
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListModelsInput) []models.Reservation); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Reservation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListModelsInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_ListWaiters struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_ListWaiters) Return(_a0 []models.ReservationWaiter, _a1 error) *ReservationRepo_ListWaiters {
+	return &ReservationRepo_ListWaiters{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnListWaiters(ctx context.Context, reservationKey models.ReservationKey, now time.Time) *ReservationRepo_ListWaiters {
+	c_call := _m.On("ListWaiters", ctx, reservationKey, now)
+	return &ReservationRepo_ListWaiters{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnListWaitersMatch(matchers ...interface{}) *ReservationRepo_ListWaiters {
+	c_call := _m.On("ListWaiters", matchers...)
+	return &ReservationRepo_ListWaiters{Call: c_call}
+}
+
+// ListWaiters provides a mock function with given fields: ctx, reservationKey, now
+func (_m *ReservationRepo) ListWaiters(ctx context.Context, reservationKey models.ReservationKey, now time.Time) ([]models.ReservationWaiter, error) {
+	ret := _m.Called(ctx, reservationKey, now)
+
+	var r0 []models.ReservationWaiter
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationKey, time.Time) []models.ReservationWaiter); ok {
+		r0 = rf(ctx, reservationKey, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ReservationWaiter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReservationKey, time.Time) error); ok {
+		r1 = rf(ctx, reservationKey, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type ReservationRepo_NextFencingToken struct {
+	*mock.Call
+}
+
+func (_m ReservationRepo_NextFencingToken) Return(_a0 int64, _a1 error) *ReservationRepo_NextFencingToken {
+	return &ReservationRepo_NextFencingToken{Call: _m.Call.Return(_a0, _a1)}
+}
+
+func (_m *ReservationRepo) OnNextFencingToken(ctx context.Context) *ReservationRepo_NextFencingToken {
+	c_call := _m.On("NextFencingToken", ctx)
+	return &ReservationRepo_NextFencingToken{Call: c_call}
+}
+
+func (_m *ReservationRepo) OnNextFencingTokenMatch(matchers ...interface{}) *ReservationRepo_NextFencingToken {
+	c_call := _m.On("NextFencingToken", matchers...)
+	return &ReservationRepo_NextFencingToken{Call: c_call}
+}
+
+// NextFencingToken provides a mock function with given fields: ctx
+func (_m *ReservationRepo) NextFencingToken(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type ReservationRepo_Update struct {
 	*mock.Call
 }
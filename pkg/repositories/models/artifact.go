@@ -17,6 +17,7 @@ type Artifact struct {
 	Partitions         []Partition    `gorm:"references:ArtifactID;foreignkey:ArtifactID"`
 	Tags               []Tag          `gorm:"references:ArtifactID,DatasetUUID;foreignkey:ArtifactID,DatasetUUID"`
 	SerializedMetadata []byte
+	MetadataPairs      []ArtifactMetadata `gorm:"references:ArtifactID,DatasetUUID;foreignkey:ArtifactID,DatasetUUID"`
 }
 
 type ArtifactData struct {
@@ -24,4 +25,15 @@ type ArtifactData struct {
 	ArtifactKey
 	Name     string `gorm:"primary_key"`
 	Location string
+	// The size of the stored data in bytes, zero for data stored before sizes were recorded
+	Size int64
+}
+
+// The artifacts stored by a project, used to enforce its quota. The usage is counted up when artifacts are created,
+// so that it is not recounted for every artifact.
+type ProjectUsage struct {
+	BaseModel
+	Project       string `gorm:"primary_key"`
+	ArtifactCount int64  `gorm:"not null;default:0"`
+	DataBytes     int64  `gorm:"not null;default:0"`
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
 )
@@ -17,7 +19,8 @@ type Dataset struct {
 	BaseModel
 	DatasetKey
 	SerializedMetadata []byte
-	PartitionKeys      []PartitionKey `gorm:"references:UUID;foreignkey:DatasetUUID"`
+	PartitionKeys      []PartitionKey    `gorm:"references:UUID;foreignkey:DatasetUUID"`
+	MetadataPairs      []DatasetMetadata `gorm:"references:UUID;foreignkey:DatasetUUID"`
 }
 
 type PartitionKey struct {
@@ -26,6 +29,17 @@ type PartitionKey struct {
 	Name        string `gorm:"primary_key"`
 }
 
+// Aggregated statistics of the artifacts, tags and partitions of a dataset
+type DatasetStats struct {
+	ArtifactCount           int64
+	TagCount                int64
+	PartitionValueCounts    map[string]int64
+	OldestArtifactCreatedAt *time.Time
+	NewestArtifactCreatedAt *time.Time
+	// The total size of the artifact data, as recorded when it was stored
+	StoredBytes int64
+}
+
 // BeforeCreate so that we set the UUID in golang rather than from a DB function call
 func (dataset *Dataset) BeforeCreate(tx *gorm.DB) error {
 	if dataset.UUID == "" {
@@ -0,0 +1,19 @@
+package models
+
+// The key/value pairs of an artifact's metadata. They are stored next to the serialized metadata of the artifact so
+// that artifacts can be filtered by their metadata, e.g. to find the artifact produced by a given execution.
+type ArtifactMetadata struct {
+	BaseModel
+	DatasetUUID string `gorm:"primary_key;type:uuid"`
+	ArtifactID  string `gorm:"primary_key;index"` // index for JOINs with the Artifact table when filtering
+	Key         string `gorm:"primary_key;index:artifact_metadata_key_value_idx"`
+	Value       string `gorm:"index:artifact_metadata_key_value_idx"`
+}
+
+// The key/value pairs of a dataset's metadata, stored next to the serialized metadata for filtering
+type DatasetMetadata struct {
+	BaseModel
+	DatasetUUID string `gorm:"primary_key;type:uuid"`
+	Key         string `gorm:"primary_key;index:dataset_metadata_key_value_idx"`
+	Value       string `gorm:"index:dataset_metadata_key_value_idx"`
+}
@@ -9,6 +9,8 @@ type ReservationKey struct {
 	DatasetDomain  string `gorm:"primary_key"`
 	DatasetVersion string `gorm:"primary_key"`
 	TagName        string `gorm:"primary_key"`
+	// Reservations with a capacity of more than one owner are held in independent slots, numbered from zero
+	Slot int `gorm:"primary_key;default:0"`
 }
 
 // Reservation tracks the metadata needed to allow
@@ -23,4 +25,51 @@ type Reservation struct {
 	// When the reservation will expire
 	ExpiresAt          time.Time
 	SerializedMetadata []byte
+
+	// Increases whenever the reservation is acquired by a new owner, so that writes of a previous owner can be fenced
+	FencingToken int64 `gorm:"not null;default:0"`
+
+	// When the reservation was acquired by its current owner, kept while the owner extends it
+	AcquiredAt *time.Time
+
+	// How many owners may hold the reservation in its slots, persisted when it is acquired. Zero for reservations
+	// acquired before capacities were persisted.
+	Capacity int `gorm:"not null;default:0"`
+}
+
+// ReservationWaiter is an owner queued for a reservation held by other owners. Waiters are handed the reservation in
+// the order they were queued in, so that no owner starves while others keep taking it over.
+type ReservationWaiter struct {
+	BaseModel
+	// Owners are queued for all slots of the key, so the slot is always zero
+	ReservationKey
+
+	// Identifies the queued owner
+	OwnerID string `gorm:"primary_key"`
+
+	// When the owner was queued
+	EnqueuedAt time.Time
+
+	// When the owner is dropped from the queue unless it requests the reservation again
+	ExpiresAt time.Time
+}
+
+// ReservationForceRelease records a reservation released regardless of its owner. The released reservation is
+// deleted, so who released it, when and why is kept here.
+type ReservationForceRelease struct {
+	BaseModel
+	ReservationKey
+
+	// When the reservation was released
+	ReleasedAt time.Time `gorm:"primary_key"`
+
+	// The identity of the administrator which released the reservation
+	ReleasedBy string
+
+	// Why the reservation was released
+	Reason string
+
+	// The owner which held the released reservation and when it would have expired
+	OwnerID   string
+	ExpiresAt time.Time
 }
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"github.com/flyteorg/datacatalog/pkg/repositories/errors"
 	"github.com/flyteorg/datacatalog/pkg/repositories/gormimpl"
 	"github.com/flyteorg/datacatalog/pkg/repositories/interfaces"
@@ -9,6 +11,7 @@ import (
 )
 
 type PostgresRepo struct {
+	db              *gorm.DB
 	datasetRepo     interfaces.DatasetRepo
 	artifactRepo    interfaces.ArtifactRepo
 	tagRepo         interfaces.TagRepo
@@ -31,11 +34,39 @@ func (dc *PostgresRepo) ReservationRepo() interfaces.ReservationRepo {
 	return dc.reservationRepo
 }
 
-func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, scope promutils.Scope) interfaces.DataCatalogRepo {
+func (dc *PostgresRepo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return gormimpl.Transaction(ctx, dc.db, fn)
+}
+
+// Ping checks that a connection to the database can be established
+func (dc *PostgresRepo) Ping(ctx context.Context) error {
+	return pingDB(ctx, dc.db)
+}
+
+// Close closes the connection pool of the database
+func (dc *PostgresRepo) Close() error {
+	sqlDB, err := dc.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// Check the connectivity of the database through the connection pool of gorm
+func pingDB(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func NewPostgresRepo(db *gorm.DB, errorTransformer errors.ErrorTransformer, statementTimeouts gormimpl.StatementTimeouts, scope promutils.Scope) interfaces.DataCatalogRepo {
 	return &PostgresRepo{
-		datasetRepo:     gormimpl.NewDatasetRepo(db, errorTransformer, scope.NewSubScope("dataset")),
-		artifactRepo:    gormimpl.NewArtifactRepo(db, errorTransformer, scope.NewSubScope("artifact")),
-		tagRepo:         gormimpl.NewTagRepo(db, errorTransformer, scope.NewSubScope("tag")),
-		reservationRepo: gormimpl.NewReservationRepo(db, errorTransformer, scope.NewSubScope("reservation")),
+		db:              db,
+		datasetRepo:     gormimpl.NewDatasetRepo(db, errorTransformer, statementTimeouts, scope.NewSubScope("dataset")),
+		artifactRepo:    gormimpl.NewArtifactRepo(db, errorTransformer, statementTimeouts, scope.NewSubScope("artifact")),
+		tagRepo:         gormimpl.NewTagRepo(db, errorTransformer, statementTimeouts, scope.NewSubScope("tag")),
+		reservationRepo: gormimpl.NewReservationRepo(db, errorTransformer, statementTimeouts, scope.NewSubScope("reservation")),
 	}
 }
@@ -37,6 +37,7 @@ func CreateArtifactModel(request *datacatalog.CreateArtifactRequest, artifactDat
 		ArtifactData:       artifactData,
 		SerializedMetadata: serializedMetadata,
 		Partitions:         partitions,
+		MetadataPairs:      toArtifactMetadataModels(request.Artifact.Metadata, dataset.UUID, request.Artifact.Id),
 	}, nil
 }
 
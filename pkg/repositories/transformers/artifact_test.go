@@ -82,6 +82,10 @@ func TestCreateArtifactModel(t *testing.T) {
 	assert.Equal(t, artifactModel.ArtifactKey.DatasetVersion, datasetID.Version)
 	assert.EqualValues(t, testArtifactData, artifactModel.ArtifactData)
 	assert.EqualValues(t, getTestPartitions(), artifactModel.Partitions)
+	assert.EqualValues(t, []models.ArtifactMetadata{
+		{DatasetUUID: "test-uuid", ArtifactID: "artifactID-1", Key: "testKey1", Value: "testValue1"},
+		{DatasetUUID: "test-uuid", ArtifactID: "artifactID-1", Key: "testKey2", Value: "testValue2"},
+	}, artifactModel.MetadataPairs)
 }
 
 func TestCreateArtifactModelNoMetdata(t *testing.T) {
@@ -30,6 +30,7 @@ func CreateDatasetModel(dataset *datacatalog.Dataset) (*models.Dataset, error) {
 		},
 		SerializedMetadata: serializedMetadata,
 		PartitionKeys:      partitionKeys,
+		MetadataPairs:      toDatasetMetadataModels(dataset.Metadata, dataset.Id.UUID),
 	}, nil
 }
 
@@ -64,3 +65,47 @@ func FromDatasetModel(dataset models.Dataset) (*datacatalog.Dataset, error) {
 		PartitionKeys: partitionKeyStrings,
 	}, nil
 }
+
+// Apply an update to an existing dataset model. The metadata and its key/value pairs are replaced if set. If partition
+// keys are set, the existing keys that are still present keep their order and new keys are appended in the requested
+// order.
+func UpdateDatasetModel(dataset *models.Dataset, metadata *datacatalog.Metadata, partitionKeys []string) error {
+	if metadata != nil {
+		serializedMetadata, err := marshalMetadata(metadata)
+		if err != nil {
+			return err
+		}
+		dataset.SerializedMetadata = serializedMetadata
+		dataset.MetadataPairs = toDatasetMetadataModels(metadata, dataset.UUID)
+	}
+
+	if partitionKeys == nil {
+		return nil
+	}
+
+	requestedKeys := make(map[string]bool, len(partitionKeys))
+	for _, partitionKey := range partitionKeys {
+		requestedKeys[partitionKey] = true
+	}
+
+	updatedPartitionKeys := make([]models.PartitionKey, 0, len(partitionKeys))
+	existingKeys := make(map[string]bool, len(dataset.PartitionKeys))
+	for _, partitionKey := range dataset.PartitionKeys {
+		existingKeys[partitionKey.Name] = true
+		if requestedKeys[partitionKey.Name] {
+			updatedPartitionKeys = append(updatedPartitionKeys, partitionKey)
+		}
+	}
+
+	for _, partitionKey := range partitionKeys {
+		if !existingKeys[partitionKey] {
+			updatedPartitionKeys = append(updatedPartitionKeys, models.PartitionKey{
+				DatasetUUID: dataset.UUID,
+				Name:        partitionKey,
+			})
+		}
+	}
+
+	dataset.PartitionKeys = updatedPartitionKeys
+	return nil
+}
@@ -66,6 +66,11 @@ func TestCreateDatasetModel(t *testing.T) {
 	assert.Len(t, datasetModel.PartitionKeys, 2)
 	assert.Equal(t, datasetModel.PartitionKeys[0], models.PartitionKey{Name: dataset.PartitionKeys[0]})
 	assert.Equal(t, datasetModel.PartitionKeys[1], models.PartitionKey{Name: dataset.PartitionKeys[1]})
+
+	assert.EqualValues(t, []models.DatasetMetadata{
+		{DatasetUUID: "test-uuid", Key: "testKey1", Value: "testValue1"},
+		{DatasetUUID: "test-uuid", Key: "testKey2", Value: "testValue2"},
+	}, datasetModel.MetadataPairs)
 }
 
 func TestFromDatasetID(t *testing.T) {
@@ -112,3 +117,48 @@ func TestFromDatasetModelWithPartitions(t *testing.T) {
 	assert.EqualValues(t, dataset.Metadata.KeyMap, metadata.KeyMap)
 	assert.Len(t, dataset.PartitionKeys, 2)
 }
+
+func TestUpdateDatasetModel(t *testing.T) {
+	getDatasetModel := func() *models.Dataset {
+		return &models.Dataset{
+			DatasetKey:         FromDatasetID(datasetID),
+			SerializedMetadata: []byte{},
+			PartitionKeys: []models.PartitionKey{
+				{DatasetUUID: datasetID.UUID, Name: "key1"},
+				{DatasetUUID: datasetID.UUID, Name: "key2"},
+			},
+		}
+	}
+
+	t.Run("ReplaceMetadata", func(t *testing.T) {
+		datasetModel := getDatasetModel()
+		err := UpdateDatasetModel(datasetModel, metadata, nil)
+		assert.NoError(t, err)
+
+		unmarshaledMetadata, err := unmarshalMetadata(datasetModel.SerializedMetadata)
+		assert.NoError(t, err)
+		assert.EqualValues(t, metadata.KeyMap, unmarshaledMetadata.KeyMap)
+		assert.Len(t, datasetModel.MetadataPairs, 2)
+		assert.Equal(t, []string{"key1", "key2"}, FromPartitionKeyModel(datasetModel.PartitionKeys))
+	})
+
+	t.Run("AppendAndRemovePartitionKeys", func(t *testing.T) {
+		datasetModel := getDatasetModel()
+		err := UpdateDatasetModel(datasetModel, nil, []string{"key3", "key2"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte{}, datasetModel.SerializedMetadata)
+		assert.Nil(t, datasetModel.MetadataPairs)
+		assert.Equal(t, []string{"key2", "key3"}, FromPartitionKeyModel(datasetModel.PartitionKeys))
+		for _, partitionKey := range datasetModel.PartitionKeys {
+			assert.Equal(t, datasetID.UUID, partitionKey.DatasetUUID)
+		}
+	})
+
+	t.Run("RemoveAllPartitionKeys", func(t *testing.T) {
+		datasetModel := getDatasetModel()
+		err := UpdateDatasetModel(datasetModel, nil, []string{})
+		assert.NoError(t, err)
+		assert.Len(t, datasetModel.PartitionKeys, 0)
+	})
+}
@@ -2,14 +2,17 @@ package transformers
 
 import (
 	"context"
+	"time"
 
 	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/errors"
 
 	"github.com/flyteorg/datacatalog/pkg/manager/impl/validators"
 	"github.com/flyteorg/datacatalog/pkg/repositories/gormimpl"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/flyteorg/flytestdlib/logger"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -20,15 +23,25 @@ const (
 	domainFieldName         = "domain"
 	nameFieldName           = "name"
 	versionFieldName        = "version"
+	metadataKeyFieldName    = "key"
+	metadataValueFieldName  = "value"
 )
 
+// The entities holding the metadata key/value pairs of the entities that can be listed
+var metadataEntities = map[common.Entity]common.Entity{
+	common.Artifact: common.ArtifactMetadata,
+	common.Dataset:  common.DatasetMetadata,
+}
+
 var comparisonOperatorMap = map[datacatalog.SinglePropertyFilter_ComparisonOperator]common.ComparisonOperator{
 	datacatalog.SinglePropertyFilter_EQUALS: common.Equal,
 }
 
-func FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterExpression *datacatalog.FilterExpression) (models.ListModelsInput, error) {
+// Construct the list input for the filter expression. Metadata filters, which can't be expressed in a filter
+// expression, only match entities whose metadata contains the given key/value pairs.
+func FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterExpression *datacatalog.FilterExpression, metadataFilters ...*datacatalog.KeyValuePair) (models.ListModelsInput, error) {
 	// ListInput is composed of filters and joins for multiple entities, lets construct that
-	modelFilters := make([]models.ModelFilter, 0, len(filterExpression.GetFilters()))
+	modelFilters := make([]models.ModelFilter, 0, len(filterExpression.GetFilters())+len(metadataFilters))
 
 	// Construct the ModelFilter for each PropertyFilter
 	for _, filter := range filterExpression.GetFilters() {
@@ -39,6 +52,14 @@ func FilterToListInput(ctx context.Context, sourceEntity common.Entity, filterEx
 		modelFilters = append(modelFilters, modelFilter)
 	}
 
+	for _, metadataFilter := range metadataFilters {
+		modelFilter, err := constructMetadataFilter(ctx, metadataFilter, sourceEntity)
+		if err != nil {
+			return models.ListModelsInput{}, err
+		}
+		modelFilters = append(modelFilters, modelFilter)
+	}
+
 	return models.ListModelsInput{
 		ModelFilters: modelFilters,
 	}, nil
@@ -150,3 +171,64 @@ func constructModelFilter(ctx context.Context, singleFilter *datacatalog.SingleP
 
 	return modelFilter, nil
 }
+
+func constructMetadataFilter(ctx context.Context, keyValue *datacatalog.KeyValuePair, sourceEntity common.Entity) (models.ModelFilter, error) {
+	metadataEntity, ok := metadataEntities[sourceEntity]
+	if !ok {
+		return models.ModelFilter{}, errors.NewDataCatalogErrorf(codes.InvalidArgument,
+			"metadata filters are not supported for entity [%v]", sourceEntity)
+	}
+
+	key := keyValue.GetKey()
+	value := keyValue.GetValue()
+	logger.Debugf(ctx, "Constructing metadata key:[%v], val:[%v] filter", key, value)
+	if err := validators.ValidateEmptyStringField(key, "MetadataKey"); err != nil {
+		return models.ModelFilter{}, err
+	}
+	metadataKeyFilter := gormimpl.NewGormValueFilter(common.Equal, metadataKeyFieldName, key)
+	metadataValueFilter := gormimpl.NewGormValueFilter(common.Equal, metadataValueFieldName, value)
+
+	return models.ModelFilter{
+		Entity:        metadataEntity,
+		ValueFilters:  []models.ModelValueFilter{metadataKeyFilter, metadataValueFilter},
+		JoinCondition: gormimpl.NewGormJoinCondition(sourceEntity, metadataEntity),
+	}, nil
+}
+
+// Add the time range filters to the list input. The filters are expected to be validated, entities other than the
+// source entity are joined to it.
+func ApplyTimeRangeFilters(ctx context.Context, sourceEntity common.Entity, timeRangeFilters []common.TimeRangeFilter, input *models.ListModelsInput) error {
+	for _, timeRangeFilter := range timeRangeFilters {
+		logger.Debugf(ctx, "Constructing %v time range filter on %v:[%v, %v)", timeRangeFilter.Entity,
+			timeRangeFilter.Property, timeRangeFilter.Start, timeRangeFilter.End)
+
+		modelValueFilters := make([]models.ModelValueFilter, 0, 2)
+		if timeRangeFilter.Start != "" {
+			start, err := time.Parse(time.RFC3339, timeRangeFilter.Start)
+			if err != nil {
+				return errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid time range start [%v]", timeRangeFilter.Start)
+			}
+			modelValueFilters = append(modelValueFilters,
+				gormimpl.NewGormValueFilter(common.GreaterThanOrEqual, timeRangeFilter.Property, start))
+		}
+		if timeRangeFilter.End != "" {
+			end, err := time.Parse(time.RFC3339, timeRangeFilter.End)
+			if err != nil {
+				return errors.NewDataCatalogErrorf(codes.InvalidArgument, "invalid time range end [%v]", timeRangeFilter.End)
+			}
+			modelValueFilters = append(modelValueFilters,
+				gormimpl.NewGormValueFilter(common.LessThan, timeRangeFilter.Property, end))
+		}
+
+		modelFilter := models.ModelFilter{
+			Entity:       timeRangeFilter.Entity,
+			ValueFilters: modelValueFilters,
+		}
+		if timeRangeFilter.Entity != sourceEntity {
+			modelFilter.JoinCondition = gormimpl.NewGormJoinCondition(sourceEntity, timeRangeFilter.Entity)
+		}
+		input.ModelFilters = append(input.ModelFilters, modelFilter)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package transformers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/flyteorg/datacatalog/pkg/common"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
@@ -99,3 +100,62 @@ func TestEmptyFiledListInput(t *testing.T) {
 	_, err := FilterToListInput(context.Background(), common.Artifact, filter)
 	assert.Error(t, err)
 }
+
+func TestListInputWithMetadata(t *testing.T) {
+	metadataFilters := []*datacatalog.KeyValuePair{
+		{Key: "execution_id", Value: "exec-1"},
+	}
+
+	listInput, err := FilterToListInput(context.Background(), common.Artifact, nil, metadataFilters...)
+	assert.NoError(t, err)
+	assert.Len(t, listInput.ModelFilters, 1)
+	assert.Equal(t, common.ArtifactMetadata, listInput.ModelFilters[0].Entity)
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[0], "artifact_metadata",
+		"artifact_metadata.key = ?", "execution_id")
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[1], "artifact_metadata",
+		"artifact_metadata.value = ?", "exec-1")
+	assertJoinExpression(t, listInput.ModelFilters[0].JoinCondition, "artifacts", "artifact_metadata",
+		"am0", "JOIN artifact_metadata am0 ON artifacts.artifact_id = am0.artifact_id")
+
+	listInput, err = FilterToListInput(context.Background(), common.Dataset, nil, metadataFilters...)
+	assert.NoError(t, err)
+	assert.Len(t, listInput.ModelFilters, 1)
+	assert.Equal(t, common.DatasetMetadata, listInput.ModelFilters[0].Entity)
+	assertJoinExpression(t, listInput.ModelFilters[0].JoinCondition, "datasets", "dataset_metadata",
+		"dm0", "JOIN dataset_metadata dm0 ON datasets.uuid = dm0.dataset_uuid")
+}
+
+func TestListInputWithInvalidMetadata(t *testing.T) {
+	_, err := FilterToListInput(context.Background(), common.Artifact, nil, &datacatalog.KeyValuePair{Key: "", Value: "v"})
+	assert.Error(t, err)
+
+	_, err = FilterToListInput(context.Background(), common.Tag, nil, &datacatalog.KeyValuePair{Key: "k", Value: "v"})
+	assert.Error(t, err)
+}
+
+func TestApplyTimeRangeFilters(t *testing.T) {
+	timeRangeFilters := []common.TimeRangeFilter{
+		{Entity: common.Artifact, Property: common.CreatedAt, Start: "2023-01-01T00:00:00Z", End: "2023-01-02T00:00:00Z"},
+		{Entity: common.Tag, Property: common.UpdatedAt, Start: "2023-01-01T12:00:00+02:00"},
+	}
+
+	listInput := models.ListModelsInput{}
+	err := ApplyTimeRangeFilters(context.Background(), common.Artifact, timeRangeFilters, &listInput)
+	assert.NoError(t, err)
+	assert.Len(t, listInput.ModelFilters, 2)
+
+	assert.Nil(t, listInput.ModelFilters[0].JoinCondition)
+	assert.Len(t, listInput.ModelFilters[0].ValueFilters, 2)
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[0], "artifacts",
+		"artifacts.created_at >= ?", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[1], "artifacts",
+		"artifacts.created_at < ?", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	assert.Len(t, listInput.ModelFilters[1].ValueFilters, 1)
+	expr, err := listInput.ModelFilters[1].ValueFilters[0].GetDBQueryExpression("t1")
+	assert.NoError(t, err)
+	assert.Equal(t, "t1.updated_at >= ?", expr.Query)
+	assert.True(t, time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC).Equal(expr.Args.(time.Time)))
+	assertJoinExpression(t, listInput.ModelFilters[1].JoinCondition, "artifacts", "tags",
+		"t1", "JOIN tags t1 ON artifacts.artifact_id = t1.artifact_id")
+}
@@ -1,9 +1,13 @@
 package transformers
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/flyteorg/datacatalog/pkg/common"
 	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/repositories/gormimpl"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 
@@ -30,6 +34,20 @@ func CreateReservation(reservation *models.Reservation, heartbeatInterval time.D
 		return datacatalog.Reservation{}, errors.NewDataCatalogErrorf(codes.Internal, "failed to serialize expires at time")
 	}
 
+	// the metadata supplied by the owner is returned along with the fencing token and slot
+	keyMap := make(map[string]string)
+	if len(reservation.SerializedMetadata) > 0 {
+		metadata, err := unmarshalMetadata(reservation.SerializedMetadata)
+		if err != nil {
+			return datacatalog.Reservation{}, errors.NewDataCatalogErrorf(codes.Internal, "failed to deserialize reservation metadata")
+		}
+		for key, value := range metadata.KeyMap {
+			keyMap[key] = value
+		}
+	}
+	keyMap[interfaces.ReservationMetadataFencingToken] = strconv.FormatInt(reservation.FencingToken, 10)
+	keyMap[interfaces.ReservationMetadataSlot] = strconv.Itoa(reservation.Slot)
+
 	heartbeatIntervalPb := ptypes.DurationProto(heartbeatInterval)
 	return datacatalog.Reservation{
 		ReservationId: &datacatalog.ReservationID{
@@ -44,5 +62,60 @@ func CreateReservation(reservation *models.Reservation, heartbeatInterval time.D
 		OwnerId:           reservation.OwnerID,
 		HeartbeatInterval: heartbeatIntervalPb,
 		ExpiresAt:         expiresAtPb,
+		Metadata: &datacatalog.Metadata{
+			KeyMap: keyMap,
+		},
 	}, nil
 }
+
+// Serialize the metadata supplied by the owner of a reservation, nil if none was supplied
+func SerializeReservationMetadata(metadata *datacatalog.Metadata) ([]byte, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+
+	serializedMetadata, err := marshalMetadata(metadata)
+	if err != nil {
+		return nil, errors.NewDataCatalogErrorf(codes.InvalidArgument, "failed to serialize reservation metadata")
+	}
+	return serializedMetadata, nil
+}
+
+// Construct the list input for the filters of a list reservations request. Reservations expiring after now are active,
+// all others are expired.
+func ToReservationListInput(request *interfaces.ListReservationsRequest, now time.Time) models.ListModelsInput {
+	valueFilters := make([]models.ModelValueFilter, 0, 5)
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"dataset_project", request.Project},
+		{"dataset_domain", request.Domain},
+		{"dataset_name", request.DatasetName},
+		{"owner_id", request.OwnerID},
+	} {
+		if field.value != "" {
+			valueFilters = append(valueFilters, gormimpl.NewGormValueFilter(common.Equal, field.name, field.value))
+		}
+	}
+
+	switch request.State {
+	case interfaces.ReservationStateActive:
+		valueFilters = append(valueFilters, gormimpl.NewGormValueFilter(common.GreaterThanOrEqual, "expires_at", now))
+	case interfaces.ReservationStateExpired:
+		valueFilters = append(valueFilters, gormimpl.NewGormValueFilter(common.LessThan, "expires_at", now))
+	}
+
+	if len(valueFilters) == 0 {
+		return models.ListModelsInput{}
+	}
+
+	return models.ListModelsInput{
+		ModelFilters: []models.ModelFilter{
+			{
+				Entity:       common.Reservation,
+				ValueFilters: valueFilters,
+			},
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flyteorg/datacatalog/pkg/common"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
 	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/stretchr/testify/assert"
@@ -38,9 +40,11 @@ func TestCreateReservation(t *testing.T) {
 			DatasetDomain:  "d",
 			DatasetVersion: "v",
 			TagName:        "t",
+			Slot:           2,
 		},
-		OwnerID:   "o",
-		ExpiresAt: now,
+		OwnerID:      "o",
+		ExpiresAt:    now,
+		FencingToken: 42,
 	}
 
 	reservation, err := CreateReservation(&modelReservation, heartbeatInterval)
@@ -49,6 +53,8 @@ func TestCreateReservation(t *testing.T) {
 	assert.Equal(t, reservation.ExpiresAt.AsTime(), modelReservation.ExpiresAt.UTC())
 	assert.Equal(t, reservation.HeartbeatInterval.AsDuration(), heartbeatInterval)
 	assert.Equal(t, reservation.OwnerId, modelReservation.OwnerID)
+	assert.Equal(t, "42", reservation.Metadata.KeyMap[interfaces.ReservationMetadataFencingToken])
+	assert.Equal(t, "2", reservation.Metadata.KeyMap[interfaces.ReservationMetadataSlot])
 
 	reservationID := reservation.ReservationId
 	assert.Equal(t, reservationID.TagName, modelReservation.TagName)
@@ -59,3 +65,49 @@ func TestCreateReservation(t *testing.T) {
 	assert.Equal(t, datasetID.Domain, modelReservation.DatasetDomain)
 	assert.Equal(t, datasetID.Version, modelReservation.DatasetVersion)
 }
+
+func TestCreateReservationWithMetadata(t *testing.T) {
+	serializedMetadata, err := SerializeReservationMetadata(&datacatalog.Metadata{
+		KeyMap: map[string]string{interfaces.ReservationMetadataProgress: "40"},
+	})
+	assert.NoError(t, err)
+
+	reservation, err := CreateReservation(&models.Reservation{
+		OwnerID:            "o",
+		ExpiresAt:          time.Now(),
+		SerializedMetadata: serializedMetadata,
+		FencingToken:       1,
+	}, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		interfaces.ReservationMetadataProgress:     "40",
+		interfaces.ReservationMetadataFencingToken: "1",
+		interfaces.ReservationMetadataSlot:         "0",
+	}, reservation.Metadata.KeyMap)
+
+	serializedMetadata, err = SerializeReservationMetadata(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, serializedMetadata)
+}
+
+func TestToReservationListInput(t *testing.T) {
+	now := time.Now()
+
+	listInput := ToReservationListInput(&interfaces.ListReservationsRequest{}, now)
+	assert.Len(t, listInput.ModelFilters, 0)
+
+	listInput = ToReservationListInput(&interfaces.ListReservationsRequest{
+		Project:     "p",
+		DatasetName: "n",
+		State:       interfaces.ReservationStateExpired,
+	}, now)
+	assert.Len(t, listInput.ModelFilters, 1)
+	assert.Equal(t, common.Reservation, listInput.ModelFilters[0].Entity)
+	assert.Len(t, listInput.ModelFilters[0].ValueFilters, 3)
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[0], "reservations",
+		"reservations.dataset_project = ?", "p")
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[1], "reservations",
+		"reservations.dataset_name = ?", "n")
+	assertFilterExpression(t, listInput.ModelFilters[0].ValueFilters[2], "reservations",
+		"reservations.expires_at < ?", now)
+}
@@ -1,7 +1,10 @@
 package transformers
 
 import (
+	"sort"
+
 	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/repositories/models"
 	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
@@ -23,3 +26,60 @@ func unmarshalMetadata(serializedMetadata []byte) (*datacatalog.Metadata, error)
 	err := proto.Unmarshal(serializedMetadata, &metadata)
 	return &metadata, err
 }
+
+// Get the keys of the metadata key map in a stable order, so the metadata models are always created in the same order
+func sortedMetadataKeys(metadata *datacatalog.Metadata) []string {
+	keys := make([]string, 0, len(metadata.GetKeyMap()))
+	for key := range metadata.GetKeyMap() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toArtifactMetadataModels(metadata *datacatalog.Metadata, datasetUUID string, artifactID string) []models.ArtifactMetadata {
+	keys := sortedMetadataKeys(metadata)
+	metadataModels := make([]models.ArtifactMetadata, len(keys))
+	for i, key := range keys {
+		metadataModels[i] = models.ArtifactMetadata{
+			DatasetUUID: datasetUUID,
+			ArtifactID:  artifactID,
+			Key:         key,
+			Value:       metadata.GetKeyMap()[key],
+		}
+	}
+	return metadataModels
+}
+
+func toDatasetMetadataModels(metadata *datacatalog.Metadata, datasetUUID string) []models.DatasetMetadata {
+	keys := sortedMetadataKeys(metadata)
+	metadataModels := make([]models.DatasetMetadata, len(keys))
+	for i, key := range keys {
+		metadataModels[i] = models.DatasetMetadata{
+			DatasetUUID: datasetUUID,
+			Key:         key,
+			Value:       metadata.GetKeyMap()[key],
+		}
+	}
+	return metadataModels
+}
+
+// DeserializeArtifactMetadataModels creates the metadata models of an artifact stored before they were created from
+// its serialized metadata
+func DeserializeArtifactMetadataModels(artifact models.Artifact) ([]models.ArtifactMetadata, error) {
+	metadata, err := unmarshalMetadata(artifact.SerializedMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return toArtifactMetadataModels(metadata, artifact.DatasetUUID, artifact.ArtifactID), nil
+}
+
+// DeserializeDatasetMetadataModels creates the metadata models of a dataset stored before they were created from its
+// serialized metadata
+func DeserializeDatasetMetadataModels(dataset models.Dataset) ([]models.DatasetMetadata, error) {
+	metadata, err := unmarshalMetadata(dataset.SerializedMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return toDatasetMetadataModels(metadata, dataset.UUID), nil
+}
@@ -0,0 +1,51 @@
+package datacatalogservice
+
+import (
+	"crypto"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/config"
+)
+
+// Create the chain of authenticators for the configured credentials. Explicit credentials take precedence over the
+// client certificate of the connection, e.g. for callers sharing a proxy.
+func newAuthenticators(cfg *config.Config) ([]auth.Authenticator, error) {
+	var authenticators []auth.Authenticator
+
+	keys := make(map[string]crypto.PublicKey)
+	if cfg.Auth.JWKSFile != "" {
+		jwks, err := auth.LoadJWKS(cfg.Auth.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range jwks {
+			keys[kid] = key
+		}
+	}
+	if len(cfg.Auth.PublicKeyFiles) > 0 {
+		publicKeys, err := auth.LoadPublicKeys(cfg.Auth.PublicKeyFiles)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range publicKeys {
+			keys[kid] = key
+		}
+	}
+	if len(keys) > 0 {
+		authenticators = append(authenticators, auth.NewJWTAuthenticator(keys, cfg.Auth.Issuer, cfg.Auth.Audience, cfg.Auth.IdentityClaim))
+	}
+
+	if cfg.Auth.APIKeysFile != "" {
+		keyHashes, err := auth.LoadAPIKeyHashes(cfg.Auth.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, auth.NewAPIKeyAuthenticator(keyHashes))
+	}
+
+	if cfg.Secure && cfg.TLS.ClientCAFile != "" {
+		authenticators = append(authenticators, auth.ClientCertificateAuthenticator{})
+	}
+
+	return authenticators, nil
+}
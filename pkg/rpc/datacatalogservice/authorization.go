@@ -0,0 +1,85 @@
+package datacatalogservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+)
+
+func datasetResource(datasetID *catalog.DatasetID, operations ...auth.Operation) (auth.Resource, bool) {
+	return auth.Resource{
+		Project:    datasetID.GetProject(),
+		Domain:     datasetID.GetDomain(),
+		Operations: operations,
+	}, true
+}
+
+// Get the project and domain of the datasets listed by the filter, empty if the datasets are not filtered by them
+func listedDatasetsResource(filter *catalog.FilterExpression) (auth.Resource, bool) {
+	resource := auth.Resource{Operations: []auth.Operation{auth.OperationRead}}
+	for _, singleFilter := range filter.GetFilters() {
+		if singleFilter.GetOperator() != catalog.SinglePropertyFilter_EQUALS {
+			continue
+		}
+
+		datasetFilter := singleFilter.GetDatasetFilter()
+		switch datasetFilter.GetProperty().(type) {
+		case *catalog.DatasetPropertyFilter_Project:
+			resource.Project = datasetFilter.GetProject()
+		case *catalog.DatasetPropertyFilter_Domain:
+			resource.Domain = datasetFilter.GetDomain()
+		}
+	}
+	return resource, true
+}
+
+// Get the tenant sending the request, identified by the project and domain it accesses and the identity of the caller
+func getRateLimitKey(ctx context.Context, req interface{}) string {
+	resource, _ := getRequestResource(req)
+	return fmt.Sprintf("%s/%s/%s", resource.Project, resource.Domain, auth.IdentityFromContext(ctx))
+}
+
+// Get the project and domain the request accesses and the operations it performs, so that it can be authorized
+func getRequestResource(req interface{}) (auth.Resource, bool) {
+	switch request := req.(type) {
+	case *catalog.CreateDatasetRequest:
+		return datasetResource(request.GetDataset().GetId(), auth.OperationWrite)
+	case *catalog.GetDatasetRequest:
+		return datasetResource(request.GetDataset(), auth.OperationRead)
+	case *catalog.ListDatasetsRequest:
+		return listedDatasetsResource(request.GetFilter())
+	case *catalog.CreateArtifactRequest:
+		return datasetResource(request.GetArtifact().GetDataset(), auth.OperationWrite)
+	case *catalog.GetArtifactRequest:
+		return datasetResource(request.GetDataset(), auth.OperationRead)
+	case *catalog.ListArtifactsRequest:
+		return datasetResource(request.GetDataset(), auth.OperationRead)
+	case *catalog.UpdateArtifactRequest:
+		return datasetResource(request.GetDataset(), auth.OperationWrite)
+	case *catalog.AddTagRequest:
+		return datasetResource(request.GetTag().GetDataset(), auth.OperationTag)
+	case *catalog.GetOrExtendReservationRequest:
+		return datasetResource(request.GetReservationId().GetDatasetId(), auth.OperationReserve)
+	case *catalog.ReleaseReservationRequest:
+		return datasetResource(request.GetReservationId().GetDatasetId(), auth.OperationReserve)
+	case *interfaces.UpdateDatasetRequest:
+		return datasetResource(request.Dataset, auth.OperationWrite)
+	case *interfaces.GetDatasetStatsRequest:
+		return datasetResource(request.Dataset, auth.OperationRead)
+	case *interfaces.SearchArtifactsRequest:
+		return auth.Resource{Project: request.Project, Domain: request.Domain, Operations: []auth.Operation{auth.OperationRead}}, true
+	case *interfaces.ListReservationsRequest:
+		return auth.Resource{Project: request.Project, Domain: request.Domain, Operations: []auth.Operation{auth.OperationReserve}}, true
+	case *interfaces.ForceReleaseReservationRequest:
+		return datasetResource(request.ReservationID.GetDatasetId(), auth.OperationAdmin)
+	case *interfaces.WaitForArtifactRequest:
+		return datasetResource(request.ReservationID.GetDatasetId(), auth.OperationRead)
+	case *interfaces.CompleteReservationRequest:
+		return datasetResource(request.ReservationID.GetDatasetId(), auth.OperationWrite, auth.OperationTag, auth.OperationReserve)
+	default:
+		return auth.Resource{}, false
+	}
+}
@@ -0,0 +1,69 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGetRequestResource(t *testing.T) {
+	datasetID := &catalog.DatasetID{Project: "team-a", Domain: "production", Name: "name", Version: "version"}
+	reservationID := &catalog.ReservationID{DatasetId: datasetID, TagName: "tag"}
+
+	for name, testCase := range map[string]struct {
+		request    interface{}
+		operations []auth.Operation
+	}{
+		"create artifact": {&catalog.CreateArtifactRequest{Artifact: &catalog.Artifact{Dataset: datasetID}}, []auth.Operation{auth.OperationWrite}},
+		"get artifact":    {&catalog.GetArtifactRequest{Dataset: datasetID}, []auth.Operation{auth.OperationRead}},
+		"add tag":         {&catalog.AddTagRequest{Tag: &catalog.Tag{Dataset: datasetID}}, []auth.Operation{auth.OperationTag}},
+		"reserve":         {&catalog.GetOrExtendReservationRequest{ReservationId: reservationID}, []auth.Operation{auth.OperationReserve}},
+		"force release":   {&interfaces.ForceReleaseReservationRequest{ReservationID: reservationID}, []auth.Operation{auth.OperationAdmin}},
+		"complete reservation": {&interfaces.CompleteReservationRequest{ReservationID: reservationID},
+			[]auth.Operation{auth.OperationWrite, auth.OperationTag, auth.OperationReserve}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource, ok := getRequestResource(testCase.request)
+			assert.True(t, ok)
+			assert.Equal(t, auth.Resource{Project: "team-a", Domain: "production", Operations: testCase.operations}, resource)
+		})
+	}
+
+	t.Run("list datasets", func(t *testing.T) {
+		resource, ok := getRequestResource(&catalog.ListDatasetsRequest{Filter: &catalog.FilterExpression{
+			Filters: []*catalog.SinglePropertyFilter{{
+				PropertyFilter: &catalog.SinglePropertyFilter_DatasetFilter{
+					DatasetFilter: &catalog.DatasetPropertyFilter{Property: &catalog.DatasetPropertyFilter_Project{Project: "team-a"}},
+				},
+			}},
+		}})
+		assert.True(t, ok)
+		// listing the datasets of all domains requires access to all of them
+		assert.Equal(t, auth.Resource{Project: "team-a", Operations: []auth.Operation{auth.OperationRead}}, resource)
+	})
+
+	t.Run("missing dataset", func(t *testing.T) {
+		resource, ok := getRequestResource(&catalog.GetDatasetRequest{})
+		assert.True(t, ok)
+		assert.Empty(t, resource.Project)
+	})
+
+	t.Run("other service", func(t *testing.T) {
+		_, ok := getRequestResource(&grpc_health_v1.HealthCheckRequest{})
+		assert.False(t, ok)
+	})
+}
+
+func TestGetRateLimitKey(t *testing.T) {
+	datasetID := &catalog.DatasetID{Project: "team-a", Domain: "production", Name: "name", Version: "version"}
+	request := &catalog.CreateArtifactRequest{Artifact: &catalog.Artifact{Dataset: datasetID}}
+
+	ctx := auth.WithIdentity(context.Background(), "svc")
+	assert.Equal(t, "team-a/production/svc", getRateLimitKey(ctx, request))
+	assert.Equal(t, "team-a/production/", getRateLimitKey(context.Background(), request))
+}
@@ -0,0 +1,50 @@
+package datacatalogservice
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Bounds requests by the timeout of their method, identified by its case-insensitive name, or the default timeout,
+// unless the caller set an earlier deadline. Requests are not bound by the server if their timeout is zero.
+// Requests failing once their deadline passed fail with DeadlineExceeded, whichever error their database queries or
+// storage reads returned, and are counted by the counter.
+func deadlineInterceptor(defaultTimeout time.Duration, timeouts map[string]time.Duration, timedOut *prometheus.CounterVec) grpc.UnaryServerInterceptor {
+	methodTimeouts := make(map[string]time.Duration, len(timeouts))
+	for method, timeout := range timeouts {
+		methodTimeouts[strings.ToLower(method)] = timeout
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := path.Base(info.FullMethod)
+		timeout, ok := methodTimeouts[strings.ToLower(method)]
+		if !ok {
+			timeout = defaultTimeout
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil || (ctx.Err() != context.DeadlineExceeded && status.Code(err) != codes.DeadlineExceeded) {
+			return resp, err
+		}
+
+		timedOut.WithLabelValues(method).Inc()
+		if status.Code(err) != codes.DeadlineExceeded {
+			logger.Debugf(ctx, "Request to %s failed after its deadline: %v", method, err)
+			err = status.Errorf(codes.DeadlineExceeded, "deadline exceeded serving %s", method)
+		}
+		return nil, err
+	}
+}
@@ -0,0 +1,88 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlineInterceptor(t *testing.T) {
+	getInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	listInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/ListArtifacts"}
+	addTagInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/AddTag"}
+	timeouts := map[string]time.Duration{"listartifacts": time.Hour, "AddTag": 0}
+
+	t.Run("Timeout of the method", func(t *testing.T) {
+		interceptor := deadlineInterceptor(time.Minute, timeouts,
+			promutils.NewTestScope().MustNewCounterVec("request_timeouts", "", "method"))
+
+		for info, expected := range map[*grpc.UnaryServerInfo]time.Duration{getInfo: time.Minute, listInfo: time.Hour} {
+			_, err := interceptor(context.Background(), &catalog.GetArtifactRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				deadline, ok := ctx.Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(expected), deadline, time.Second)
+				return &catalog.GetArtifactResponse{}, nil
+			})
+			assert.NoError(t, err)
+		}
+
+		_, err := interceptor(context.Background(), &catalog.AddTagRequest{}, addTagInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			return &catalog.AddTagResponse{}, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Earlier deadline of the caller", func(t *testing.T) {
+		interceptor := deadlineInterceptor(time.Minute, timeouts,
+			promutils.NewTestScope().MustNewCounterVec("request_timeouts", "", "method"))
+		callerCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		callerDeadline, _ := callerCtx.Deadline()
+
+		_, err := interceptor(callerCtx, &catalog.GetArtifactRequest{}, getInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			deadline, _ := ctx.Deadline()
+			assert.Equal(t, callerDeadline, deadline)
+			return &catalog.GetArtifactResponse{}, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Deadline exceeded", func(t *testing.T) {
+		timedOut := promutils.NewTestScope().MustNewCounterVec("request_timeouts", "", "method")
+		interceptor := deadlineInterceptor(time.Millisecond, timeouts, timedOut)
+
+		// the error of the query cancelled by the deadline is replaced
+		resp, err := interceptor(context.Background(), &catalog.GetArtifactRequest{}, getInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return &catalog.GetArtifactResponse{}, errors.NewDataCatalogError(codes.Internal, "query cancelled")
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+		// statement timeouts already fail with DeadlineExceeded
+		_, err = interceptor(context.Background(), &catalog.ListArtifactsRequest{}, listInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.DeadlineExceeded, "database operation timed out")
+		})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Equal(t, "database operation timed out", status.Convert(err).Message())
+
+		_, err = interceptor(context.Background(), &catalog.ListArtifactsRequest{}, listInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.NotFound, "not found")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(timedOut.WithLabelValues("GetArtifact")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(timedOut.WithLabelValues("ListArtifacts")))
+	})
+}
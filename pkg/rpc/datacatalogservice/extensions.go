@@ -0,0 +1,79 @@
+package datacatalogservice
+
+import (
+	"context"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// The gRPC service serving the methods of the DataCatalogService which are not part of the flyteidl DataCatalog
+// service. Their requests and responses are not protobuf messages, so they are encoded as JSON like in the JSON API
+// and must be called with the json content subtype, i.e. the content type application/grpc+json.
+const extensionsServiceName = "datacatalog.v1.DataCatalogExtensions"
+
+// The content subtype of the requests of the DataCatalogExtensions service
+const jsonCodecName = "json"
+
+// Encodes the messages of the DataCatalogExtensions service, any protobuf messages with protojson
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return marshalGatewayJSON(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return unmarshalGatewayJSON(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return jsonCodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// Create the description of the DataCatalogExtensions service, serving the methods of the service whose requests are
+// not flyteidl messages. The requests pass through the interceptors of the server like those of the DataCatalog
+// service.
+func newExtensionsServiceDesc(service *DataCatalogService) *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: extensionsServiceName,
+		HandlerType: (*interface{})(nil),
+		Metadata:    "datacatalog/extensions",
+	}
+
+	for name, method := range getServiceMethods(service) {
+		if isProtoMessage(method.requestType) {
+			continue
+		}
+
+		fullMethod := "/" + extensionsServiceName + "/" + name
+		method := method
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: name,
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := method.newRequest()
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return method.invoke(ctx, request)
+				}
+
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+				return interceptor(ctx, request, info, method.invoke)
+			},
+		})
+	}
+
+	sort.Slice(desc.Methods, func(i, j int) bool {
+		return desc.Methods[i].MethodName < desc.Methods[j].MethodName
+	})
+	return desc
+}
@@ -0,0 +1,84 @@
+package datacatalogservice
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/manager/mocks"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewExtensionsServiceDesc(t *testing.T) {
+	desc := newExtensionsServiceDesc(&DataCatalogService{})
+
+	var methods []string
+	for _, method := range desc.Methods {
+		methods = append(methods, method.MethodName)
+	}
+	// the methods of the flyteidl DataCatalog service are not served twice
+	assert.Equal(t, []string{"CompleteReservation", "ForceReleaseReservation", "GetDatasetStats", "ListReservations",
+		"SearchArtifacts", "UpdateDataset", "WaitForArtifact"}, methods)
+}
+
+func TestExtensionsService(t *testing.T) {
+	reservationID := &catalog.ReservationID{
+		DatasetId: &catalog.DatasetID{Project: "team-a", Domain: "production", Name: "name", Version: "version"},
+		TagName:   "cache-key",
+	}
+	reservationManager := &mocks.ReservationManager{}
+	reservationManager.OnListReservationsMatch(mock.Anything, mock.MatchedBy(func(request *interfaces.ListReservationsRequest) bool {
+		return request.OwnerID == "owner"
+	})).Return(&interfaces.ListReservationsResponse{
+		Reservations: []*catalog.Reservation{{ReservationId: reservationID, OwnerId: "owner"}},
+		NextToken:    "1",
+	}, nil)
+	reservationManager.OnWaitForArtifactMatch(mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+	service := &DataCatalogService{ReservationManager: reservationManager}
+
+	var fullMethods []string
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		fullMethods = append(fullMethods, info.FullMethod)
+		return handler(ctx, req)
+	}))
+	server.RegisterService(newExtensionsServiceDesc(service), service)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(jsonCodecName)))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("call", func(t *testing.T) {
+		response := &interfaces.ListReservationsResponse{}
+		err := conn.Invoke(context.Background(), "/"+extensionsServiceName+"/ListReservations",
+			&interfaces.ListReservationsRequest{OwnerID: "owner"}, response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Reservations, 1)
+		assert.Equal(t, "cache-key", response.Reservations[0].ReservationId.TagName)
+		assert.Equal(t, "1", response.NextToken)
+		assert.Contains(t, fullMethods, "/"+extensionsServiceName+"/ListReservations")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := conn.Invoke(context.Background(), "/"+extensionsServiceName+"/WaitForArtifact",
+			&interfaces.WaitForArtifactRequest{ReservationID: reservationID}, &interfaces.WaitForArtifactResponse{})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("flyteidl method", func(t *testing.T) {
+		err := conn.Invoke(context.Background(), "/"+extensionsServiceName+"/GetDataset",
+			&catalog.GetDatasetRequest{}, &catalog.GetDatasetResponse{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
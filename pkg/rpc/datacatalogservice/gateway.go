@@ -0,0 +1,220 @@
+package datacatalogservice
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// The prefix of the JSON API, which serves every method of the DataCatalogService at POST <prefix><method name>
+const gatewayPathPrefix = "/api/v1/"
+
+// The gRPC service the methods of the JSON API are attributed to, so that they are authorized and rate limited like
+// the gRPC methods of the same name
+const gatewayServiceName = "/" + catalogServiceName + "/"
+
+// The maximum size of request bodies, same as the default maximum size of gRPC messages
+const maxGatewayRequestBytes = 4 << 20
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// A method of the DataCatalogService which takes a context and a request and returns a response and an error
+type serviceMethod struct {
+	requestType reflect.Type
+	call        reflect.Value
+}
+
+// Create an empty request of the method to decode the request into
+func (m serviceMethod) newRequest() interface{} {
+	return reflect.New(m.requestType.Elem()).Interface()
+}
+
+// Call the method with the request, as the handler of the interceptors
+func (m serviceMethod) invoke(ctx context.Context, request interface{}) (interface{}, error) {
+	results := m.call.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(request)})
+	if err, _ := results[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	return results[0].Interface(), nil
+}
+
+// The gateway serves the DataCatalogService as JSON over HTTP. Requests pass through the same interceptors as gRPC
+// requests, with the HTTP headers except the trusted admin and identity headers as incoming metadata.
+type gateway struct {
+	methods      map[string]serviceMethod
+	interceptors []grpc.UnaryServerInterceptor
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	methodName := strings.TrimPrefix(r.URL.Path, gatewayPathPrefix)
+	method, ok := g.methods[methodName]
+	if !ok {
+		writeGatewayError(w, status.Errorf(codes.Unimplemented, "unknown method %s", methodName))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGatewayRequestBytes))
+	if err != nil {
+		writeGatewayError(w, status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err))
+		return
+	}
+
+	request := method.newRequest()
+	if len(body) > 0 {
+		if err := unmarshalGatewayJSON(body, request); err != nil {
+			writeGatewayError(w, status.Errorf(codes.InvalidArgument, "invalid %s request: %v", methodName, err))
+			return
+		}
+	}
+
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		md.Append(key, values...)
+	}
+	// the admin and identity headers are only trusted on gRPC requests, callers of the JSON API must authenticate
+	md.Delete(auth.AdminHeader)
+	md.Delete(auth.IdentityHeader)
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	// callers presenting a client certificate are authenticated by it, like gRPC callers
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+	}
+
+	info := &grpc.UnaryServerInfo{Server: g, FullMethod: gatewayServiceName + methodName}
+	handler := grpc.UnaryHandler(method.invoke)
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	response, err := handler(ctx, request)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	responseBody, err := marshalGatewayJSON(response)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to marshal %s response, err: %v", methodName, err)
+		writeGatewayError(w, status.Errorf(codes.Internal, "failed to marshal response: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(responseBody)
+}
+
+// Write the status of the error as JSON with the HTTP status corresponding to its code. Clients are told when to retry
+// requests rejected by rate limits.
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			retryAfter := math.Ceil(retryInfo.RetryDelay.AsDuration().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+		}
+	}
+
+	body, marshalErr := protojson.Marshal(st.Proto())
+	if marshalErr != nil {
+		body = []byte(`{"message":"failed to marshal error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_, _ = w.Write(body)
+}
+
+// Map the gRPC status codes to the HTTP status codes, as specified in google/rpc/code.proto
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Create the JSON API of the service, serving all of its methods
+func newGateway(service *DataCatalogService, interceptors []grpc.UnaryServerInterceptor) *gateway {
+	return &gateway{
+		methods:      getServiceMethods(service),
+		interceptors: interceptors,
+	}
+}
+
+// Get the exported methods of the service which take a context and a request and return a response and an error, by
+// name
+func getServiceMethods(service *DataCatalogService) map[string]serviceMethod {
+	methods := make(map[string]serviceMethod)
+	serviceValue := reflect.ValueOf(service)
+	serviceType := serviceValue.Type()
+	for i := 0; i < serviceType.NumMethod(); i++ {
+		method := serviceType.Method(i)
+		methodType := method.Func.Type()
+		if methodType.NumIn() != 3 || methodType.In(1) != contextType || methodType.In(2).Kind() != reflect.Ptr ||
+			methodType.NumOut() != 2 || methodType.Out(1) != errorType {
+			continue
+		}
+
+		methods[method.Name] = serviceMethod{
+			requestType: methodType.In(2),
+			call:        serviceValue.Method(i),
+		}
+	}
+
+	return methods
+}
+
+// Protobuf messages of the flyteidl DataCatalog service are encoded with protojson, others with encoding/json
+func isProtoMessage(t reflect.Type) bool {
+	return t.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem())
+}
@@ -0,0 +1,161 @@
+package datacatalogservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	gatewayMarshaler   = protojson.MarshalOptions{}
+	gatewayUnmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Decode the JSON request. The requests of methods which are not part of the flyteidl DataCatalog service are structs
+// embedding protobuf messages, which are decoded with protojson so that they are encoded the same in every request.
+func unmarshalGatewayJSON(data []byte, v interface{}) error {
+	if message, ok := v.(proto.Message); ok {
+		return gatewayUnmarshaler.Unmarshal(data, proto.MessageV2(message))
+	}
+
+	value := reflect.ValueOf(v).Elem()
+	if value.Kind() != reflect.Struct {
+		return json.Unmarshal(data, v)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		name, _, ok := jsonFieldName(value.Type().Field(i))
+		if !ok {
+			continue
+		}
+		raw, ok := fields[name]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+		if err := unmarshalGatewayField(raw, value.Field(i)); err != nil {
+			return fmt.Errorf("invalid field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalGatewayField(raw json.RawMessage, field reflect.Value) error {
+	switch {
+	case isProtoMessage(field.Type()):
+		message := reflect.New(field.Type().Elem())
+		if err := gatewayUnmarshaler.Unmarshal(raw, proto.MessageV2(message.Interface())); err != nil {
+			return err
+		}
+		field.Set(message)
+	case field.Kind() == reflect.Slice && isProtoMessage(field.Type().Elem()):
+		var elements []json.RawMessage
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(field.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := unmarshalGatewayField(element, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	default:
+		return json.Unmarshal(raw, field.Addr().Interface())
+	}
+	return nil
+}
+
+// Encode the response as JSON, the counterpart of unmarshalGatewayJSON
+func marshalGatewayJSON(v interface{}) ([]byte, error) {
+	if message, ok := v.(proto.Message); ok {
+		return gatewayMarshaler.Marshal(proto.MessageV2(message))
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return json.Marshal(v)
+	}
+
+	fields := make(map[string]json.RawMessage, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		name, omitEmpty, ok := jsonFieldName(value.Type().Field(i))
+		if !ok || (omitEmpty && isEmptyJSONValue(value.Field(i))) {
+			continue
+		}
+		raw, err := marshalGatewayField(value.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s: %w", name, err)
+		}
+		fields[name] = raw
+	}
+	return json.Marshal(fields)
+}
+
+func marshalGatewayField(field reflect.Value) (json.RawMessage, error) {
+	switch {
+	case isProtoMessage(field.Type()):
+		if field.IsNil() {
+			return json.RawMessage("null"), nil
+		}
+		return gatewayMarshaler.Marshal(proto.MessageV2(field.Interface()))
+	case field.Kind() == reflect.Slice && isProtoMessage(field.Type().Elem()):
+		elements := make([]json.RawMessage, field.Len())
+		for i := range elements {
+			element, err := marshalGatewayField(field.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return json.Marshal(elements)
+	default:
+		return json.Marshal(field.Interface())
+	}
+}
+
+// Whether encoding/json considers the value empty, omitting it from the output if tagged with omitempty
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// Get the JSON name of the exported struct field and whether it is omitted if empty
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
@@ -0,0 +1,225 @@
+package datacatalogservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/manager/mocks"
+	"github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/core"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func serveGatewayRequest(gateway http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Authorization", "Bearer token")
+	request.Header.Set("Datacatalog-Admin", "true")
+	request.Header.Set("Datacatalog-Identity", "svc")
+	gateway.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGateway(t *testing.T) {
+	datasetID := &catalog.DatasetID{Project: "team-a", Domain: "production", Name: "name", Version: "version"}
+
+	t.Run("proto request", func(t *testing.T) {
+		datasetManager := &mocks.DatasetManager{}
+		datasetManager.OnGetDatasetMatch(mock.Anything, mock.MatchedBy(func(request *catalog.GetDatasetRequest) bool {
+			return proto.Equal(request.Dataset, datasetID)
+		})).Return(&catalog.GetDatasetResponse{Dataset: &catalog.Dataset{Id: datasetID, PartitionKeys: []string{"region"}}}, nil)
+
+		gateway := newGateway(&DataCatalogService{DatasetManager: datasetManager}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/GetDataset",
+			`{"dataset": {"project": "team-a", "domain": "production", "name": "name", "version": "version"}}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"dataset": {"id": {"project": "team-a", "domain": "production", "name": "name", "version": "version"},
+			"partitionKeys": ["region"]}}`, recorder.Body.String())
+	})
+
+	t.Run("struct request", func(t *testing.T) {
+		artifactManager := &mocks.ArtifactManager{}
+		artifactManager.OnCompleteReservationMatch(mock.Anything, mock.MatchedBy(func(request *interfaces.CompleteReservationRequest) bool {
+			return request.OwnerID == "owner" && request.ReservationID.TagName == "cache-key" &&
+				request.Artifact.Data[0].Value.GetScalar().GetPrimitive().GetInteger() == 42 &&
+				len(request.TagNames) == 1
+		})).Return(&interfaces.CompleteReservationResponse{TagNames: []string{"cache-key", "latest"}}, nil)
+
+		gateway := newGateway(&DataCatalogService{ArtifactManager: artifactManager}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/CompleteReservation", `{
+			"reservationId": {"datasetId": {"project": "team-a", "domain": "production", "name": "name", "version": "version"}, "tagName": "cache-key"},
+			"ownerId": "owner",
+			"artifact": {"id": "id", "data": [{"name": "out", "value": {"scalar": {"primitive": {"integer": "42"}}}}]},
+			"tagNames": ["latest"]}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"tagNames": ["cache-key", "latest"]}`, recorder.Body.String())
+	})
+
+	t.Run("struct response with proto messages", func(t *testing.T) {
+		artifactManager := &mocks.ArtifactManager{}
+		artifactManager.OnSearchArtifactsMatch(mock.Anything, mock.Anything).Return(&interfaces.SearchArtifactsResponse{
+			Artifacts: []*catalog.Artifact{{Id: "id", Dataset: datasetID, Data: []*catalog.ArtifactData{{
+				Name: "out", Value: &core.Literal{Value: &core.Literal_Scalar{Scalar: &core.Scalar{
+					Value: &core.Scalar_Primitive{Primitive: &core.Primitive{Value: &core.Primitive_Integer{Integer: 42}}}}}},
+			}}}},
+		}, nil)
+
+		gateway := newGateway(&DataCatalogService{ArtifactManager: artifactManager}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/SearchArtifacts", `{"project": "team-a", "domain": "production"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"artifacts": [{"id": "id",
+			"dataset": {"project": "team-a", "domain": "production", "name": "name", "version": "version"},
+			"data": [{"name": "out", "value": {"scalar": {"primitive": {"integer": "42"}}}}]}],
+			"nextToken": ""}`, recorder.Body.String())
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		datasetManager := &mocks.DatasetManager{}
+		datasetManager.OnListDatasetsMatch(mock.Anything, mock.Anything).Return(&catalog.ListDatasetsResponse{}, nil)
+
+		gateway := newGateway(&DataCatalogService{DatasetManager: datasetManager}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/ListDatasets", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{}`, recorder.Body.String())
+	})
+
+	t.Run("interceptors", func(t *testing.T) {
+		datasetManager := &mocks.DatasetManager{}
+		datasetManager.OnGetDatasetMatch(mock.Anything, mock.Anything).Return(&catalog.GetDatasetResponse{}, nil)
+
+		var calls []string
+		newInterceptor := func(name string) grpc.UnaryServerInterceptor {
+			return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+				// the trusted headers are not forwarded
+				assert.Empty(t, md.Get("datacatalog-admin"))
+				assert.Empty(t, md.Get("datacatalog-identity"))
+				_, ok := peer.FromContext(ctx)
+				assert.False(t, ok)
+				assert.Equal(t, "/flyteidl.datacatalog.DataCatalog/GetDataset", info.FullMethod)
+				calls = append(calls, name)
+				return handler(ctx, req)
+			}
+		}
+
+		gateway := newGateway(&DataCatalogService{DatasetManager: datasetManager},
+			[]grpc.UnaryServerInterceptor{newInterceptor("first"), newInterceptor("second")})
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/GetDataset", `{}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		datasetManager := &mocks.DatasetManager{}
+		datasetManager.OnGetDatasetMatch(mock.Anything, mock.Anything).Return(&catalog.GetDatasetResponse{}, nil)
+
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "svc"}}
+		var identity string
+		authenticate := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			identity, _ = auth.ClientCertificateAuthenticator{}.Authenticate(ctx)
+			return handler(ctx, req)
+		}
+
+		gateway := newGateway(&DataCatalogService{DatasetManager: datasetManager}, []grpc.UnaryServerInterceptor{authenticate})
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/GetDataset", strings.NewReader(`{}`))
+		request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		recorder := httptest.NewRecorder()
+		gateway.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "svc", identity)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		datasetManager := &mocks.DatasetManager{}
+		datasetManager.OnGetDatasetMatch(mock.Anything, mock.Anything).
+			Return(nil, errors.NewDataCatalogError(codes.NotFound, "dataset not found"))
+
+		gateway := newGateway(&DataCatalogService{DatasetManager: datasetManager}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/GetDataset", `{}`)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.JSONEq(t, `{"code": 5, "message": "dataset not found"}`, recorder.Body.String())
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		limited := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+				WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)})
+			assert.NoError(t, err)
+			return nil, st.Err()
+		}
+
+		gateway := newGateway(&DataCatalogService{}, []grpc.UnaryServerInterceptor{limited})
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/CreateArtifact", `{}`)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Len(t, body["details"], 1)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		gateway := newGateway(&DataCatalogService{}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/GetDataset", `{"dataset": 1}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		gateway := newGateway(&DataCatalogService{}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodPost, "/api/v1/DeleteDataset", `{}`)
+		assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		gateway := newGateway(&DataCatalogService{}, nil)
+		recorder := serveGatewayRequest(gateway, http.MethodGet, "/api/v1/GetDataset", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, http.MethodPost, recorder.Header().Get("Allow"))
+	})
+}
+
+func TestNewGateway(t *testing.T) {
+	gateway := newGateway(&DataCatalogService{}, nil)
+
+	// every method of the service is served, including the ones not part of the flyteidl DataCatalog service
+	for _, method := range []string{"CreateDataset", "GetDataset", "UpdateDataset", "GetDatasetStats", "ListDatasets",
+		"CreateArtifact", "GetArtifact", "ListArtifacts", "UpdateArtifact", "SearchArtifacts", "CompleteReservation",
+		"AddTag", "GetOrExtendReservation", "ReleaseReservation", "ListReservations", "WaitForArtifact",
+		"ForceReleaseReservation"} {
+		assert.Contains(t, gateway.methods, method)
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	assert.Equal(t, http.StatusOK, httpStatusFromCode(codes.OK))
+	assert.Equal(t, http.StatusBadRequest, httpStatusFromCode(codes.InvalidArgument))
+	assert.Equal(t, http.StatusConflict, httpStatusFromCode(codes.AlreadyExists))
+	assert.Equal(t, http.StatusPreconditionFailed, httpStatusFromCode(codes.FailedPrecondition))
+	assert.Equal(t, http.StatusUnauthorized, httpStatusFromCode(codes.Unauthenticated))
+	assert.Equal(t, http.StatusForbidden, httpStatusFromCode(codes.PermissionDenied))
+	assert.Equal(t, http.StatusTooManyRequests, httpStatusFromCode(codes.ResourceExhausted))
+	assert.Equal(t, http.StatusInternalServerError, httpStatusFromCode(codes.Internal))
+	assert.Equal(t, http.StatusInternalServerError, httpStatusFromCode(codes.Unknown))
+}
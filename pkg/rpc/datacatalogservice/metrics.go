@@ -0,0 +1,48 @@
+package datacatalogservice
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// The metrics of the requests to every method, labelled with the project and domain the request accesses, empty if
+// it does not access a single one
+type requestMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newRequestMetrics(scope promutils.Scope) requestMetrics {
+	return requestMetrics{
+		requests: scope.MustNewCounterVec("requests", "The number of requests by method",
+			"method", "project", "domain"),
+		errors: scope.MustNewCounterVec("request_errors", "The number of failed requests by method and gRPC status code",
+			"method", "code", "project", "domain"),
+		duration: scope.MustNewHistogramVec("request_duration_seconds", "The duration of requests by method",
+			"method", "project", "domain"),
+	}
+}
+
+// Records the count, errors and duration of the requests to every method, served over gRPC and the JSON API alike
+func metricsInterceptor(metrics requestMetrics, now func() time.Time) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := now()
+		resp, err := handler(ctx, req)
+
+		method := path.Base(info.FullMethod)
+		resource, _ := getRequestResource(req)
+		metrics.requests.WithLabelValues(method, resource.Project, resource.Domain).Inc()
+		metrics.duration.WithLabelValues(method, resource.Project, resource.Domain).Observe(now().Sub(start).Seconds())
+		if err != nil {
+			metrics.errors.WithLabelValues(method, status.Code(err).String(), resource.Project, resource.Domain).Inc()
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,58 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMetricsInterceptor(t *testing.T) {
+	metrics := newRequestMetrics(promutils.NewTestScope())
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	interceptor := metricsInterceptor(metrics, func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls) * 250 * time.Millisecond)
+	})
+
+	getInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	getRequest := &catalog.GetArtifactRequest{Dataset: &catalog.DatasetID{Project: "team-a", Domain: "production"}}
+	_, err := interceptor(context.Background(), getRequest, getInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &catalog.GetArtifactResponse{}, nil
+	})
+	assert.NoError(t, err)
+	_, err = interceptor(context.Background(), getRequest, getInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.NewDataCatalogError(codes.NotFound, "artifact not found")
+	})
+	assert.Error(t, err)
+
+	addTagInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/AddTag"}
+	addTagRequest := &catalog.AddTagRequest{Tag: &catalog.Tag{Dataset: &catalog.DatasetID{Project: "team-b", Domain: "development"}}}
+	_, err = interceptor(context.Background(), addTagRequest, addTagInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &catalog.AddTagResponse{}, nil
+	})
+	assert.NoError(t, err)
+
+	// requests without dataset are not labelled with a project and domain
+	listInfo := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/ListDatasets"}
+	_, err = interceptor(context.Background(), &catalog.ListDatasetsRequest{}, listInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.NewDataCatalogError(codes.Internal, "failed")
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.requests.WithLabelValues("GetArtifact", "team-a", "production")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.requests.WithLabelValues("AddTag", "team-b", "development")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.requests.WithLabelValues("ListDatasets", "", "")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.errors.WithLabelValues("GetArtifact", "NotFound", "team-a", "production")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.errors.WithLabelValues("ListDatasets", "Internal", "", "")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.errors))
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.duration))
+}
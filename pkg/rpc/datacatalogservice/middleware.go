@@ -0,0 +1,64 @@
+package datacatalogservice
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recovers panics while serving requests, failing the request with an Internal error instead of crashing the server.
+// Panics are counted by the counter.
+func recoveryInterceptor(panicCounter prometheus.Counter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicCounter.Inc()
+				logger.Errorf(ctx, "Caught panic serving %s: %v [%s]", info.FullMethod, r, string(debug.Stack()))
+				resp, err = nil, status.Errorf(codes.Internal, "internal error serving %s", info.FullMethod)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// Logs one line per request with the method, status code, duration, caller and the project and domain it accesses.
+// Failed requests are logged as errors if the server failed to serve them.
+func requestLogInterceptor(now func() time.Time) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := now()
+		resp, err := handler(ctx, req)
+
+		level := logger.InfoLevel
+		if errors.IsServerError(err) {
+			level = logger.ErrorLevel
+		}
+		if !logger.IsLoggable(ctx, level) {
+			return resp, err
+		}
+
+		resource, _ := getRequestResource(req)
+		format := "Served request %s of project [%s] domain [%s] with code %s in %dms for caller [%s]"
+		args := []interface{}{info.FullMethod, resource.Project, resource.Domain, status.Code(err),
+			now().Sub(start).Milliseconds(), auth.IdentityFromContext(ctx)}
+		if err != nil {
+			format += ", error: %s"
+			args = append(args, status.Convert(err).Message())
+		}
+
+		if level == logger.ErrorLevel {
+			logger.Errorf(ctx, format, args...)
+		} else {
+			logger.Infof(ctx, format, args...)
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,90 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/auth"
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
+	"github.com/flyteorg/flytestdlib/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	panics := prometheus.NewCounter(prometheus.CounterOpts{Name: "request_panic"})
+	interceptor := recoveryInterceptor(panics)
+
+	resp, err := interceptor(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("nil map")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, float64(1), testutil.ToFloat64(panics))
+
+	resp, err = interceptor(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "served", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "served", resp)
+	assert.Equal(t, float64(1), testutil.ToFloat64(panics))
+}
+
+func TestRequestLogInterceptor(t *testing.T) {
+	assert.NoError(t, logger.SetConfig(&logger.Config{Level: logger.InfoLevel}))
+	defer func() { assert.NoError(t, logger.SetConfig(&logger.Config{Level: logger.WarnLevel})) }()
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	request := &catalog.GetArtifactRequest{Dataset: &catalog.DatasetID{Project: "team-a", Domain: "production"}}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	interceptor := requestLogInterceptor(func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls) * 25 * time.Millisecond)
+	})
+
+	t.Run("served", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), "svc")
+		resp, err := interceptor(ctx, request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "served", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "served", resp)
+
+		entry := hook.LastEntry()
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		assert.Equal(t, "Served request /flyteidl.datacatalog.DataCatalog/GetArtifact of project [team-a] domain "+
+			"[production] with code OK in 25ms for caller [svc]", entry.Message)
+	})
+
+	t.Run("expected error", func(t *testing.T) {
+		_, err := interceptor(context.Background(), request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.NotFound, "artifact not found")
+		})
+		assert.Error(t, err)
+
+		entry := hook.LastEntry()
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		assert.Equal(t, "Served request /flyteidl.datacatalog.DataCatalog/GetArtifact of project [team-a] domain "+
+			"[production] with code NotFound in 25ms for caller [], error: artifact not found", entry.Message)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		_, err := interceptor(context.Background(), request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.Internal, "failed")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	})
+}
@@ -0,0 +1,57 @@
+package datacatalogservice
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/readiness"
+	"github.com/flyteorg/flytestdlib/storage"
+)
+
+// The names of the dependencies checked for readiness, reported as services of the gRPC health server
+const (
+	databaseHealthService = "datacatalog.database"
+	storageHealthService  = "datacatalog.storage"
+)
+
+// Create the readiness check of the blob storage, which writes an object below the storage prefix and reads it back.
+// Every replica writes its own object, named after its host.
+func newStorageCheck(store *storage.DataStore, storagePrefix storage.DataReference) readiness.Check {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return func(ctx context.Context) error {
+		reference, err := store.ConstructReference(ctx, storagePrefix, ".readiness", hostname)
+		if err != nil {
+			return err
+		}
+
+		written := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+		err = store.WriteRaw(ctx, reference, int64(len(written)), storage.Options{}, bytes.NewReader(written))
+		if err != nil {
+			return fmt.Errorf("failed to write %v: %w", reference, err)
+		}
+
+		reader, err := store.ReadRaw(ctx, reference)
+		if err != nil {
+			return fmt.Errorf("failed to read %v: %w", reference, err)
+		}
+		defer reader.Close()
+
+		read, err := io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read %v: %w", reference, err)
+		}
+		if !bytes.Equal(read, written) {
+			return fmt.Errorf("read %q from %v, expected %q", read, reference, written)
+		}
+		return nil
+	}
+}
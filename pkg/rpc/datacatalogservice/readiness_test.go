@@ -0,0 +1,28 @@
+package datacatalogservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
+	"github.com/flyteorg/flytestdlib/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	labeled.SetMetricKeys(contextutils.AppNameKey)
+}
+
+func TestStorageCheck(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewDataStore(&storage.Config{Type: storage.TypeMemory}, promutils.NewTestScope())
+	assert.NoError(t, err)
+
+	check := newStorageCheck(store, "s3://bucket/prefix")
+	assert.NoError(t, check(ctx))
+
+	// the object is overwritten by every check
+	assert.NoError(t, check(ctx))
+}
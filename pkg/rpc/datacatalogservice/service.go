@@ -2,34 +2,52 @@ package datacatalogservice
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"runtime/debug"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/flyteorg/datacatalog/pkg/auth"
 	"github.com/flyteorg/datacatalog/pkg/config"
 	"github.com/flyteorg/datacatalog/pkg/manager/impl"
 	"github.com/flyteorg/datacatalog/pkg/manager/interfaces"
+	"github.com/flyteorg/datacatalog/pkg/notifications"
+	"github.com/flyteorg/datacatalog/pkg/ratelimit"
+	"github.com/flyteorg/datacatalog/pkg/readiness"
 	"github.com/flyteorg/datacatalog/pkg/repositories"
 	"github.com/flyteorg/datacatalog/pkg/runtime"
+	"github.com/flyteorg/datacatalog/pkg/runtime/configs"
+	"github.com/flyteorg/datacatalog/pkg/tracing"
 	catalog "github.com/flyteorg/flyteidl/gen/pb-go/flyteidl/datacatalog"
 	"github.com/flyteorg/flytestdlib/contextutils"
 	"github.com/flyteorg/flytestdlib/logger"
 	"github.com/flyteorg/flytestdlib/promutils"
+	"github.com/flyteorg/flytestdlib/promutils/labeled"
 	"github.com/flyteorg/flytestdlib/storage"
 )
 
+// The name of the flyteidl DataCatalog service
+const catalogServiceName = "flyteidl.datacatalog.DataCatalog"
+
 type DataCatalogService struct {
 	DatasetManager     interfaces.DatasetManager
 	ArtifactManager    interfaces.ArtifactManager
 	TagManager         interfaces.TagManager
 	ReservationManager interfaces.ReservationManager
+
+	readinessChecks map[string]readiness.Check
+	// stops the background workers and closes the connections of the service
+	shutdown func() error
 }
 
 func (s *DataCatalogService) CreateDataset(ctx context.Context, request *catalog.CreateDatasetRequest) (*catalog.CreateDatasetResponse, error) {
@@ -64,6 +82,27 @@ func (s *DataCatalogService) UpdateArtifact(ctx context.Context, request *catalo
 	return s.ArtifactManager.UpdateArtifact(ctx, request)
 }
 
+// UpdateDataset is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions service.
+func (s *DataCatalogService) UpdateDataset(ctx context.Context, request *interfaces.UpdateDatasetRequest) (*interfaces.UpdateDatasetResponse, error) {
+	return s.DatasetManager.UpdateDataset(ctx, request)
+}
+
+// GetDatasetStats is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions service.
+func (s *DataCatalogService) GetDatasetStats(ctx context.Context, request *interfaces.GetDatasetStatsRequest) (*interfaces.GetDatasetStatsResponse, error) {
+	return s.DatasetManager.GetDatasetStats(ctx, request)
+}
+
+// SearchArtifacts is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions service.
+func (s *DataCatalogService) SearchArtifacts(ctx context.Context, request *interfaces.SearchArtifactsRequest) (*interfaces.SearchArtifactsResponse, error) {
+	return s.ArtifactManager.SearchArtifacts(ctx, request)
+}
+
+// CompleteReservation is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions
+// service.
+func (s *DataCatalogService) CompleteReservation(ctx context.Context, request *interfaces.CompleteReservationRequest) (*interfaces.CompleteReservationResponse, error) {
+	return s.ArtifactManager.CompleteReservation(ctx, request)
+}
+
 func (s *DataCatalogService) GetOrExtendReservation(ctx context.Context, request *catalog.GetOrExtendReservationRequest) (*catalog.GetOrExtendReservationResponse, error) {
 	return s.ReservationManager.GetOrExtendReservation(ctx, request)
 }
@@ -72,11 +111,37 @@ func (s *DataCatalogService) ReleaseReservation(ctx context.Context, request *ca
 	return s.ReservationManager.ReleaseReservation(ctx, request)
 }
 
+// ListReservations is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions service.
+func (s *DataCatalogService) ListReservations(ctx context.Context, request *interfaces.ListReservationsRequest) (*interfaces.ListReservationsResponse, error) {
+	return s.ReservationManager.ListReservations(ctx, request)
+}
+
+// WaitForArtifact is not part of the flyteidl DataCatalog service, it is served by the DataCatalogExtensions service.
+func (s *DataCatalogService) WaitForArtifact(ctx context.Context, request *interfaces.WaitForArtifactRequest) (*interfaces.WaitForArtifactResponse, error) {
+	return s.ReservationManager.WaitForArtifact(ctx, request)
+}
+
+// ForceReleaseReservation is not part of the flyteidl DataCatalog service and therefore is served by the
+// DataCatalogExtensions service. Clients of the DataCatalog service can force the release with the
+// datacatalog-force-release header of ReleaseReservation instead.
+func (s *DataCatalogService) ForceReleaseReservation(ctx context.Context, request *interfaces.ForceReleaseReservationRequest) (*interfaces.ForceReleaseReservationResponse, error) {
+	return s.ReservationManager.ForceReleaseReservation(ctx, request)
+}
+
+// Close stops the background workers of the service and closes its connections to the database. It must only be
+// called once all requests have been served.
+func (s *DataCatalogService) Close() error {
+	if s.shutdown == nil {
+		return nil
+	}
+	return s.shutdown()
+}
+
 func NewDataCatalogService() *DataCatalogService {
 	configProvider := runtime.NewConfigurationProvider()
 	dataCatalogConfig := configProvider.ApplicationConfiguration().GetDataCatalogConfig()
 	catalogScope := promutils.NewScope(dataCatalogConfig.MetricsScope).NewSubScope("datacatalog")
-	ctx := contextutils.WithAppName(context.Background(), "datacatalog")
+	ctx, stopWorkers := context.WithCancel(contextutils.WithAppName(context.Background(), "datacatalog"))
 
 	defer func() {
 		if err := recover(); err != nil {
@@ -102,38 +167,263 @@ func NewDataCatalogService() *DataCatalogService {
 	}
 
 	dbConfigValues := configProvider.ApplicationConfiguration().GetDbConfig()
-	repos := repositories.GetRepository(ctx, repositories.POSTGRES, *dbConfigValues, catalogScope)
+	repos := repositories.GetRepository(ctx, repositories.POSTGRES, *dbConfigValues,
+		dataCatalogConfig.StatementTimeouts, catalogScope)
 	logger.Infof(ctx, "Created DB connection.")
 
+	notifier, err := notifications.NewNotifier(ctx, *dbConfigValues, dataCatalogConfig.PostgresNotifications,
+		catalogScope.NewSubScope("notifications"))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to create notifier, err %v", err)
+		panic(err)
+	}
+
+	var workers sync.WaitGroup
+	reaperConfig := dataCatalogConfig.ReservationReaper
+	if reaperConfig.Enabled {
+		if reaperConfig.Interval.Duration <= 0 || reaperConfig.BatchSize <= 0 {
+			logger.Errorf(ctx, "Invalid reservation reaper config %+v, interval and batch size must be positive", reaperConfig)
+			panic(fmt.Errorf("invalid reservation reaper config %+v", reaperConfig))
+		}
+
+		reaper := impl.NewReservationReaper(repos, reaperConfig.Interval.Duration, reaperConfig.GracePeriod.Duration,
+			reaperConfig.BatchSize, time.Now, catalogScope.NewSubScope("reservation_reaper"))
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			reaper.Run(ctx)
+		}()
+		logger.Infof(ctx, "Started reaping reservations expired for %v every %v", reaperConfig.GracePeriod, reaperConfig.Interval)
+	}
+
+	artifactQuotas := impl.ArtifactQuotas{
+		Default:  impl.ArtifactQuota(dataCatalogConfig.ArtifactQuota),
+		Projects: make(map[string]impl.ArtifactQuota, len(dataCatalogConfig.ProjectArtifactQuotas)),
+	}
+	for project, quota := range dataCatalogConfig.ProjectArtifactQuotas {
+		artifactQuotas.Projects[project] = impl.ArtifactQuota(quota)
+	}
+
+	reservationManager := impl.NewReservationManager(repos, time.Duration(dataCatalogConfig.HeartbeatGracePeriodMultiplier), dataCatalogConfig.MaxReservationHeartbeat.Duration, time.Now,
+		notifier, dataCatalogConfig.MaxArtifactWait.Duration, dataCatalogConfig.QueueReservationWaiters, catalogScope.NewSubScope("reservation"))
+	// reservations completed by the artifact manager are handed off by the reservation manager
+	reservationHandOff := reservationManager.(impl.ReservationHandOff)
+
 	return &DataCatalogService{
-		DatasetManager:  impl.NewDatasetManager(repos, dataStorageClient, catalogScope.NewSubScope("dataset")),
-		ArtifactManager: impl.NewArtifactManager(repos, dataStorageClient, storagePrefix, catalogScope.NewSubScope("artifact")),
-		TagManager:      impl.NewTagManager(repos, dataStorageClient, catalogScope.NewSubScope("tag")),
-		ReservationManager: impl.NewReservationManager(repos, time.Duration(dataCatalogConfig.HeartbeatGracePeriodMultiplier), dataCatalogConfig.MaxReservationHeartbeat.Duration, time.Now,
-			catalogScope.NewSubScope("reservation")),
+		DatasetManager:     impl.NewDatasetManager(repos, dataStorageClient, dataCatalogConfig.DatasetStatsCacheTTL.Duration, catalogScope.NewSubScope("dataset")),
+		ArtifactManager:    impl.NewArtifactManager(repos, dataStorageClient, storagePrefix, notifier, reservationHandOff, artifactQuotas, catalogScope.NewSubScope("artifact")),
+		TagManager:         impl.NewTagManager(repos, dataStorageClient, notifier, catalogScope.NewSubScope("tag")),
+		ReservationManager: reservationManager,
+		readinessChecks: map[string]readiness.Check{
+			databaseHealthService: repos.Ping,
+			storageHealthService:  newStorageCheck(dataStorageClient, storagePrefix),
+		},
+		shutdown: func() error {
+			stopWorkers()
+			workers.Wait()
+			if err := notifier.Close(); err != nil {
+				logger.Warnf(ctx, "Failed to close notifier, err: %v", err)
+			}
+			return repos.Close()
+		},
 	}
 }
 
-// Create and start the gRPC server
+// Create and start the gRPC server and the http server with the healthcheck, the readiness endpoint and the JSON API
 func ServeInsecure(ctx context.Context, cfg *config.Config) error {
-	grpcServer := newGRPCServer(ctx, cfg)
+	return serve(ctx, cfg, nil)
+}
+
+// Create and start the gRPC server and the http server with the healthcheck, the readiness endpoint and the JSON API,
+// both with TLS. Clients must present a certificate signed by the configured client CA if mutual TLS is enabled, its
+// identity is stored in the request context. With mutual TLS, http probes of the healthcheck and readiness endpoint
+// must present a certificate as well.
+func ServeSecure(ctx context.Context, cfg *config.Config) error {
+	tlsConfig, err := newServerTLSConfig(cfg.TLS, time.Now)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Loaded TLS certificate %v, mutual TLS: %v", cfg.TLS.CertFile, cfg.TLS.ClientCAFile != "")
+	return serve(ctx, cfg, tlsConfig)
+}
+
+// Create and start the servers of the DataCatalogService until the context is done, with TLS unless the TLS
+// configuration is nil. The dependencies of the service are checked periodically, the gRPC health service and the
+// readiness endpoint report whether they are available.
+func serve(ctx context.Context, cfg *config.Config, tlsConfig *tls.Config) error {
+	mode := "Insecure"
+	var serverOpts []grpc.ServerOption
+	if tlsConfig != nil {
+		mode = "Secure"
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else if cfg.Secure {
+		// the JSON API would otherwise be served in cleartext and without client certificates
+		return fmt.Errorf("refusing to serve without TLS in secure mode")
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// the spans of the requests drained on shutdown are flushed last
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Errorf(ctx, "Failed to flush spans, err: %v", err)
+		}
+	}()
+
+	service := NewDataCatalogService()
+	defer func() {
+		if err := service.Close(); err != nil {
+			logger.Errorf(ctx, "Failed to close the DataCatalogService, err: %v", err)
+		}
+	}()
+
+	interceptors, err := newUnaryInterceptors(cfg)
+	if err != nil {
+		return err
+	}
+
+	healthServer := health.NewServer()
+	checker := readiness.NewChecker(service.readinessChecks, []string{"", catalogServiceName, extensionsServiceName}, cfg.Readiness.Timeout.Duration,
+		healthServer, time.Now)
+	go checker.Run(ctx, cfg.Readiness.Interval.Duration)
 
+	httpServer := newHTTPServer(cfg, newGateway(service, interceptors), checker)
+	if tlsConfig != nil {
+		httpServer.TLSConfig = newHTTPTLSConfig(tlsConfig)
+	}
+	go serveHTTPGateway(ctx, httpServer)
+
+	grpcServer := newGRPCServer(ctx, cfg, service, healthServer, interceptors, serverOpts...)
 	grpcListener, err := net.Listen("tcp", cfg.GetGrpcHostAddress())
 	if err != nil {
 		return err
 	}
 
-	logger.Infof(ctx, "Serving DataCatalog Insecure on port %v", config.GetConfig().GetGrpcHostAddress())
-	return grpcServer.Serve(grpcListener)
+	logger.Infof(ctx, "Serving DataCatalog %s on port %v", mode, cfg.GetGrpcHostAddress())
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(grpcListener)
+	}()
+
+	select {
+	case err := <-served:
+		_ = httpServer.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	checker.Shutdown()
+	shutdown(ctx, cfg.ShutdownTimeout.Duration, grpcServer, httpServer)
+	return nil
+}
+
+// Stop the servers gracefully, waiting for the requests in flight to complete until the timeout. The requests still
+// in flight afterwards are cancelled.
+func shutdown(ctx context.Context, timeout time.Duration, grpcServer *grpc.Server, httpServer *http.Server) {
+	logger.Infof(ctx, "Shutting down, draining requests for up to %v", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Warnf(ctx, "Failed to drain http requests, err: %v", err)
+		_ = httpServer.Close()
+	}
+
+	select {
+	case <-stopped:
+		logger.Infof(ctx, "Drained all requests")
+	case <-shutdownCtx.Done():
+		logger.Warnf(ctx, "Requests still in flight after %v, cancelling them", timeout)
+		grpcServer.Stop()
+	}
+}
+
+// Creates the interceptors of the requests to the DataCatalogService, served over gRPC and the JSON API alike. Every
+// request is traced and measured, panics are recovered and requests are logged once the caller is authenticated.
+func newUnaryInterceptors(cfg *config.Config) ([]grpc.UnaryServerInterceptor, error) {
+	dataCatalogConfig := runtime.NewConfigurationProvider().ApplicationConfiguration().GetDataCatalogConfig()
+	serverScope := promutils.NewScope(dataCatalogConfig.MetricsScope).NewSubScope("datacatalog").NewSubScope("server")
+	interceptors := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(),
+		// requests rejected by the interceptors below are counted as well
+		metricsInterceptor(newRequestMetrics(serverScope), time.Now),
+		recoveryInterceptor(serverScope.MustNewCounter("request_panic", "The number of panics recovered while serving requests")),
+		deadlineInterceptor(dataCatalogConfig.RequestTimeout.Duration, newRequestTimeouts(dataCatalogConfig),
+			serverScope.MustNewCounterVec("request_timeouts", "The number of requests failed by their deadline or a statement timeout", "method")),
+	}
+
+	if cfg.TrustAdminHeader {
+		interceptors = append(interceptors, auth.AdminHeaderInterceptor())
+	}
+
+	// the authenticated identity takes precedence over the identity header
+	authenticators, err := newAuthenticators(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(authenticators) > 0 || cfg.Auth.Required {
+		interceptors = append(interceptors, auth.AuthenticationInterceptor(cfg.Auth.Required, authenticators...))
+	}
+
+	// requests rejected by the authentication interceptor are logged by it, all others are logged with their caller
+	interceptors = append(interceptors, requestLogInterceptor(time.Now))
+
+	if cfg.Auth.PolicyFile != "" {
+		policy, err := auth.LoadPolicy(cfg.Auth.PolicyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		deniedCounter := labeled.NewCounter("authorization_denied", "The number of requests denied by the authorization policy", serverScope)
+		interceptors = append(interceptors, auth.AuthorizationInterceptor(policy, getRequestResource, deniedCounter))
+	}
+
+	if len(dataCatalogConfig.RateLimits) > 0 {
+		limits := make(map[string]ratelimit.Limit, len(dataCatalogConfig.RateLimits))
+		for method, limit := range dataCatalogConfig.RateLimits {
+			limits[method] = ratelimit.Limit(limit)
+		}
+
+		limitedCounter := serverScope.MustNewCounterVec("rate_limited", "The number of requests rejected by rate limits", "method")
+		limiter := ratelimit.NewLimiter(limits, getRateLimitKey, time.Now, limitedCounter)
+		interceptors = append(interceptors, limiter.UnaryServerInterceptor())
+	}
+	return interceptors, nil
+}
+
+// The timeouts of the methods overriding the request timeout. Waits for artifacts block for up to the max artifact wait
+// and are therefore bound by the request timeout plus the max artifact wait unless configured otherwise.
+func newRequestTimeouts(dataCatalogConfig configs.DataCatalogConfig) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(dataCatalogConfig.RequestTimeouts)+1)
+	if dataCatalogConfig.RequestTimeout.Duration > 0 {
+		timeouts["waitforartifact"] = dataCatalogConfig.RequestTimeout.Duration + dataCatalogConfig.MaxArtifactWait.Duration
+	}
+	for method, timeout := range dataCatalogConfig.RequestTimeouts {
+		timeouts[strings.ToLower(method)] = timeout.Duration
+	}
+	return timeouts
 }
 
 // Creates a new GRPC Server with all the configuration
-func newGRPCServer(_ context.Context, cfg *config.Config) *grpc.Server {
-	grpcServer := grpc.NewServer()
-	catalog.RegisterDataCatalogServer(grpcServer, NewDataCatalogService())
+func newGRPCServer(_ context.Context, cfg *config.Config, service *DataCatalogService, healthServer grpc_health_v1.HealthServer,
+	interceptors []grpc.UnaryServerInterceptor, serverOpts ...grpc.ServerOption) *grpc.Server {
+	if len(interceptors) > 0 {
+		serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
+	catalog.RegisterDataCatalogServer(grpcServer, service)
+	grpcServer.RegisterService(newExtensionsServiceDesc(service), service)
 
-	healthServer := health.NewServer()
-	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 
 	if cfg.GrpcServerReflection {
@@ -144,6 +434,30 @@ func newGRPCServer(_ context.Context, cfg *config.Config) *grpc.Server {
 
 // ServeHTTPHealthCheck create a http healthcheck endpoint
 func ServeHTTPHealthCheck(ctx context.Context, cfg *config.Config) error {
+	logger.Infof(ctx, "Serving DataCatalog http on port %v", cfg.GetHTTPHostAddress())
+	return newHTTPServer(cfg, nil, nil).ListenAndServe()
+}
+
+// Serve the healthcheck, the readiness endpoint and the JSON API until the server is shut down, with TLS if the server
+// has a TLS configuration. The error is logged if the http server fails as the gRPC server keeps serving.
+func serveHTTPGateway(ctx context.Context, server *http.Server) {
+	var err error
+	if server.TLSConfig != nil {
+		logger.Infof(ctx, "Serving DataCatalog https on port %v", server.Addr)
+		// the certificates are served by the TLS configuration
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		logger.Infof(ctx, "Serving DataCatalog http on port %v", server.Addr)
+		err = server.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		logger.Errorf(ctx, "Unable to serve http on %v, err: %v", server.Addr, err)
+	}
+}
+
+// Create the http server with the healthcheck endpoint and the readiness endpoint and JSON API, if any. The healthcheck
+// reports liveness only, it succeeds as long as the server is running.
+func newHTTPServer(cfg *config.Config, gateway http.Handler, readiness http.Handler) *http.Server {
 	mux := http.NewServeMux()
 
 	// Register Health check
@@ -151,14 +465,18 @@ func ServeHTTPHealthCheck(ctx context.Context, cfg *config.Config) error {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	logger.Infof(ctx, "Serving DataCatalog http on port %v", cfg.GetHTTPHostAddress())
+	if readiness != nil {
+		mux.Handle("/readyz", readiness)
+	}
+	if gateway != nil {
+		mux.Handle(gatewayPathPrefix, gateway)
+	}
 
-	server := &http.Server{
+	return &http.Server{
 		Addr:              cfg.GetHTTPHostAddress(),
 		Handler:           mux,
 		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeoutSeconds) * time.Second,
 	}
-	return server.ListenAndServe()
 }
 
 // Create and start the gRPC server and http healthcheck endpoint
@@ -0,0 +1,90 @@
+package datacatalogservice
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Start a gRPC server with the health service and a http server with a handler taking the delay to respond
+func startTestServers(t *testing.T, delay time.Duration) (*grpc.Server, string, *http.Server, string) {
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+	go func() { _ = grpcServer.Serve(grpcListener) }()
+
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	httpServer := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			w.WriteHeader(http.StatusOK)
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() { _ = httpServer.Serve(httpListener) }()
+
+	return grpcServer, grpcListener.Addr().String(), httpServer, httpListener.Addr().String()
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("drains requests in flight", func(t *testing.T) {
+		grpcServer, _, httpServer, httpAddress := startTestServers(t, 100*time.Millisecond)
+
+		responses := make(chan int)
+		go func() {
+			resp, err := http.Get("http://" + httpAddress)
+			if !assert.NoError(t, err) {
+				responses <- 0
+				return
+			}
+			_ = resp.Body.Close()
+			responses <- resp.StatusCode
+		}()
+
+		// let the request arrive before shutting down
+		time.Sleep(20 * time.Millisecond)
+		shutdown(context.Background(), time.Second, grpcServer, httpServer)
+		assert.Equal(t, http.StatusOK, <-responses)
+
+		_, err := http.Get("http://" + httpAddress)
+		assert.Error(t, err)
+	})
+
+	t.Run("cancels requests after the timeout", func(t *testing.T) {
+		grpcServer, grpcAddress, httpServer, _ := startTestServers(t, 0)
+
+		// a watch of the health status streams until it is cancelled
+		conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		defer conn.Close()
+		stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.NoError(t, err)
+
+		start := time.Now()
+		shutdown(context.Background(), 50*time.Millisecond, grpcServer, httpServer)
+		assert.Less(t, time.Since(start), time.Second)
+
+		_, err = stream.Recv()
+		assert.Error(t, err)
+	})
+}
+
+func TestServe(t *testing.T) {
+	t.Run("refuses to serve without TLS in secure mode", func(t *testing.T) {
+		err := serve(context.Background(), &config.Config{Secure: true}, nil)
+		assert.Error(t, err)
+	})
+}
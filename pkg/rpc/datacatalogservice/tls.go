@@ -0,0 +1,177 @@
+package datacatalogservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/config"
+	"github.com/flyteorg/flytestdlib/logger"
+)
+
+// certificateReloader serves the TLS configuration of the server from the configured files and reloads them once
+// they change, so that rotated certificates are picked up by new connections without restarting the server.
+type certificateReloader struct {
+	cfg config.TLSConfig
+	now func() time.Time
+
+	mutex       sync.Mutex
+	tlsConfig   *tls.Config
+	modTimes    []time.Time
+	lastChecked time.Time
+}
+
+// Get the TLS configuration for a new connection, reloading the files first if they changed since they were last
+// checked. The previous configuration is kept if the changed files can't be loaded, e.g. while they are being
+// replaced.
+func (r *certificateReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastChecked) < r.cfg.ReloadInterval.Duration {
+		return r.tlsConfig, nil
+	}
+	r.lastChecked = now
+
+	modTimes, err := r.getModTimes()
+	if err != nil {
+		logger.Warnf(context.Background(), "Failed to check the TLS files for changes, err: %v", err)
+		return r.tlsConfig, nil
+	}
+	if equalTimes(modTimes, r.modTimes) {
+		return r.tlsConfig, nil
+	}
+
+	tlsConfig, err := loadTLSConfig(r.cfg)
+	if err != nil {
+		logger.Warnf(context.Background(), "Failed to reload the changed TLS files, err: %v", err)
+		return r.tlsConfig, nil
+	}
+
+	logger.Infof(context.Background(), "Reloaded the changed TLS files")
+	r.tlsConfig = tlsConfig
+	r.modTimes = modTimes
+	return r.tlsConfig, nil
+}
+
+func (r *certificateReloader) getModTimes() ([]time.Time, error) {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+
+	modTimes := make([]time.Time, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Load the server certificate and, if configured, the CA certificates client certificates must be signed by
+func loadTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// gRPC requires HTTP/2 to be negotiated
+		NextProtos: []string{"h2"},
+	}
+
+	if cfg.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client CA certificates: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no client CA certificates found in %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// Create the TLS configuration of the http server from the one of the gRPC server, so that both serve the same reloaded
+// certificate and require the same client certificates. HTTP/1.1 is negotiated besides HTTP/2.
+func newHTTPTLSConfig(tlsConfig *tls.Config) *tls.Config {
+	getConfigForClient := func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		clientConfig, err := tlsConfig.GetConfigForClient(hello)
+		if err != nil {
+			return nil, err
+		}
+		clientConfig = clientConfig.Clone()
+		clientConfig.NextProtos = []string{"h2", "http/1.1"}
+		return clientConfig, nil
+	}
+
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: getConfigForClient,
+		// only used by http.Server to tell that the configuration provides a certificate, the handshake uses the
+		// configuration for the client
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			clientConfig, err := getConfigForClient(hello)
+			if err != nil {
+				return nil, err
+			}
+			return &clientConfig.Certificates[0], nil
+		},
+	}
+}
+
+// Create the TLS configuration of the server, failing if the configured files can't be loaded
+func newServerTLSConfig(cfg config.TLSConfig, now func() time.Time) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("the certificate and key files are required in secure mode")
+	}
+
+	reloader := &certificateReloader{
+		cfg: cfg,
+		now: now,
+	}
+
+	modTimes, err := reloader.getModTimes()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := loadTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	reloader.tlsConfig = tlsConfig
+	reloader.modTimes = modTimes
+	reloader.lastChecked = now()
+
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: reloader.getConfigForClient,
+	}, nil
+}
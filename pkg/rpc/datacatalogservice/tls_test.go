@@ -0,0 +1,156 @@
+package datacatalogservice
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/datacatalog/pkg/config"
+	stdConfig "github.com/flyteorg/flytestdlib/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Write a self-signed certificate and its key to the directory, returning the paths of the files
+func writeTestCertificate(t *testing.T, dir string, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		DNSNames:     []string{"localhost"},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func getCommonName(t *testing.T, tlsConfig *tls.Config) string {
+	cert, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	assert.NoError(t, err)
+	return cert.Subject.CommonName
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	now := time.Now()
+	nowFunc := func() time.Time { return now }
+
+	t.Run("Reloads changed files", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTestCertificate(t, dir, "first")
+		cfg := config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: stdConfig.Duration{Duration: time.Minute}}
+
+		tlsConfig, err := newServerTLSConfig(cfg, nowFunc)
+		assert.NoError(t, err)
+		clientConfig, err := tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "first", getCommonName(t, clientConfig))
+		assert.Equal(t, tls.NoClientCert, clientConfig.ClientAuth)
+
+		writeTestCertificate(t, dir, "second")
+		modTime := now.Add(time.Second)
+		assert.NoError(t, os.Chtimes(certFile, modTime, modTime))
+
+		// the files are not checked again within the reload interval
+		clientConfig, err = tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "first", getCommonName(t, clientConfig))
+
+		now = now.Add(time.Minute)
+		clientConfig, err = tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "second", getCommonName(t, clientConfig))
+	})
+
+	t.Run("Keeps the certificate if the changed files are invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTestCertificate(t, dir, "valid")
+		cfg := config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ReloadInterval: stdConfig.Duration{Duration: time.Minute}}
+
+		tlsConfig, err := newServerTLSConfig(cfg, nowFunc)
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.WriteFile(keyFile, []byte("truncated"), 0600))
+		modTime := now.Add(time.Hour)
+		assert.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+		now = now.Add(time.Minute)
+
+		clientConfig, err := tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "valid", getCommonName(t, clientConfig))
+	})
+
+	t.Run("Mutual TLS", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTestCertificate(t, dir, "server")
+		caFile, _ := writeTestCertificate(t, t.TempDir(), "client-ca")
+		cfg := config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}
+
+		tlsConfig, err := newServerTLSConfig(cfg, nowFunc)
+		assert.NoError(t, err)
+		clientConfig, err := tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, clientConfig.ClientAuth)
+		assert.NotNil(t, clientConfig.ClientCAs)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTestCertificate(t, dir, "server")
+		caFile, _ := writeTestCertificate(t, t.TempDir(), "client-ca")
+		cfg := config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}
+
+		tlsConfig, err := newServerTLSConfig(cfg, nowFunc)
+		assert.NoError(t, err)
+		httpConfig := newHTTPTLSConfig(tlsConfig)
+		clientConfig, err := httpConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "server", getCommonName(t, clientConfig))
+		assert.Equal(t, tls.RequireAndVerifyClientCert, clientConfig.ClientAuth)
+		assert.Equal(t, []string{"h2", "http/1.1"}, clientConfig.NextProtos)
+
+		// the configuration of the gRPC server is not modified
+		grpcConfig, err := tlsConfig.GetConfigForClient(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"h2"}, grpcConfig.NextProtos)
+
+		cert, err := httpConfig.GetCertificate(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, clientConfig.Certificates[0].Certificate, cert.Certificate)
+	})
+
+	t.Run("Missing files", func(t *testing.T) {
+		_, err := newServerTLSConfig(config.TLSConfig{}, nowFunc)
+		assert.Error(t, err)
+
+		_, err = newServerTLSConfig(config.TLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"}, nowFunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid client CA", func(t *testing.T) {
+		dir := t.TempDir()
+		certFile, keyFile := writeTestCertificate(t, dir, "server")
+		_, err := newServerTLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile}, nowFunc)
+		assert.Error(t, err)
+	})
+}
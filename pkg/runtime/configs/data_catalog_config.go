@@ -14,13 +14,64 @@ var defaultConfig = &DataCatalogConfig{
 	ProfilerPort:                   10254,
 	HeartbeatGracePeriodMultiplier: 3,
 	MaxReservationHeartbeat:        config.Duration{Duration: time.Second * 10},
+	DatasetStatsCacheTTL:           config.Duration{Duration: time.Minute * 5},
+	MaxArtifactWait:                config.Duration{Duration: time.Minute},
+	ReservationReaper: ReservationReaperConfig{
+		Enabled:     false,
+		Interval:    config.Duration{Duration: time.Minute * 10},
+		GracePeriod: config.Duration{Duration: time.Hour},
+		BatchSize:   1000,
+	},
 }
 
 // DataCatalogConfig is the base configuration to start datacatalog
 type DataCatalogConfig struct {
-	StoragePrefix                  string          `json:"storage-prefix" pflag:",StoragePrefix specifies the prefix where DataCatalog stores offloaded ArtifactData in CloudStorage. If not specified, the data will be stored in the base container directly."`
-	MetricsScope                   string          `json:"metrics-scope" pflag:",Scope that the metrics will record under."`
-	ProfilerPort                   int             `json:"profiler-port" pflag:",Port that the profiling service is listening on."`
-	HeartbeatGracePeriodMultiplier int             `json:"heartbeat-grace-period-multiplier" pflag:",Number of heartbeats before a reservation expires without an extension."`
-	MaxReservationHeartbeat        config.Duration `json:"max-reservation-heartbeat" pflag:",The maximum available reservation extension heartbeat interval."`
+	StoragePrefix                  string                     `json:"storage-prefix" pflag:",StoragePrefix specifies the prefix where DataCatalog stores offloaded ArtifactData in CloudStorage. If not specified, the data will be stored in the base container directly."`
+	MetricsScope                   string                     `json:"metrics-scope" pflag:",Scope that the metrics will record under."`
+	ProfilerPort                   int                        `json:"profiler-port" pflag:",Port that the profiling service is listening on."`
+	HeartbeatGracePeriodMultiplier int                        `json:"heartbeat-grace-period-multiplier" pflag:",Number of heartbeats before a reservation expires without an extension."`
+	MaxReservationHeartbeat        config.Duration            `json:"max-reservation-heartbeat" pflag:",The maximum available reservation extension heartbeat interval."`
+	DatasetStatsCacheTTL           config.Duration            `json:"dataset-stats-cache-ttl" pflag:",How long computed dataset statistics are served from the cache before being refreshed. Caching is disabled if set to 0."`
+	MaxArtifactWait                config.Duration            `json:"max-artifact-wait" pflag:",The maximum duration a wait for an artifact blocks before returning the reservation still holding it."`
+	QueueReservationWaiters        bool                       `json:"queue-reservation-waiters" pflag:",Whether owners requesting a held reservation are queued and handed the reservation in the order they requested it when it is released or expires. Otherwise the first owner requesting it afterwards acquires it."`
+	PostgresNotifications          bool                       `json:"postgres-notifications" pflag:",Whether to notify waiters of all replicas about artifacts and released reservations with Postgres LISTEN/NOTIFY. Otherwise only the waiters of the replica serving the write are notified, the others wake up when the reservation expires."`
+	ReservationReaper              ReservationReaperConfig    `json:"reservation-reaper" pflag:",Configuration of the background deletion of expired reservations."`
+	RateLimits                     map[string]RateLimit       `json:"rate-limits" pflag:"-,Rate limits of the RPCs by their method name, e.g. CreateArtifact. Requests are limited per project, domain and caller."`
+	ArtifactQuota                  ArtifactQuota              `json:"artifact-quota" pflag:",The quota of artifacts every project may store."`
+	ProjectArtifactQuotas          map[string]ArtifactQuota   `json:"project-artifact-quotas" pflag:"-,Quotas of artifacts of individual projects, overriding the quota of all projects."`
+	RequestTimeout                 config.Duration            `json:"request-timeout" pflag:",The deadline of requests, unless the caller set an earlier one. Requests are not bound by the server if set to 0, the default."`
+	RequestTimeouts                map[string]config.Duration `json:"request-timeouts" pflag:"-,Deadlines of the RPCs by their method name, e.g. ListArtifacts, overriding the request-timeout. WaitForArtifact is bound by the request-timeout plus the max-artifact-wait unless set."`
+	StatementTimeouts              StatementTimeouts          `json:"statement-timeouts" pflag:",The Postgres statement timeouts of the classes of queries."`
+}
+
+// StatementTimeouts bound how long Postgres executes queries, so that slow queries release their connection even if
+// the request has no deadline. The default timeout is set once per connection, the timeouts of the classes of queries
+// only override it within transactions. Queries are not bound by Postgres if zero.
+type StatementTimeouts struct {
+	Default config.Duration `json:"default" pflag:",The statement timeout of every database connection."`
+	Get     config.Duration `json:"get" pflag:",The statement timeout of queries getting a single entity within transactions."`
+	List    config.Duration `json:"list" pflag:",The statement timeout of queries listing or aggregating entities within transactions."`
+	Write   config.Duration `json:"write" pflag:",The statement timeout of queries creating, updating or deleting entities within transactions."`
+}
+
+// RateLimit limits the rate of requests with a token bucket, which holds up to burst requests and is refilled with
+// requests-per-second.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests-per-second"`
+	Burst             int     `json:"burst"`
+}
+
+// ArtifactQuota caps the artifacts stored by a project. Limits are not enforced if zero.
+type ArtifactQuota struct {
+	MaxArtifacts int64 `json:"max-artifacts" pflag:",The maximum number of artifacts a project may store."`
+	MaxBytes     int64 `json:"max-bytes" pflag:",The maximum size of the artifact data a project may store in bytes."`
+}
+
+// ReservationReaperConfig configures the periodic deletion of expired reservations, which would otherwise be kept until
+// they are taken over or released.
+type ReservationReaperConfig struct {
+	Enabled     bool            `json:"enabled" pflag:",Whether to periodically delete expired reservations."`
+	Interval    config.Duration `json:"interval" pflag:",How often expired reservations are deleted."`
+	GracePeriod config.Duration `json:"grace-period" pflag:",How long reservations are kept after they expired, so that they can still be listed."`
+	BatchSize   int             `json:"batch-size" pflag:",The maximum number of reservations deleted by a single statement."`
 }
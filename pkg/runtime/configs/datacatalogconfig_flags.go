@@ -55,5 +55,20 @@ func (cfg DataCatalogConfig) GetPFlagSet(prefix string) *pflag.FlagSet {
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "profiler-port"), defaultConfig.ProfilerPort, "Port that the profiling service is listening on.")
 	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "heartbeat-grace-period-multiplier"), defaultConfig.HeartbeatGracePeriodMultiplier, "Number of heartbeats before a reservation expires without an extension.")
 	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "max-reservation-heartbeat"), defaultConfig.MaxReservationHeartbeat.String(), "The maximum available reservation extension heartbeat interval.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "dataset-stats-cache-ttl"), defaultConfig.DatasetStatsCacheTTL.String(), "How long computed dataset statistics are served from the cache before being refreshed. Caching is disabled if set to 0.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "max-artifact-wait"), defaultConfig.MaxArtifactWait.String(), "The maximum duration a wait for an artifact blocks before returning the reservation still holding it.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "queue-reservation-waiters"), defaultConfig.QueueReservationWaiters, "Whether owners requesting a held reservation are queued and handed the reservation in the order they requested it when it is released or expires. Otherwise the first owner requesting it afterwards acquires it.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "postgres-notifications"), defaultConfig.PostgresNotifications, "Whether to notify waiters of all replicas about artifacts and released reservations with Postgres LISTEN/NOTIFY. Otherwise only the waiters of the replica serving the write are notified,  the others wake up when the reservation expires.")
+	cmdFlags.Bool(fmt.Sprintf("%v%v", prefix, "reservation-reaper.enabled"), defaultConfig.ReservationReaper.Enabled, "Whether to periodically delete expired reservations.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "reservation-reaper.interval"), defaultConfig.ReservationReaper.Interval.String(), "How often expired reservations are deleted.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "reservation-reaper.grace-period"), defaultConfig.ReservationReaper.GracePeriod.String(), "How long reservations are kept after they expired,  so that they can still be listed.")
+	cmdFlags.Int(fmt.Sprintf("%v%v", prefix, "reservation-reaper.batch-size"), defaultConfig.ReservationReaper.BatchSize, "The maximum number of reservations deleted by a single statement.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "artifact-quota.max-artifacts"), defaultConfig.ArtifactQuota.MaxArtifacts, "The maximum number of artifacts a project may store.")
+	cmdFlags.Int64(fmt.Sprintf("%v%v", prefix, "artifact-quota.max-bytes"), defaultConfig.ArtifactQuota.MaxBytes, "The maximum size of the artifact data a project may store in bytes.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "request-timeout"), defaultConfig.RequestTimeout.String(), "The deadline of requests,  unless the caller set an earlier one. Requests are not bound by the server if set to 0,  the default.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "statement-timeouts.default"), defaultConfig.StatementTimeouts.Default.String(), "The statement timeout of every database connection.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "statement-timeouts.get"), defaultConfig.StatementTimeouts.Get.String(), "The statement timeout of queries getting a single entity within transactions.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "statement-timeouts.list"), defaultConfig.StatementTimeouts.List.String(), "The statement timeout of queries listing or aggregating entities within transactions.")
+	cmdFlags.String(fmt.Sprintf("%v%v", prefix, "statement-timeouts.write"), defaultConfig.StatementTimeouts.Write.String(), "The statement timeout of queries creating,  updating or deleting entities within transactions.")
 	return cmdFlags
 }
@@ -169,4 +169,214 @@ func TestDataCatalogConfig_SetFlags(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Test_dataset-stats-cache-ttl", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.DatasetStatsCacheTTL.String()
+
+			cmdFlags.Set("dataset-stats-cache-ttl", testValue)
+			if vString, err := cmdFlags.GetString("dataset-stats-cache-ttl"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.DatasetStatsCacheTTL)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_max-artifact-wait", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.MaxArtifactWait.String()
+
+			cmdFlags.Set("max-artifact-wait", testValue)
+			if vString, err := cmdFlags.GetString("max-artifact-wait"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.MaxArtifactWait)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_queue-reservation-waiters", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("queue-reservation-waiters", testValue)
+			if vBool, err := cmdFlags.GetBool("queue-reservation-waiters"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.QueueReservationWaiters)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_postgres-notifications", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("postgres-notifications", testValue)
+			if vBool, err := cmdFlags.GetBool("postgres-notifications"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.PostgresNotifications)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reservation-reaper.enabled", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("reservation-reaper.enabled", testValue)
+			if vBool, err := cmdFlags.GetBool("reservation-reaper.enabled"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vBool), &actual.ReservationReaper.Enabled)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reservation-reaper.interval", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ReservationReaper.Interval.String()
+
+			cmdFlags.Set("reservation-reaper.interval", testValue)
+			if vString, err := cmdFlags.GetString("reservation-reaper.interval"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.ReservationReaper.Interval)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reservation-reaper.grace-period", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.ReservationReaper.GracePeriod.String()
+
+			cmdFlags.Set("reservation-reaper.grace-period", testValue)
+			if vString, err := cmdFlags.GetString("reservation-reaper.grace-period"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.ReservationReaper.GracePeriod)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_reservation-reaper.batch-size", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("reservation-reaper.batch-size", testValue)
+			if vInt, err := cmdFlags.GetInt("reservation-reaper.batch-size"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt), &actual.ReservationReaper.BatchSize)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_artifact-quota.max-artifacts", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("artifact-quota.max-artifacts", testValue)
+			if vInt64, err := cmdFlags.GetInt64("artifact-quota.max-artifacts"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt64), &actual.ArtifactQuota.MaxArtifacts)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_artifact-quota.max-bytes", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := "1"
+
+			cmdFlags.Set("artifact-quota.max-bytes", testValue)
+			if vInt64, err := cmdFlags.GetInt64("artifact-quota.max-bytes"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vInt64), &actual.ArtifactQuota.MaxBytes)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_request-timeout", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.RequestTimeout.String()
+
+			cmdFlags.Set("request-timeout", testValue)
+			if vString, err := cmdFlags.GetString("request-timeout"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.RequestTimeout)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_statement-timeouts.default", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StatementTimeouts.Default.String()
+
+			cmdFlags.Set("statement-timeouts.default", testValue)
+			if vString, err := cmdFlags.GetString("statement-timeouts.default"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.StatementTimeouts.Default)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_statement-timeouts.get", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StatementTimeouts.Get.String()
+
+			cmdFlags.Set("statement-timeouts.get", testValue)
+			if vString, err := cmdFlags.GetString("statement-timeouts.get"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.StatementTimeouts.Get)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_statement-timeouts.list", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StatementTimeouts.List.String()
+
+			cmdFlags.Set("statement-timeouts.list", testValue)
+			if vString, err := cmdFlags.GetString("statement-timeouts.list"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.StatementTimeouts.List)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
+	t.Run("Test_statement-timeouts.write", func(t *testing.T) {
+
+		t.Run("Override", func(t *testing.T) {
+			testValue := defaultConfig.StatementTimeouts.Write.String()
+
+			cmdFlags.Set("statement-timeouts.write", testValue)
+			if vString, err := cmdFlags.GetString("statement-timeouts.write"); err == nil {
+				testDecodeJson_DataCatalogConfig(t, fmt.Sprintf("%v", vString), &actual.StatementTimeouts.Write)
+
+			} else {
+				assert.FailNow(t, err.Error())
+			}
+		})
+	})
 }
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/datacatalog/pkg/errors"
+	"github.com/flyteorg/flytestdlib/contextutils"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/flyteidl.datacatalog.DataCatalog/GetArtifact"}
+	interceptor := UnaryServerInterceptor()
+
+	t.Run("continues trace of caller", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+		resp, err := interceptor(ctx, "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", contextutils.Value(ctx, contextutils.RequestIDKey))
+			assert.True(t, trace.SpanFromContext(ctx).IsRecording())
+			return "served", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "served", resp)
+
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		assert.Equal(t, "flyteidl.datacatalog.DataCatalog/GetArtifact", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Contains(t, span.Attributes(), semconv.RPCMethod("GetArtifact"))
+		assert.Contains(t, span.Attributes(), semconv.RPCService("flyteidl.datacatalog.DataCatalog"))
+		assert.Equal(t, otelcodes.Unset, span.Status().Code)
+	})
+
+	t.Run("new trace", func(t *testing.T) {
+		_, err := interceptor(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.NotEmpty(t, contextutils.Value(ctx, contextutils.RequestIDKey))
+			return nil, errors.NewDataCatalogError(codes.NotFound, "artifact not found")
+		})
+		assert.Error(t, err)
+
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		assert.False(t, span.Parent().IsValid())
+		assert.Contains(t, span.Attributes(), semconv.RPCGRPCStatusCodeKey.Int(int(codes.NotFound)))
+		// expected errors do not fail the span
+		assert.Equal(t, otelcodes.Unset, span.Status().Code)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		_, err := interceptor(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NewDataCatalogError(codes.Internal, "failed")
+		})
+		assert.Error(t, err)
+
+		spans := recorder.Ended()
+		span := spans[len(spans)-1]
+		assert.Equal(t, otelcodes.Error, span.Status().Code)
+		assert.Len(t, span.Events(), 1)
+	})
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	service, method := splitFullMethod("/flyteidl.datacatalog.DataCatalog/GetArtifact")
+	assert.Equal(t, "flyteidl.datacatalog.DataCatalog", service)
+	assert.Equal(t, "GetArtifact", method)
+
+	service, method = splitFullMethod("GetArtifact")
+	assert.Empty(t, service)
+	assert.Equal(t, "GetArtifact", method)
+}
@@ -0,0 +1,71 @@
+// Package tracing traces requests to the catalog with OpenTelemetry, so that the time spent serving them can be
+// attributed to the repo calls and storage reads they issue.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flyteorg/datacatalog/pkg/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Spans are not recorded
+	ExporterNone = "none"
+	// Spans are written to stdout as JSON
+	ExporterStdout = "stdout"
+	// Spans are exported to an OTLP collector over gRPC
+	ExporterOTLP = "otlp"
+)
+
+const serviceName = "datacatalog"
+
+// Setup creates the tracer provider exporting spans with the configured exporter and registers it globally, along
+// with the W3C trace context propagator. The returned function flushes the spans not exported yet and must be called
+// on shutdown.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.SamplePercentage < 0 || cfg.SamplePercentage > 100 {
+		return nil, fmt.Errorf("invalid tracing sample percentage %d, must be between 0 and 100", cfg.SamplePercentage)
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("invalid tracing exporter %q, must be one of %s, %s or %s", cfg.Exporter, ExporterNone,
+			ExporterStdout, ExporterOTLP)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", cfg.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+		// the decision of the caller whether to trace the request is respected
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(cfg.SamplePercentage)/100))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
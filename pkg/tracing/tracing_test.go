@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/datacatalog/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSetup(t *testing.T) {
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	t.Run("none", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterNone, SamplePercentage: 100})
+		assert.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+
+		_, span := otel.Tracer("test").Start(context.Background(), "span")
+		assert.False(t, span.IsRecording())
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterStdout, SamplePercentage: 100})
+		assert.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(context.Background(), "span")
+		assert.True(t, span.IsRecording())
+		span.End()
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("not sampled", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterStdout, SamplePercentage: 0})
+		assert.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(context.Background(), "span")
+		assert.False(t, span.IsRecording())
+		assert.NoError(t, shutdown(context.Background()))
+	})
+
+	t.Run("invalid exporter", func(t *testing.T) {
+		_, err := Setup(context.Background(), config.TracingConfig{Exporter: "jaeger", SamplePercentage: 100})
+		assert.EqualError(t, err, `invalid tracing exporter "jaeger", must be one of none, stdout or otlp`)
+	})
+
+	t.Run("invalid sample percentage", func(t *testing.T) {
+		_, err := Setup(context.Background(), config.TracingConfig{Exporter: ExporterStdout, SamplePercentage: 101})
+		assert.Error(t, err)
+	})
+}